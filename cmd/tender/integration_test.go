@@ -2,6 +2,9 @@ package main
 
 import (
 	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -39,8 +42,10 @@ func TestCLICommands(t *testing.T) {
 			"add             Add a tender non-interactively (agent-friendly)",
 			"update          Update a tender non-interactively (agent-friendly)",
 			"ls              List managed tender workflows",
-			"run             Trigger an on-demand tender now via GitHub CLI",
+			"run             Trigger an on-demand tender now via the GitHub API",
 			"rm              Remove a tender workflow",
+			"doctor          Check tender workflows for problems",
+			"schedule        Show the week's scheduled runs and overlaps",
 			"help [command]  Show command help",
 		}
 
@@ -205,6 +210,81 @@ func TestCLICommands(t *testing.T) {
 		}
 	})
 
+	t.Run("tender run rejects --wait with --local", func(t *testing.T) {
+		cmd := exec.Command(binPath, "run", "--wait", "--local", "nightly")
+		cmd.Dir = t.TempDir()
+		out, err := cmd.CombinedOutput()
+		if err == nil || !strings.Contains(string(out), "use either --wait or --local") {
+			t.Fatalf("expected conflicting flag error, got %v: %s", err, out)
+		}
+	})
+
+	t.Run("tender status reads runs from the GitHub API", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		workflowDir := filepath.Join(tmpDir, ".github", "workflows")
+		if err := os.MkdirAll(workflowDir, 0o755); err != nil {
+			t.Fatalf("mkdir workflows: %v", err)
+		}
+		workflow := `name: "tender/nightly"
+on:
+  workflow_dispatch:
+jobs:
+  tender:
+    runs-on: ubuntu-latest
+    env:
+      TENDER_AGENT: "Build"
+    steps:
+      - run: opencode run --agent "$TENDER_AGENT"
+`
+		if err := os.WriteFile(filepath.Join(workflowDir, "nightly.yml"), []byte(workflow), 0o644); err != nil {
+			t.Fatalf("write workflow: %v", err)
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/repos/acme/widgets/actions/workflows/nightly.yml/runs":
+				fmt.Fprint(w, `{"workflow_runs": [{"id": 9, "event": "schedule", "status": "completed", "conclusion": "failure", "run_started_at": "2026-10-16T09:00:00Z", "updated_at": "2026-10-16T09:00:30Z"}]}`)
+			case "/repos/acme/widgets/commits":
+				fmt.Fprint(w, `[]`)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+
+		cmd := exec.Command(binPath, "status", "nightly")
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(), "GITHUB_API_URL="+server.URL, "GITHUB_REPOSITORY=acme/widgets", "GH_TOKEN=test")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("status failed: %v\n%s", err, out)
+		}
+		if !strings.Contains(string(out), "9\t2026-10-16 09:00 UTC\tfailure\t30s\tschedule\tno") {
+			t.Fatalf("unexpected status output: %s", out)
+		}
+	})
+
+	t.Run("tender logs requires name argument", func(t *testing.T) {
+		cmd := exec.Command(binPath, "logs", "--latest")
+		cmd.Dir = t.TempDir()
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err == nil {
+			t.Fatal("expected error when no name provided")
+		}
+		if !strings.Contains(stderr.String(), "usage: tender logs") {
+			t.Fatalf("expected usage error, got: %s", stderr.String())
+		}
+	})
+
+	t.Run("tender logs rejects --run with --latest", func(t *testing.T) {
+		cmd := exec.Command(binPath, "logs", "nightly", "--run", "5", "--latest")
+		cmd.Dir = t.TempDir()
+		out, err := cmd.CombinedOutput()
+		if err == nil || !strings.Contains(string(out), "use either --run or --latest") {
+			t.Fatalf("expected conflicting flag error, got %v: %s", err, out)
+		}
+	})
+
 	t.Run("tender run with non-existent tender", func(t *testing.T) {
 		tmpDir := t.TempDir()
 
@@ -328,7 +408,8 @@ func TestCLIAgentCommands(t *testing.T) {
 		if err := listCmd.Run(); err != nil {
 			t.Fatalf("ls failed: %v", err)
 		}
-		if !strings.Contains(listStdout.String(), "nightly\tTendTests\tweekly Mon at 09:00 UTC + on-demand\tnightly.yml") {
+		if !strings.Contains(listStdout.String(), "nightly\tTendTests\tweekly Mon at 09:00 UTC + on-demand\tMon ") ||
+			!strings.Contains(listStdout.String(), " 09:00 UTC\tnightly.yml") {
 			t.Fatalf("expected created tender in list output, got: %s", listStdout.String())
 		}
 		workflowPath := filepath.Join(tmpDir, ".github", "workflows", "nightly.yml")
@@ -395,11 +476,648 @@ func TestCLIAgentCommands(t *testing.T) {
 		if err := listAfterUpdateCmd.Run(); err != nil {
 			t.Fatalf("ls after update failed: %v", err)
 		}
-		if !strings.Contains(listAfterUpdateStdout.String(), "nightly\tTendTests\ton-push(main)\tnightly.yml") {
+		if !strings.Contains(listAfterUpdateStdout.String(), "nightly\tTendTests\ton-push(main)\t-\tnightly.yml") {
 			t.Fatalf("expected updated tender in list output, got: %s", listAfterUpdateStdout.String())
 		}
 	})
 
+	t.Run("tender add and update switch delivery mode and branch", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		workflowPath := filepath.Join(tmpDir, ".github", "workflows", "reviewed.yml")
+
+		addCmd := exec.Command(binPath, "add", "reviewed", "--agent", "TendTests", "--deliver", "pr")
+		addCmd.Dir = tmpDir
+		addCmd.Env = withPrependedPATH(fakeBin)
+		if out, err := addCmd.CombinedOutput(); err != nil {
+			t.Fatalf("add failed: %v\n%s", err, out)
+		}
+		workflowBytes, err := os.ReadFile(workflowPath)
+		if err != nil {
+			t.Fatalf("read created workflow: %v", err)
+		}
+		if !strings.Contains(string(workflowBytes), "- name: Open pull request") {
+			t.Fatalf("expected pull request step, got:\n%s", workflowBytes)
+		}
+
+		updateCmd := exec.Command(binPath, "update", "reviewed", "--deliver", "push")
+		updateCmd.Dir = tmpDir
+		updateCmd.Env = withPrependedPATH(fakeBin)
+		if out, err := updateCmd.CombinedOutput(); err != nil {
+			t.Fatalf("update failed: %v\n%s", err, out)
+		}
+		workflowBytes, err = os.ReadFile(workflowPath)
+		if err != nil {
+			t.Fatalf("read updated workflow: %v", err)
+		}
+		workflow := string(workflowBytes)
+		if !strings.Contains(workflow, "- name: Commit and push main") || strings.Contains(workflow, "Open pull request") {
+			t.Fatalf("expected push delivery after update, got:\n%s", workflow)
+		}
+
+		branchCmd := exec.Command(binPath, "update", "reviewed", "--branch", "develop")
+		branchCmd.Dir = tmpDir
+		branchCmd.Env = withPrependedPATH(fakeBin)
+		if out, err := branchCmd.CombinedOutput(); err != nil {
+			t.Fatalf("update --branch failed: %v\n%s", err, out)
+		}
+		workflowBytes, err = os.ReadFile(workflowPath)
+		if err != nil {
+			t.Fatalf("read updated workflow: %v", err)
+		}
+		workflow = string(workflowBytes)
+		for _, snippet := range []string{`TENDER_BRANCH: "develop"`, "- name: Commit and push develop", "group: tender-develop"} {
+			if !strings.Contains(workflow, snippet) {
+				t.Fatalf("expected %q after branch update, got:\n%s", snippet, workflow)
+			}
+		}
+
+		badCmd := exec.Command(binPath, "update", "reviewed", "--deliver", "email")
+		badCmd.Dir = tmpDir
+		badCmd.Env = withPrependedPATH(fakeBin)
+		out, err := badCmd.CombinedOutput()
+		if err == nil {
+			t.Fatal("expected invalid --deliver to fail")
+		}
+		if !strings.Contains(string(out), "invalid value for --deliver") {
+			t.Fatalf("unexpected error output: %s", out)
+		}
+	})
+
+	t.Run("tender add and update schedule in a timezone", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		workflowPath := filepath.Join(tmpDir, ".github", "workflows", "standup.yml")
+		run := func(args ...string) string {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("tender %v failed: %v\n%s", args, err, out)
+			}
+			return string(out)
+		}
+		readWorkflow := func() string {
+			t.Helper()
+			workflowBytes, err := os.ReadFile(workflowPath)
+			if err != nil {
+				t.Fatalf("read workflow: %v", err)
+			}
+			return string(workflowBytes)
+		}
+
+		// Asia/Tokyo has no daylight-saving time, so the conversion is stable.
+		run("add", "standup", "--agent", "TendTests", "--cron", "0 9 * * 1-5", "--timezone", "Asia/Tokyo")
+		workflow := readWorkflow()
+		for _, snippet := range []string{`- cron: "0 0 * * 1-5"`, `TENDER_TIMEZONE: "Asia/Tokyo"`, `TENDER_LOCAL_CRON: "0 9 * * 1-5"`} {
+			if !strings.Contains(workflow, snippet) {
+				t.Fatalf("expected %q in workflow, got:\n%s", snippet, workflow)
+			}
+		}
+		if out := run("ls"); !strings.Contains(out, "at 09:00 Asia/Tokyo on weekdays + on-demand") {
+			t.Fatalf("expected local schedule in ls output, got:\n%s", out)
+		}
+
+		run("update", "standup", "--cron", "30 8 * * *")
+		if workflow := readWorkflow(); !strings.Contains(workflow, `- cron: "30 23 * * *"`) || !strings.Contains(workflow, `TENDER_LOCAL_CRON: "30 8 * * *"`) {
+			t.Fatalf("expected --cron to stay in Asia/Tokyo, got:\n%s", workflow)
+		}
+
+		run("update", "standup", "--timezone", "UTC")
+		workflow = readWorkflow()
+		if !strings.Contains(workflow, `- cron: "30 8 * * *"`) || strings.Contains(workflow, "TENDER_TIMEZONE") {
+			t.Fatalf("expected a plain UTC schedule, got:\n%s", workflow)
+		}
+
+		cmd := exec.Command(binPath, "update", "standup", "--timezone", "Mars/Olympus")
+		cmd.Dir = tmpDir
+		cmd.Env = withPrependedPATH(fakeBin)
+		out, err := cmd.CombinedOutput()
+		if err == nil || !strings.Contains(string(out), `unknown timezone "Mars/Olympus"`) {
+			t.Fatalf("expected unknown timezone error, got %v: %s", err, out)
+		}
+	})
+
+	t.Run("tender add and update with several schedules", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		workflowPath := filepath.Join(tmpDir, ".github", "workflows", "sweeper.yml")
+		run := func(args ...string) string {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("tender %v failed: %v\n%s", args, err, out)
+			}
+			return string(out)
+		}
+
+		run("add", "sweeper", "--agent", "TendTests", "--cron", "0 9 * * 1-5", "--cron", "0 3 * * 0")
+		workflowBytes, err := os.ReadFile(workflowPath)
+		if err != nil {
+			t.Fatalf("read workflow: %v", err)
+		}
+		if !strings.Contains(string(workflowBytes), "schedule:\n    - cron: \"0 9 * * 1-5\"\n    - cron: \"0 3 * * 0\"\n") {
+			t.Fatalf("expected both schedules in workflow, got:\n%s", workflowBytes)
+		}
+		if out := run("ls"); !strings.Contains(out, "at 09:00 UTC on weekdays + weekly Sun at 03:00 UTC + on-demand") {
+			t.Fatalf("expected both schedules in ls output, got:\n%s", out)
+		}
+
+		run("update", "sweeper", "--cron", "30 6 * * *")
+		workflowBytes, err = os.ReadFile(workflowPath)
+		if err != nil {
+			t.Fatalf("read workflow: %v", err)
+		}
+		if got := strings.Count(string(workflowBytes), "- cron:"); got != 1 || !strings.Contains(string(workflowBytes), `- cron: "30 6 * * *"`) {
+			t.Fatalf("expected --cron to replace every schedule, got:\n%s", workflowBytes)
+		}
+	})
+
+	t.Run("tender doctor exits 1 on warnings", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		workflowDir := filepath.Join(tmpDir, ".github", "workflows")
+		if err := os.MkdirAll(workflowDir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+
+		cmd := exec.Command(binPath, "doctor")
+		cmd.Dir = tmpDir
+		out, err := cmd.CombinedOutput()
+		if err != nil || !strings.Contains(string(out), "No problems found in 0 tender(s).") {
+			t.Fatalf("expected a clean report, got %v: %s", err, out)
+		}
+
+		broken := "name: \"tender/broken\"\non: [workflow_dispatch]\njobs:\n  tender:\n    env:\n      TENDER_AGENT: \"Build\"\n"
+		if err := os.WriteFile(filepath.Join(workflowDir, "broken.yml"), []byte(broken), 0o644); err != nil {
+			t.Fatalf("write workflow: %v", err)
+		}
+		cmd = exec.Command(binPath, "doctor")
+		cmd.Dir = tmpDir
+		out, err = cmd.CombinedOutput()
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+			t.Fatalf("expected exit code 1, got %v: %s", err, out)
+		}
+		if !strings.Contains(string(out), "warning: broken.yml: cannot be loaded:") {
+			t.Fatalf("unexpected doctor output: %s", out)
+		}
+	})
+
+	t.Run("tender add and update with a prompt file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		workflowPath := filepath.Join(tmpDir, ".github", "workflows", "docs.yml")
+		promptPath := filepath.Join(tmpDir, ".tender", "prompts", "docs.md")
+		run := func(args ...string) string {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("tender %v failed: %v\n%s", args, err, out)
+			}
+			return string(out)
+		}
+		readFile := func(path string) string {
+			t.Helper()
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read %s: %v", path, err)
+			}
+			return string(data)
+		}
+
+		run("add", "docs", "--agent", "TendTests", "--prompt", "Refresh the README.", "--prompt-file", ".tender/prompts/docs.md")
+		if got := readFile(promptPath); got != "Refresh the README.\n" {
+			t.Fatalf("unexpected prompt file %q", got)
+		}
+		if workflow := readFile(workflowPath); !strings.Contains(workflow, `TENDER_PROMPT: ""`) || !strings.Contains(workflow, `TENDER_PROMPT_FILE: ".tender/prompts/docs.md"`) {
+			t.Fatalf("expected the workflow to reference the prompt file, got:\n%s", workflow)
+		}
+
+		run("update", "docs", "--prompt", "Inline again.")
+		if workflow := readFile(workflowPath); !strings.Contains(workflow, `TENDER_PROMPT: "Inline again."`) || strings.Contains(workflow, "TENDER_PROMPT_FILE") {
+			t.Fatalf("expected --prompt to switch back to an inline prompt, got:\n%s", workflow)
+		}
+
+		run("update", "docs", "--prompt-file", ".tender/prompts/docs.md")
+		if workflow := readFile(workflowPath); !strings.Contains(workflow, `TENDER_PROMPT: ""`) || !strings.Contains(workflow, "TENDER_PROMPT_FILE") {
+			t.Fatalf("expected the existing prompt file to be used, got:\n%s", workflow)
+		}
+		if got := readFile(promptPath); got != "Refresh the README.\n" {
+			t.Fatalf("an existing prompt file must not be overwritten, got %q", got)
+		}
+	})
+
+	t.Run("tender add, update and run with dispatch inputs", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		workflowPath := filepath.Join(tmpDir, ".github", "workflows", "docs.yml")
+		tender := func(args ...string) (string, error) {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			return string(out), err
+		}
+		readWorkflow := func() string {
+			t.Helper()
+			data, err := os.ReadFile(workflowPath)
+			if err != nil {
+				t.Fatalf("read workflow: %v", err)
+			}
+			return string(data)
+		}
+
+		if out, err := tender("add", "docs", "--agent", "TendTests", "--prompt", "Cover {{input.scope}}", "--input", "scope:choice:docs,tests", "--input", "dry_run:boolean"); err != nil {
+			t.Fatalf("tender add failed: %v\n%s", err, out)
+		}
+		workflow := readWorkflow()
+		for _, want := range []string{"      scope:\n", "        type: choice\n", "      dry_run:\n", "TENDER_INPUT_DRY_RUN:"} {
+			if !strings.Contains(workflow, want) {
+				t.Fatalf("expected %q in workflow:\n%s", want, workflow)
+			}
+		}
+
+		out, err := tender("run", "--input", "scope=all", "docs")
+		if err == nil || !strings.Contains(out, `input "scope" must be one of docs, tests`) {
+			t.Fatalf("expected tender run to reject the value, got err=%v\n%s", err, out)
+		}
+
+		if out, err := tender("update", "docs", "--prompt", "Cover everything", "--clear-inputs"); err != nil {
+			t.Fatalf("tender update failed: %v\n%s", err, out)
+		}
+		if workflow := readWorkflow(); strings.Contains(workflow, "TENDER_INPUT_") || strings.Contains(workflow, "dry_run") {
+			t.Fatalf("expected --clear-inputs to drop the inputs, got:\n%s", workflow)
+		}
+	})
+
+	t.Run("tender add and update with issue and pull request triggers", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		run := func(args ...string) string {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("tender %v failed: %v\n%s", args, err, out)
+			}
+			return string(out)
+		}
+
+		run("add", "triage", "--agent", "TendTests", "--manual", "false", "--issue-label", "true", "--pr-comment", "true", "--prompt", "Triage {{event_title}}")
+		if out := run("ls"); !strings.Contains(out, "on-label(tender:triage) + on-comment(/tender triage)") {
+			t.Fatalf("expected event triggers in ls output:\n%s", out)
+		}
+
+		run("update", "triage", "--pr-comment", "false", "--pr-open", "true")
+		data, err := os.ReadFile(filepath.Join(tmpDir, ".github", "workflows", "triage.yml"))
+		if err != nil {
+			t.Fatalf("read workflow: %v", err)
+		}
+		if workflow := string(data); strings.Contains(workflow, "issue_comment:") || !strings.Contains(workflow, "pull_request:") || !strings.Contains(workflow, "issues:") {
+			t.Fatalf("unexpected triggers after update:\n%s", workflow)
+		}
+	})
+
+	t.Run("add and update chain tenders with --after", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		run := func(args ...string) (string, error) {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			return string(out), err
+		}
+
+		if out, err := run("add", "implement", "--agent", "TendTests", "--manual", "false", "--after", "write-tests"); err == nil || !strings.Contains(out, "does not exist") {
+			t.Fatalf("expected missing upstream error, got %v:\n%s", err, out)
+		}
+		for _, args := range [][]string{
+			{"add", "write-tests", "--agent", "TendTests", "--cron", "0 9 * * *"},
+			{"add", "implement", "--agent", "TendTests", "--manual", "false", "--after", "write-tests", "--after-when", "pushed"},
+		} {
+			if out, err := run(args...); err != nil {
+				t.Fatalf("tender %v failed: %v\n%s", args, err, out)
+			}
+		}
+		if out, _ := run("ls"); !strings.Contains(out, "after(write-tests, pushed)") {
+			t.Fatalf("expected the chain in ls output:\n%s", out)
+		}
+		if out, err := run("update", "write-tests", "--after", "implement"); err == nil || !strings.Contains(out, "tender chain write-tests -> implement -> write-tests is a cycle") {
+			t.Fatalf("expected cycle error, got %v:\n%s", err, out)
+		}
+		if out, err := run("update", "implement", "--after-when", "completed"); err != nil {
+			t.Fatalf("update failed: %v\n%s", err, out)
+		}
+		data, err := os.ReadFile(filepath.Join(tmpDir, ".github", "workflows", "implement.yml"))
+		if err != nil {
+			t.Fatalf("read workflow: %v", err)
+		}
+		if workflow := string(data); !strings.Contains(workflow, "workflow_run:") || strings.Contains(workflow, "TENDER_AFTER_WHEN") {
+			t.Fatalf("unexpected chain after update:\n%s", workflow)
+		}
+	})
+
+	t.Run("add and update gate delivery with --verify", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		run := func(args ...string) string {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("tender %v failed: %v\n%s", args, err, out)
+			}
+			return string(out)
+		}
+		workflow := func() string {
+			t.Helper()
+			data, err := os.ReadFile(filepath.Join(tmpDir, ".github", "workflows", "nightly.yml"))
+			if err != nil {
+				t.Fatalf("read workflow: %v", err)
+			}
+			return string(data)
+		}
+
+		run("add", "nightly", "--agent", "TendTests", "--verify", "make check-fast", "--verify-failure", "branch")
+		if got := workflow(); !strings.Contains(got, "TENDER_VERIFY: \"make check-fast\"") || !strings.Contains(got, "- name: Push unverified changes") {
+			t.Fatalf("expected the verify gate:\n%s", got)
+		}
+		run("update", "nightly", "--verify", "")
+		if got := workflow(); strings.Contains(got, "TENDER_VERIFY") || strings.Contains(got, "Verify changes") || strings.Contains(got, "Push unverified changes") {
+			t.Fatalf("expected the verify gate to be removed:\n%s", got)
+		}
+	})
+
+	t.Run("add and update path and size guardrails", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		run := func(args ...string) string {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("tender %v failed: %v\n%s", args, err, out)
+			}
+			return string(out)
+		}
+		workflow := func() string {
+			t.Helper()
+			data, err := os.ReadFile(filepath.Join(tmpDir, ".github", "workflows", "docs.yml"))
+			if err != nil {
+				t.Fatalf("read workflow: %v", err)
+			}
+			return string(data)
+		}
+
+		run("add", "docs", "--agent", "TendTests", "--allow-path", "docs/**", "--allow-path", "*.md", "--deny-path", ".github/**", "--max-files", "10")
+		if got := workflow(); !strings.Contains(got, `TENDER_ALLOW_PATHS: "docs/**; *.md"`) || !strings.Contains(got, `TENDER_MAX_FILES: "10"`) || !strings.Contains(got, "- name: Check guardrails") {
+			t.Fatalf("expected the guardrails:\n%s", got)
+		}
+		run("update", "docs", "--allow-path", "", "--deny-path", "", "--max-files", "0")
+		if got := workflow(); strings.Contains(got, "TENDER_ALLOW_PATHS") || strings.Contains(got, "TENDER_MAX_FILES") || strings.Contains(got, "Check guardrails") {
+			t.Fatalf("expected the guardrails to be removed:\n%s", got)
+		}
+	})
+
+	t.Run("add a secret scan and run tender scan-diff", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not found in PATH")
+		}
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		run := func(wantCode int, args ...string) string {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			code := 0
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				code = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatalf("tender %v failed: %v\n%s", args, err, out)
+			}
+			if code != wantCode {
+				t.Fatalf("tender %v exited %d, want %d\n%s", args, code, wantCode, out)
+			}
+			return string(out)
+		}
+		git := func(args ...string) {
+			t.Helper()
+			cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+			cmd.Dir = tmpDir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+		}
+		workflow := filepath.Join(tmpDir, ".github", "workflows", "docs.yml")
+
+		run(0, "add", "docs", "--agent", "TendTests", "--secret-scan", "true")
+		if data, _ := os.ReadFile(workflow); !strings.Contains(string(data), `TENDER_SECRET_SCAN: "true"`) || !strings.Contains(string(data), "- name: Scan for secrets") {
+			t.Fatalf("expected the secret scan:\n%s", data)
+		}
+		run(0, "update", "docs", "--secret-scan", "false")
+		if data, _ := os.ReadFile(workflow); strings.Contains(string(data), "Scan for secrets") {
+			t.Fatalf("expected the secret scan to be removed:\n%s", data)
+		}
+
+		git("init", "-q")
+		git("add", "-A")
+		git("commit", "-q", "-m", "base")
+		if out := run(0, "scan-diff"); !strings.Contains(out, "No secrets found") {
+			t.Fatalf("expected a clean scan:\n%s", out)
+		}
+		key := "AKIA" + "ABCDEFGHIJKLMNOP"
+		if err := os.WriteFile(filepath.Join(tmpDir, "config.txt"), []byte("key="+key+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "-A")
+		out := run(1, "scan-diff", "--base", "HEAD")
+		if !strings.Contains(out, "config.txt:1: aws-access-key AKIA********") || strings.Contains(out, key) {
+			t.Fatalf("expected a redacted finding:\n%s", out)
+		}
+		run(2, "scan-diff", "--base", "missing")
+	})
+
+	t.Run("tender revert undoes a tender's commits locally", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not found in PATH")
+		}
+		tmpDir := t.TempDir()
+		git := func(args ...string) string {
+			t.Helper()
+			cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+			cmd.Dir = tmpDir
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+			return strings.TrimSpace(string(out))
+		}
+		revert := func(stdin string, args ...string) (string, error) {
+			t.Helper()
+			cmd := exec.Command(binPath, append([]string{"revert"}, args...)...)
+			cmd.Dir = tmpDir
+			cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+			cmd.Stdin = strings.NewReader(stdin)
+			out, err := cmd.CombinedOutput()
+			return string(out), err
+		}
+		git("init", "-q")
+		if err := os.WriteFile(filepath.Join(tmpDir, "notes.md"), []byte("v1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "-A")
+		git("commit", "-q", "-m", "initial")
+		if err := os.WriteFile(filepath.Join(tmpDir, "notes.md"), []byte("v2\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("commit", "-q", "-am", "tender(docs): autonomous update")
+		head := git("rev-parse", "HEAD")
+
+		out, err := revert("n\n", "docs")
+		if err != nil || !strings.Contains(out, "Reverting 1 commit(s) of docs:") || !strings.Contains(out, "-v2\n+v1") || !strings.Contains(out, "cancelled") {
+			t.Fatalf("expected a cancelled preview, got %v\n%s", err, out)
+		}
+		if git("rev-parse", "HEAD") != head {
+			t.Fatalf("expected no commit after cancelling")
+		}
+		out, err = revert("", "docs", "--last", "1", "--yes")
+		if err != nil || !strings.Contains(out, "created 1 revert commit(s)") {
+			t.Fatalf("expected a revert commit, got %v\n%s", err, out)
+		}
+		if got := git("log", "-1", "--format=%s"); got != `Revert "tender(docs): autonomous update"` {
+			t.Fatalf("unexpected revert commit %q", got)
+		}
+		if out, err := revert("", "docs", "--yes"); err == nil || !strings.Contains(out, "no \"tender(docs): autonomous update\" commits") {
+			t.Fatalf("expected nothing left to revert, got %v\n%s", err, out)
+		}
+		if out, err := revert("", "docs", "--last", "1", "--since", "2026-01-01"); err == nil || !strings.Contains(out, "only one of") {
+			t.Fatalf("expected conflicting selection error, got %v\n%s", err, out)
+		}
+	})
+
+	t.Run("tender history reports tender commits from git log", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not found in PATH")
+		}
+		tmpDir := t.TempDir()
+		run := func(args ...string) (string, error) {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			out, err := cmd.CombinedOutput()
+			return string(out), err
+		}
+		git := func(args ...string) {
+			t.Helper()
+			cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+			cmd.Dir = tmpDir
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v failed: %v\n%s", args, err, out)
+			}
+		}
+		git("init", "-q")
+		if err := os.WriteFile(filepath.Join(tmpDir, "notes.md"), []byte("one\ntwo\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", "-A")
+		git("commit", "-q", "-m", "tender(docs): autonomous update")
+
+		out, err := run("history")
+		if err != nil || !strings.Contains(out, "docs\t1\t1\t+2\t-0\t") || !strings.Contains(out, "notes.md") {
+			t.Fatalf("unexpected history: %v\n%s", err, out)
+		}
+		out, err = run("history", "docs", "--json")
+		if err != nil || !strings.Contains(out, `"name": "docs"`) || !strings.Contains(out, `"path": "notes.md"`) {
+			t.Fatalf("unexpected JSON history: %v\n%s", err, out)
+		}
+		if out, err := run("history", "a", "b"); err == nil || !strings.Contains(out, "usage: tender history") {
+			t.Fatalf("expected usage error, got %v\n%s", err, out)
+		}
+	})
+
+	t.Run("tender schedule reports and staggers overlapping runs", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		run := func(args ...string) string {
+			t.Helper()
+			cmd := exec.Command(binPath, args...)
+			cmd.Dir = tmpDir
+			cmd.Env = withPrependedPATH(fakeBin)
+			out, err := cmd.CombinedOutput()
+			if err != nil {
+				t.Fatalf("tender %v failed: %v\n%s", args, err, out)
+			}
+			return string(out)
+		}
+
+		run("add", "nightly", "--agent", "TendTests", "--cron", "0 9 * * *", "--timeout-minutes", "30")
+		run("add", "standup", "--agent", "TendTests", "--cron", "0 9 * * *", "--timeout-minutes", "60")
+		if out := run("schedule"); !strings.Contains(out, "standup starts while nightly may still run (tender-main): 7 time(s) this week, waiting up to 30 min") {
+			t.Fatalf("expected an overlap report, got:\n%s", out)
+		}
+		if out := run("schedule", "--stagger"); !strings.Contains(out, "standup: 0 9 * * * -> 30 9 * * *") {
+			t.Fatalf("expected a stagger suggestion, got:\n%s", out)
+		}
+		run("schedule", "--apply")
+		if out := run("schedule"); !strings.Contains(out, "No overlapping runs.") {
+			t.Fatalf("expected no overlaps after --apply, got:\n%s", out)
+		}
+	})
+
+	t.Run("tender add validates --model against opencode.json", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		fakeBin := installFakeOpenCodeForCLI(t, tmpDir, []string{"TendTests"})
+		config := `{"provider": {"groq": {}}}`
+		if err := os.WriteFile(filepath.Join(tmpDir, "opencode.json"), []byte(config), 0o644); err != nil {
+			t.Fatalf("write opencode.json: %v", err)
+		}
+
+		badCmd := exec.Command(binPath, "add", "lint", "--agent", "TendTests", "--model", "openai/gpt-4.1")
+		badCmd.Dir = tmpDir
+		badCmd.Env = withPrependedPATH(fakeBin)
+		out, err := badCmd.CombinedOutput()
+		if err == nil {
+			t.Fatalf("expected unconfigured provider to fail:\n%s", out)
+		}
+		if !strings.Contains(string(out), `provider "openai" is not configured in opencode.json`) {
+			t.Fatalf("unexpected error output: %s", out)
+		}
+
+		addCmd := exec.Command(binPath, "add", "lint", "--agent", "TendTests", "--model", "groq/llama-3.1-8b-instant")
+		addCmd.Dir = tmpDir
+		addCmd.Env = withPrependedPATH(fakeBin)
+		if out, err := addCmd.CombinedOutput(); err != nil {
+			t.Fatalf("add failed: %v\n%s", err, out)
+		}
+		workflowBytes, err := os.ReadFile(filepath.Join(tmpDir, ".github", "workflows", "lint.yml"))
+		if err != nil {
+			t.Fatalf("read created workflow: %v", err)
+		}
+		for _, snippet := range []string{`TENDER_MODEL: "groq/llama-3.1-8b-instant"`, "GROQ_API_KEY: ${{ secrets.GROQ_API_KEY }}"} {
+			if !strings.Contains(string(workflowBytes), snippet) {
+				t.Fatalf("expected %q in workflow, got:\n%s", snippet, workflowBytes)
+			}
+		}
+	})
+
 	t.Run("tender add rejects reserved system agent", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		cmd := exec.Command(binPath, "add", "--name", "nightly", "--agent", "Build")
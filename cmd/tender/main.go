@@ -1,21 +1,31 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"tender/internal/tender"
 )
 
 const (
-	addUsageLine    = "usage: tender add [--name <name>] --agent <agent> [--prompt \"...\"] [--cron \"...\"] [--manual true|false] [--push true|false] [--timeout-minutes <minutes>] [<name>]"
-	updateUsageLine = "usage: tender update <name> [--name <new-name>] [--agent <agent>] [--prompt \"...\"] [--cron \"...\"] [--clear-cron] [--manual true|false] [--push true|false] [--timeout-minutes <minutes>]"
-	runUsageLine    = "usage: tender run [--prompt \"...\"] <name>"
-	rmUsageLine     = "usage: tender rm [--yes] <name>"
+	addUsageLine      = "usage: tender add [--name <name>] --agent <agent> [--prompt \"...\"] [--prompt-file <path>] [--cron \"...\"]... [--timezone <zone>] [--manual true|false] [--push true|false] [--issue-label true|false] [--pr-comment true|false] [--pr-open true|false] [--after <tender>] [--after-when completed|success|pushed] [--deliver push|pr] [--verify <command>] [--verify-failure discard|branch] [--allow-path <glob>]... [--deny-path <glob>]... [--max-files <n>] [--max-lines <n>] [--secret-scan true|false] [--branch <branch>] [--model <provider/model>] [--timeout-minutes <minutes>] [--input <name>[:type][=default]]... [<name>]"
+	updateUsageLine   = "usage: tender update <name> [--name <new-name>] [--agent <agent>] [--prompt \"...\"] [--prompt-file <path>] [--cron \"...\"]... [--timezone <zone>] [--clear-cron] [--manual true|false] [--push true|false] [--issue-label true|false] [--pr-comment true|false] [--pr-open true|false] [--after <tender>] [--after-when completed|success|pushed] [--deliver push|pr] [--verify <command>] [--verify-failure discard|branch] [--allow-path <glob>]... [--deny-path <glob>]... [--max-files <n>] [--max-lines <n>] [--secret-scan true|false] [--branch <branch>] [--model <provider/model>] [--timeout-minutes <minutes>] [--input <name>[:type][=default]]... [--clear-inputs]"
+	runUsageLine      = "usage: tender run [--prompt \"...\"] [--input <key>=<value>]... [--wait|--local] <name>"
+	rmUsageLine       = "usage: tender rm [--yes] <name>"
+	statusUsageLine   = "usage: tender status [<name>]"
+	logsUsageLine     = "usage: tender logs <name> [--run <id>|--latest] [--step opencode]"
+	doctorUsageLine   = "usage: tender doctor"
+	scheduleUsageLine = "usage: tender schedule [--stagger|--apply]"
+	scanDiffUsageLine = "usage: tender scan-diff [--base <rev>]"
+	revertUsageLine   = "usage: tender revert <name> [--last <n>|--since <date>|--run <id>] [--yes]"
+	historyUsageLine  = "usage: tender history [<name>] [--json]"
 )
 
 func main() {
@@ -50,16 +60,52 @@ func main() {
 			"--name":            {},
 			"-prompt":           {},
 			"--prompt":          {},
+			"-prompt-file":      {},
+			"--prompt-file":     {},
 			"-cron":             {},
 			"--cron":            {},
+			"-timezone":         {},
+			"--timezone":        {},
 			"-manual":           {},
 			"--manual":          {},
 			"-push":             {},
 			"--push":            {},
+			"-issue-label":      {},
+			"--issue-label":     {},
+			"-pr-comment":       {},
+			"--pr-comment":      {},
+			"-pr-open":          {},
+			"--pr-open":         {},
+			"-after":            {},
+			"--after":           {},
+			"-after-when":       {},
+			"--after-when":      {},
+			"-deliver":          {},
+			"--deliver":         {},
+			"-verify":           {},
+			"--verify":          {},
+			"-verify-failure":   {},
+			"--verify-failure":  {},
+			"-allow-path":       {},
+			"--allow-path":      {},
+			"-deny-path":        {},
+			"--deny-path":       {},
+			"-max-files":        {},
+			"--max-files":       {},
+			"-max-lines":        {},
+			"--max-lines":       {},
+			"-secret-scan":      {},
+			"--secret-scan":     {},
+			"-branch":           {},
+			"--branch":          {},
+			"-model":            {},
+			"--model":           {},
 			"-timeout-minutes":  {},
 			"--timeout-minutes": {},
 			"-timeout":          {},
 			"--timeout":         {},
+			"-input":            {},
+			"--input":           {},
 		}) {
 			usage()
 			fmt.Println()
@@ -70,12 +116,33 @@ func main() {
 		name := fs.String("name", "", "tender name")
 		agent := fs.String("agent", "", "OpenCode agent name")
 		prompt := fs.String("prompt", "", "optional default prompt")
-		cron := fs.String("cron", "", "optional cron schedule (5 fields, UTC)")
+		promptFile := fs.String("prompt-file", "", "repository file the workflow reads the prompt from, e.g. .tender/prompts/<name>.md")
+		var crons stringsFlag
+		fs.Var(&crons, "cron", "optional cron schedule (5 fields, UTC unless --timezone is set); repeat for several")
+		timezone := fs.String("timezone", "", "IANA timezone the cron is written in, e.g. Europe/Berlin (default UTC)")
 		manual := fs.String("manual", "", "set workflow_dispatch trigger (true/false)")
 		push := fs.String("push", "", "set push-to-main trigger (true/false)")
+		issueLabel := fs.String("issue-label", "", "run when an issue is labelled tender:<name> (true/false)")
+		prComment := fs.String("pr-comment", "", "run on a `/tender <name>` pull request comment (true/false)")
+		prOpen := fs.String("pr-open", "", "run when a pull request is opened against the branch (true/false)")
+		after := fs.String("after", "", "run after this tender's workflow completes")
+		afterWhen := fs.String("after-when", "", "which upstream runs start this one (completed/success/pushed)")
+		deliver := fs.String("deliver", "", "how changes land: push to the branch or open a pull request (push/pr)")
+		verify := fs.String("verify", "", "command that must pass before changes are delivered, e.g. \"make check-fast\"")
+		verifyFailure := fs.String("verify-failure", "", "what happens to changes that fail --verify (discard/branch)")
+		var allowPaths, denyPaths stringsFlag
+		fs.Var(&allowPaths, "allow-path", "path glob runs may change, e.g. \"docs/**\"; repeat for several")
+		fs.Var(&denyPaths, "deny-path", "path glob runs must not change, e.g. \".github/**\"; repeat for several")
+		maxFiles := fs.Int("max-files", 0, "most files a run may change (default no limit)")
+		maxLines := fs.Int("max-lines", 0, "most lines a run may add and remove (default no limit)")
+		secretScan := fs.String("secret-scan", "", "scan changes for secrets before delivering them (true/false)")
+		branch := fs.String("branch", "", "target branch (defaults to the repository's default branch)")
+		model := fs.String("model", "", "OpenCode model as provider/model (defaults to the agent's model)")
 		timeoutMinutes := tender.DefaultTimeoutMinutes
 		fs.IntVar(&timeoutMinutes, "timeout-minutes", tender.DefaultTimeoutMinutes, "job timeout in minutes")
 		fs.IntVar(&timeoutMinutes, "timeout", tender.DefaultTimeoutMinutes, "alias for --timeout-minutes")
+		var inputs stringsFlag
+		fs.Var(&inputs, "input", "workflow_dispatch input as name[:string|:boolean|:choice:a,b][=default]; repeat for several")
 		positionalName := ""
 		if len(rawArgs) > 0 && !strings.HasPrefix(rawArgs[0], "-") {
 			positionalName = strings.TrimSpace(rawArgs[0])
@@ -122,6 +189,66 @@ func main() {
 			}
 			pushValue = b
 		}
+		issueLabelValue := false
+		if isFlagSet(fs, "issue-label") {
+			b, err := parseBoolFlag(*issueLabel, "issue-label")
+			if err != nil {
+				fail(err)
+			}
+			issueLabelValue = b
+		}
+		prCommentValue := false
+		if isFlagSet(fs, "pr-comment") {
+			b, err := parseBoolFlag(*prComment, "pr-comment")
+			if err != nil {
+				fail(err)
+			}
+			prCommentValue = b
+		}
+		prOpenValue := false
+		if isFlagSet(fs, "pr-open") {
+			b, err := parseBoolFlag(*prOpen, "pr-open")
+			if err != nil {
+				fail(err)
+			}
+			prOpenValue = b
+		}
+		afterWhenValue := ""
+		if isFlagSet(fs, "after-when") {
+			w, err := parseAfterWhenFlag(*afterWhen)
+			if err != nil {
+				fail(err)
+			}
+			afterWhenValue = w
+		}
+		deliveryValue := tender.DeliveryPush
+		if isFlagSet(fs, "deliver") {
+			d, err := parseDeliveryFlag(*deliver)
+			if err != nil {
+				fail(err)
+			}
+			deliveryValue = d
+		}
+		verifyFailureValue := ""
+		if isFlagSet(fs, "verify-failure") {
+			v, err := parseVerifyFailureFlag(*verifyFailure)
+			if err != nil {
+				fail(err)
+			}
+			verifyFailureValue = v
+		}
+		secretScanValue := false
+		if isFlagSet(fs, "secret-scan") {
+			b, err := parseBoolFlag(*secretScan, "secret-scan")
+			if err != nil {
+				fail(err)
+			}
+			secretScanValue = b
+		}
+		branchValue := tender.DetectDefaultBranch(root)
+		if isFlagSet(fs, "branch") {
+			branchValue = strings.TrimSpace(*branch)
+		}
 		timeoutValue, err := parseTimeoutMinutesFlag(timeoutMinutes)
 		if err != nil {
 			fail(err)
@@ -131,15 +258,51 @@ func main() {
 		if err := requireCustomAgent(root, agentName); err != nil {
 			fail(err)
 		}
-		saved, err := tender.SaveNewTender(root, tender.Tender{
+		modelValue := strings.TrimSpace(*model)
+		if err := tender.ValidateModelForRepo(root, modelValue); err != nil {
+			fail(err)
+		}
+		if isFlagSet(fs, "timezone") && len(crons) == 0 {
+			fail(fmt.Errorf("--timezone requires --cron"))
+		}
+		declared, err := parseInputFlags(inputs)
+		if err != nil {
+			fail(err)
+		}
+		t, err := tender.SetSchedule(tender.Tender{
 			Name:           finalName,
 			Agent:          agentName,
 			Prompt:         strings.TrimSpace(*prompt),
-			Cron:           strings.TrimSpace(*cron),
 			Manual:         manualValue,
+			Inputs:         declared,
 			Push:           pushValue,
+			IssueLabel:     issueLabelValue,
+			PRComment:      prCommentValue,
+			PullRequest:    prOpenValue,
+			After:          strings.TrimSpace(*after),
+			AfterWhen:      afterWhenValue,
+			Delivery:       deliveryValue,
+			Verify:         strings.TrimSpace(*verify),
+			VerifyFailure:  verifyFailureValue,
+			AllowPaths:     allowPaths,
+			DenyPaths:      denyPaths,
+			MaxFiles:       *maxFiles,
+			MaxLines:       *maxLines,
+			SecretScan:     secretScanValue,
+			Branch:         branchValue,
+			Model:          modelValue,
+			Provider:       tender.ModelProvider(modelValue),
 			TimeoutMinutes: timeoutValue,
-		})
+		}, crons, *timezone, time.Now())
+		if err != nil {
+			fail(err)
+		}
+		if strings.TrimSpace(*promptFile) != "" {
+			if t, err = tender.UsePromptFile(root, t, *promptFile, *prompt); err != nil {
+				fail(err)
+			}
+		}
+		saved, err := tender.SaveNewTender(root, t)
 		if err != nil {
 			fail(err)
 		}
@@ -154,18 +317,54 @@ func main() {
 			"--agent":           {},
 			"-prompt":           {},
 			"--prompt":          {},
+			"-prompt-file":      {},
+			"--prompt-file":     {},
 			"-cron":             {},
 			"--cron":            {},
+			"-timezone":         {},
+			"--timezone":        {},
 			"-manual":           {},
 			"--manual":          {},
 			"-push":             {},
 			"--push":            {},
+			"-issue-label":      {},
+			"--issue-label":     {},
+			"-pr-comment":       {},
+			"--pr-comment":      {},
+			"-pr-open":          {},
+			"--pr-open":         {},
+			"-after":            {},
+			"--after":           {},
+			"-after-when":       {},
+			"--after-when":      {},
+			"-deliver":          {},
+			"--deliver":         {},
+			"-verify":           {},
+			"--verify":          {},
+			"-verify-failure":   {},
+			"--verify-failure":  {},
+			"-allow-path":       {},
+			"--allow-path":      {},
+			"-deny-path":        {},
+			"--deny-path":       {},
+			"-max-files":        {},
+			"--max-files":       {},
+			"-max-lines":        {},
+			"--max-lines":       {},
+			"-secret-scan":      {},
+			"--secret-scan":     {},
+			"-branch":           {},
+			"--branch":          {},
+			"-model":            {},
+			"--model":           {},
 			"-clear-cron":       {},
 			"--clear-cron":      {},
 			"-timeout-minutes":  {},
 			"--timeout-minutes": {},
 			"-timeout":          {},
 			"--timeout":         {},
+			"-input":            {},
+			"--input":           {},
 		}) {
 			usage()
 			fmt.Println()
@@ -176,13 +375,35 @@ func main() {
 		name := fs.String("name", "", "new tender name")
 		agent := fs.String("agent", "", "OpenCode agent name")
 		prompt := fs.String("prompt", "", "default prompt (set empty string to clear)")
-		cron := fs.String("cron", "", "cron schedule (5 fields, UTC)")
+		promptFile := fs.String("prompt-file", "", "repository file the workflow reads the prompt from (set empty string to clear)")
+		var crons stringsFlag
+		fs.Var(&crons, "cron", "cron schedule (5 fields, UTC unless the tender has a timezone); repeat for several, replacing the current ones")
+		timezone := fs.String("timezone", "", "IANA timezone the cron is written in; UTC or empty schedules in UTC")
 		clearCron := fs.Bool("clear-cron", false, "remove schedule")
 		manual := fs.String("manual", "", "set workflow_dispatch trigger (true/false)")
 		push := fs.String("push", "", "set push-to-main trigger (true/false)")
+		issueLabel := fs.String("issue-label", "", "run when an issue is labelled tender:<name> (true/false)")
+		prComment := fs.String("pr-comment", "", "run on a `/tender <name>` pull request comment (true/false)")
+		prOpen := fs.String("pr-open", "", "run when a pull request is opened against the branch (true/false)")
+		after := fs.String("after", "", "run after this tender's workflow completes (set empty string to clear)")
+		afterWhen := fs.String("after-when", "", "which upstream runs start this one (completed/success/pushed)")
+		deliver := fs.String("deliver", "", "how changes land: push to the branch or open a pull request (push/pr)")
+		verify := fs.String("verify", "", "command that must pass before changes are delivered (set empty string to clear)")
+		verifyFailure := fs.String("verify-failure", "", "what happens to changes that fail --verify (discard/branch)")
+		var allowPaths, denyPaths stringsFlag
+		fs.Var(&allowPaths, "allow-path", "path glob runs may change; repeat for several, replacing the current ones (set empty string to clear)")
+		fs.Var(&denyPaths, "deny-path", "path glob runs must not change; repeat for several, replacing the current ones (set empty string to clear)")
+		maxFiles := fs.Int("max-files", 0, "most files a run may change (0 removes the limit)")
+		maxLines := fs.Int("max-lines", 0, "most lines a run may add and remove (0 removes the limit)")
+		secretScan := fs.String("secret-scan", "", "scan changes for secrets before delivering them (true/false)")
+		branch := fs.String("branch", "", "set target branch")
+		model := fs.String("model", "", "OpenCode model as provider/model (set empty string to clear)")
 		timeoutMinutes := 0
 		fs.IntVar(&timeoutMinutes, "timeout-minutes", 0, "set job timeout in minutes")
 		fs.IntVar(&timeoutMinutes, "timeout", 0, "alias for --timeout-minutes")
+		var inputs stringsFlag
+		fs.Var(&inputs, "input", "workflow_dispatch input as name[:string|:boolean|:choice:a,b][=default]; repeat for several, replacing the current ones")
+		clearInputs := fs.Bool("clear-inputs", false, "remove workflow_dispatch inputs besides prompt")
 		targetName := ""
 		if len(rawArgs) > 0 && !strings.HasPrefix(rawArgs[0], "-") {
 			targetName = strings.TrimSpace(rawArgs[0])
@@ -204,6 +425,12 @@ func main() {
 		if isFlagSet(fs, "cron") && *clearCron {
 			fail(fmt.Errorf("use either --cron or --clear-cron, not both"))
 		}
+		if isFlagSet(fs, "timezone") && *clearCron {
+			fail(fmt.Errorf("use either --timezone or --clear-cron, not both"))
+		}
+		if isFlagSet(fs, "input") && *clearInputs {
+			fail(fmt.Errorf("use either --input or --clear-inputs, not both"))
+		}
 
 		current, err := tender.LoadTenders(root)
 		if err != nil {
@@ -227,16 +454,52 @@ func main() {
 		}
 		if isFlagSet(fs, "prompt") {
 			updated.Prompt = strings.TrimSpace(*prompt)
+			// An inline prompt replaces the prompt file unless both are given.
+			if updated.Prompt != "" && !isFlagSet(fs, "prompt-file") {
+				updated.PromptFile = ""
+			}
+			changed = true
+		}
+		if isFlagSet(fs, "prompt-file") {
+			if strings.TrimSpace(*promptFile) == "" {
+				updated.PromptFile = ""
+			} else {
+				text := ""
+				if isFlagSet(fs, "prompt") {
+					text = *prompt
+				}
+				if updated, err = tender.UsePromptFile(root, updated, *promptFile, text); err != nil {
+					fail(err)
+				}
+			}
 			changed = true
 		}
+		// The schedule is kept as written: in its timezone when it has one.
+		// Converting it again on every update also re-syncs the UTC cron after
+		// a daylight-saving change.
+		scheduleExprs, scheduleZone := updated.Crons, updated.Timezone
+		if updated.Timezone != "" {
+			scheduleExprs = updated.LocalCrons
+		}
 		if isFlagSet(fs, "cron") {
-			updated.Cron = strings.TrimSpace(*cron)
+			scheduleExprs = crons
+			changed = true
+		}
+		if isFlagSet(fs, "timezone") {
+			if len(scheduleExprs) == 0 {
+				fail(fmt.Errorf("--timezone requires a schedule; pass --cron"))
+			}
+			scheduleZone = *timezone
 			changed = true
 		}
 		if *clearCron {
-			updated.Cron = ""
+			scheduleExprs = nil
 			changed = true
 		}
+		updated, err = tender.SetSchedule(updated, scheduleExprs, scheduleZone, time.Now())
+		if err != nil {
+			fail(err)
+		}
 		if isFlagSet(fs, "manual") {
 			b, err := parseBoolFlag(*manual, "manual")
 			if err != nil {
@@ -253,6 +516,114 @@ func main() {
 			updated.Push = b
 			changed = true
 		}
+		if isFlagSet(fs, "issue-label") {
+			b, err := parseBoolFlag(*issueLabel, "issue-label")
+			if err != nil {
+				fail(err)
+			}
+			updated.IssueLabel = b
+			changed = true
+		}
+		if isFlagSet(fs, "pr-comment") {
+			b, err := parseBoolFlag(*prComment, "pr-comment")
+			if err != nil {
+				fail(err)
+			}
+			updated.PRComment = b
+			changed = true
+		}
+		if isFlagSet(fs, "pr-open") {
+			b, err := parseBoolFlag(*prOpen, "pr-open")
+			if err != nil {
+				fail(err)
+			}
+			updated.PullRequest = b
+			changed = true
+		}
+		if isFlagSet(fs, "after") {
+			updated.After = strings.TrimSpace(*after)
+			if updated.After == "" {
+				updated.AfterWhen = ""
+			}
+			changed = true
+		}
+		if isFlagSet(fs, "after-when") {
+			w, err := parseAfterWhenFlag(*afterWhen)
+			if err != nil {
+				fail(err)
+			}
+			updated.AfterWhen = w
+			changed = true
+		}
+		if isFlagSet(fs, "input") {
+			if updated.Inputs, err = parseInputFlags(inputs); err != nil {
+				fail(err)
+			}
+			changed = true
+		}
+		if *clearInputs {
+			updated.Inputs = nil
+			changed = true
+		}
+		if isFlagSet(fs, "deliver") {
+			d, err := parseDeliveryFlag(*deliver)
+			if err != nil {
+				fail(err)
+			}
+			updated.Delivery = d
+			changed = true
+		}
+		if isFlagSet(fs, "verify") {
+			updated.Verify = strings.TrimSpace(*verify)
+			if updated.Verify == "" {
+				updated.VerifyFailure = ""
+			}
+			changed = true
+		}
+		if isFlagSet(fs, "verify-failure") {
+			v, err := parseVerifyFailureFlag(*verifyFailure)
+			if err != nil {
+				fail(err)
+			}
+			updated.VerifyFailure = v
+			changed = true
+		}
+		if isFlagSet(fs, "allow-path") {
+			updated.AllowPaths = allowPaths
+			changed = true
+		}
+		if isFlagSet(fs, "deny-path") {
+			updated.DenyPaths = denyPaths
+			changed = true
+		}
+		if isFlagSet(fs, "max-files") {
+			updated.MaxFiles = *maxFiles
+			changed = true
+		}
+		if isFlagSet(fs, "max-lines") {
+			updated.MaxLines = *maxLines
+			changed = true
+		}
+		if isFlagSet(fs, "secret-scan") {
+			b, err := parseBoolFlag(*secretScan, "secret-scan")
+			if err != nil {
+				fail(err)
+			}
+			updated.SecretScan = b
+			changed = true
+		}
+		if isFlagSet(fs, "branch") {
+			updated.Branch = strings.TrimSpace(*branch)
+			changed = true
+		}
+		if isFlagSet(fs, "model") {
+			updated.Model = strings.TrimSpace(*model)
+			updated.Provider = tender.ModelProvider(updated.Model)
+			if err := tender.ValidateModelForRepo(root, updated.Model); err != nil {
+				fail(err)
+			}
+			changed = true
+		}
 		if isFlagSet(fs, "timeout-minutes") || isFlagSet(fs, "timeout") {
 			parsedTimeout, err := parseTimeoutMinutesFlag(timeoutMinutes)
 			if err != nil {
@@ -319,6 +690,8 @@ func main() {
 		if hasHelpFlag(rawArgs, map[string]struct{}{
 			"-prompt":  {},
 			"--prompt": {},
+			"-input":   {},
+			"--input":  {},
 		}) {
 			usage()
 			fmt.Println()
@@ -327,6 +700,10 @@ func main() {
 		}
 		fs := flag.NewFlagSet("run", flag.ExitOnError)
 		prompt := fs.String("prompt", "", "optional prompt override for this dispatch")
+		wait := fs.Bool("wait", false, "follow the dispatched run and exit with its result")
+		local := fs.Bool("local", false, "rehearse the tender in a temporary git worktree instead of dispatching")
+		var inputs stringsFlag
+		fs.Var(&inputs, "input", "value for a declared input as key=value; repeat for several")
 		_ = fs.Parse(rawArgs)
 		args := fs.Args()
 		if len(args) != 1 {
@@ -334,11 +711,325 @@ func main() {
 			os.Exit(2)
 		}
 		name := args[0]
-		if err := tender.DispatchTenderNow(root, name, *prompt, os.Stdout, os.Stderr); err != nil {
+		if *wait && *local {
+			fail(fmt.Errorf("use either --wait or --local, not both"))
+		}
+		if *local {
+			run, err := tender.RunTenderLocally(root, name, *prompt, inputs, os.Stdout, os.Stderr)
+			if err != nil {
+				fail(err)
+			}
+			if run.Commit == "" {
+				fmt.Printf("rehearsed %s locally: no changes\n", name)
+				return
+			}
+			report := fmt.Sprintf("rehearsed %s locally: commit %s on top of %s (not pushed)\n\n%s\n%s", name, shortSHA(run.Commit), shortSHA(run.Base), run.Stat, run.Diff)
+			if run.VerifyFailed {
+				report = fmt.Sprintf("rehearsed %s locally: verify command failed; the workflow would not deliver commit %s\n\n%s\n%s", name, shortSHA(run.Commit), run.Stat, run.Diff)
+			}
+			if len(run.Violations) > 0 {
+				report = fmt.Sprintf("rehearsed %s locally: guardrails would stop commit %s:\n  %s\n\n%s\n%s", name, shortSHA(run.Commit), strings.Join(run.Violations, "\n  "), run.Stat, run.Diff)
+			}
+			if len(run.Secrets) > 0 {
+				// Only the stat: the diff would print the secrets the report redacts.
+				findings := make([]string, len(run.Secrets))
+				for i, f := range run.Secrets {
+					findings[i] = f.String()
+				}
+				report = fmt.Sprintf("rehearsed %s locally: the secret scan would stop commit %s:\n  %s\n\n%s", name, shortSHA(run.Commit), strings.Join(findings, "\n  "), run.Stat)
+			}
+			if err := pageOutput(report); err != nil {
+				fail(err)
+			}
+			if run.VerifyFailed || len(run.Violations) > 0 || len(run.Secrets) > 0 {
+				os.Exit(1)
+			}
+			return
+		}
+		if *wait {
+			result, err := tender.DispatchTenderAndWait(root, name, *prompt, inputs, os.Stdout, os.Stderr)
+			if err != nil {
+				fail(err)
+			}
+			os.Exit(tender.ConclusionExitCode(result.Conclusion))
+		}
+		if err := tender.DispatchTenderNow(root, name, *prompt, inputs, os.Stdout, os.Stderr); err != nil {
 			fail(err)
 		}
 		fmt.Printf("triggered %s\n", name)
 
+	case "status":
+		rawArgs := os.Args[2:]
+		if hasHelpFlag(rawArgs, nil) {
+			usage()
+			fmt.Println()
+			printStatusHelp()
+			return
+		}
+		fs := flag.NewFlagSet("status", flag.ExitOnError)
+		_ = fs.Parse(rawArgs)
+		args := fs.Args()
+		if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, statusUsageLine)
+			os.Exit(2)
+		}
+		name := ""
+		if len(args) == 1 {
+			name = strings.TrimSpace(args[0])
+		}
+		if err := tender.PrintStatus(root, name, os.Stdout); err != nil {
+			fail(err)
+		}
+
+	case "logs":
+		rawArgs := os.Args[2:]
+		if hasHelpFlag(rawArgs, map[string]struct{}{
+			"-run":   {},
+			"--run":  {},
+			"-step":  {},
+			"--step": {},
+		}) {
+			usage()
+			fmt.Println()
+			printLogsHelp()
+			return
+		}
+		fs := flag.NewFlagSet("logs", flag.ExitOnError)
+		runID := fs.Int64("run", 0, "workflow run ID")
+		latest := fs.Bool("latest", false, "show the latest run (default)")
+		step := fs.String("step", tender.DefaultLogStep, "step to show: opencode, install, checkout or a step name")
+		name := ""
+		if len(rawArgs) > 0 && !strings.HasPrefix(rawArgs[0], "-") {
+			name = strings.TrimSpace(rawArgs[0])
+			rawArgs = rawArgs[1:]
+		}
+		_ = fs.Parse(rawArgs)
+		args := fs.Args()
+		if name == "" && len(args) == 1 {
+			name = strings.TrimSpace(args[0])
+			args = nil
+		}
+		if name == "" || len(args) > 0 {
+			fmt.Fprintln(os.Stderr, logsUsageLine)
+			os.Exit(2)
+		}
+		if isFlagSet(fs, "run") && *latest {
+			fail(fmt.Errorf("use either --run or --latest, not both"))
+		}
+		if isFlagSet(fs, "run") && *runID <= 0 {
+			fail(fmt.Errorf("--run must be a positive run ID"))
+		}
+		log, err := tender.FetchRunLog(root, name, *runID, *step)
+		if err != nil {
+			fail(err)
+		}
+		header := fmt.Sprintf("==> %s: run %d, step %q <==\n", name, log.RunID, log.Step)
+		if err := pageOutput(header + log.Text); err != nil {
+			fail(err)
+		}
+
+	case "revert":
+		rawArgs := os.Args[2:]
+		if hasHelpFlag(rawArgs, map[string]struct{}{
+			"-last":   {},
+			"--last":  {},
+			"-since":  {},
+			"--since": {},
+			"-run":    {},
+			"--run":   {},
+		}) {
+			usage()
+			fmt.Println()
+			printRevertHelp()
+			return
+		}
+		fs := flag.NewFlagSet("revert", flag.ExitOnError)
+		last := fs.Int("last", 0, "revert the newest n commits (default 1)")
+		since := fs.String("since", "", "revert the commits made since this date (YYYY-MM-DD or RFC 3339)")
+		runID := fs.Int64("run", 0, "revert the commits made during this workflow run")
+		yes := fs.Bool("yes", false, "revert without confirmation")
+		name := ""
+		if len(rawArgs) > 0 && !strings.HasPrefix(rawArgs[0], "-") {
+			name = strings.TrimSpace(rawArgs[0])
+			rawArgs = rawArgs[1:]
+		}
+		_ = fs.Parse(rawArgs)
+		args := fs.Args()
+		if name == "" && len(args) == 1 {
+			name = strings.TrimSpace(args[0])
+			args = nil
+		}
+		if name == "" || len(args) > 0 {
+			fmt.Fprintln(os.Stderr, revertUsageLine)
+			os.Exit(2)
+		}
+		opts := tender.RevertOptions{Last: *last, RunID: *runID}
+		if isFlagSet(fs, "last") && *last <= 0 {
+			fail(fmt.Errorf("--last must be a positive number"))
+		}
+		if isFlagSet(fs, "run") && *runID <= 0 {
+			fail(fmt.Errorf("--run must be a positive run ID"))
+		}
+		if isFlagSet(fs, "since") {
+			t, err := tender.ParseRevertSince(*since)
+			if err != nil {
+				fail(err)
+			}
+			opts.Since = t
+		}
+		plan, err := tender.PlanRevert(root, name, opts)
+		var conflict *tender.RevertConflict
+		if errors.As(err, &conflict) {
+			fmt.Fprintf(os.Stderr, "cannot revert %s cleanly: %v\n", name, conflict)
+			fmt.Fprintln(os.Stderr, "Later commits changed the same lines. Nothing was changed; pick fewer commits, or run git revert on that commit and resolve the conflict by hand.")
+			os.Exit(1)
+		}
+		if err != nil {
+			fail(err)
+		}
+		var report strings.Builder
+		fmt.Fprintf(&report, "Reverting %d commit(s) of %s:\n", len(plan.Commits), plan.Name)
+		for _, c := range plan.Commits {
+			fmt.Fprintf(&report, "  %s  %s  %s\n", shortSHA(c.SHA), c.Date.Local().Format("2006-01-02 15:04"), c.Subject)
+		}
+		fmt.Fprintf(&report, "\n%s\n%s", plan.Stat, plan.Diff)
+		if err := pageOutput(report.String()); err != nil {
+			fail(err)
+		}
+		if !*yes {
+			fmt.Fprintf(os.Stdout, "Create %d revert commit(s) on the current branch? (y/N): ", len(plan.Commits))
+			var confirm string
+			_, _ = fmt.Fscanln(os.Stdin, &confirm)
+			if confirm != "y" && confirm != "Y" && strings.ToLower(confirm) != "yes" {
+				fmt.Fprintln(os.Stdout, "cancelled")
+				return
+			}
+		}
+		reverts, err := tender.ApplyRevert(root, plan)
+		if errors.As(err, &conflict) {
+			fmt.Fprintf(os.Stderr, "cannot revert %s cleanly: %v\n", name, conflict)
+			fmt.Fprintln(os.Stderr, "The revert was aborted and the branch is unchanged.")
+			os.Exit(1)
+		}
+		if err != nil {
+			fail(err)
+		}
+		fmt.Printf("created %d revert commit(s) ending at %s; nothing was pushed\n", len(reverts), shortSHA(reverts[len(reverts)-1]))
+
+	case "history":
+		rawArgs := os.Args[2:]
+		if hasHelpFlag(rawArgs, nil) {
+			usage()
+			fmt.Println()
+			printHistoryHelp()
+			return
+		}
+		fs := flag.NewFlagSet("history", flag.ExitOnError)
+		asJSON := fs.Bool("json", false, "print the history as JSON")
+		name := ""
+		if len(rawArgs) > 0 && !strings.HasPrefix(rawArgs[0], "-") {
+			name = strings.TrimSpace(rawArgs[0])
+			rawArgs = rawArgs[1:]
+		}
+		_ = fs.Parse(rawArgs)
+		args := fs.Args()
+		if name == "" && len(args) == 1 {
+			name = strings.TrimSpace(args[0])
+			args = nil
+		}
+		if len(args) > 0 {
+			fmt.Fprintln(os.Stderr, historyUsageLine)
+			os.Exit(2)
+		}
+		if *asJSON {
+			if err := tender.PrintHistory(root, name, true, os.Stdout); err != nil {
+				fail(err)
+			}
+			return
+		}
+		var out strings.Builder
+		if err := tender.PrintHistory(root, name, false, &out); err != nil {
+			fail(err)
+		}
+		if err := pageOutput(out.String()); err != nil {
+			fail(err)
+		}
+
+	case "doctor":
+		rawArgs := os.Args[2:]
+		if hasHelpFlag(rawArgs, nil) {
+			usage()
+			fmt.Println()
+			printDoctorHelp()
+			return
+		}
+		fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+		_ = fs.Parse(rawArgs)
+		if len(fs.Args()) != 0 {
+			fmt.Fprintln(os.Stderr, doctorUsageLine)
+			os.Exit(2)
+		}
+		warnings, err := tender.PrintDoctor(root, time.Now(), os.Stdout)
+		if err != nil {
+			fail(err)
+		}
+		if warnings > 0 {
+			os.Exit(1)
+		}
+
+	case "schedule":
+		rawArgs := os.Args[2:]
+		if hasHelpFlag(rawArgs, nil) {
+			usage()
+			fmt.Println()
+			printScheduleHelp()
+			return
+		}
+		fs := flag.NewFlagSet("schedule", flag.ExitOnError)
+		stagger := fs.Bool("stagger", false, "suggest start minutes that keep tenders from waiting on each other")
+		apply := fs.Bool("apply", false, "write the suggested start minutes to the workflows")
+		_ = fs.Parse(rawArgs)
+		if len(fs.Args()) != 0 {
+			fmt.Fprintln(os.Stderr, scheduleUsageLine)
+			os.Exit(2)
+		}
+		if *stagger || *apply {
+			if err := tender.PrintStagger(root, time.Now(), *apply, os.Stdout); err != nil {
+				fail(err)
+			}
+			return
+		}
+		if err := tender.PrintSchedule(root, time.Now(), os.Stdout); err != nil {
+			fail(err)
+		}
+
+	case "scan-diff":
+		rawArgs := os.Args[2:]
+		if hasHelpFlag(rawArgs, map[string]struct{}{
+			"-base":  {},
+			"--base": {},
+		}) {
+			usage()
+			fmt.Println()
+			printScanDiffHelp()
+			return
+		}
+		fs := flag.NewFlagSet("scan-diff", flag.ExitOnError)
+		base := fs.String("base", "HEAD", "revision the staged changes are compared with")
+		_ = fs.Parse(rawArgs)
+		if len(fs.Args()) != 0 {
+			fmt.Fprintln(os.Stderr, scanDiffUsageLine)
+			os.Exit(2)
+		}
+		findings, err := tender.PrintScanDiff(root, *base, os.Stdout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "error:", err)
+			os.Exit(2)
+		}
+		if findings > 0 {
+			os.Exit(1)
+		}
+
 	case "help":
 		if len(os.Args) == 2 {
 			usage()
@@ -374,8 +1065,15 @@ func usage() {
 	fmt.Println("  add             Add a tender non-interactively (agent-friendly)")
 	fmt.Println("  update          Update a tender non-interactively (agent-friendly)")
 	fmt.Println("  ls              List managed tender workflows")
-	fmt.Println("  run             Trigger an on-demand tender now via GitHub CLI")
+	fmt.Println("  status          Show recent GitHub Actions runs per tender")
+	fmt.Println("  logs            Show the OpenCode output of a tender run")
+	fmt.Println("  run             Trigger an on-demand tender now via the GitHub API")
 	fmt.Println("  rm              Remove a tender workflow")
+	fmt.Println("  doctor          Check tender workflows for problems")
+	fmt.Println("  schedule        Show the week's scheduled runs and overlaps")
+	fmt.Println("  history         Show what each tender's commits changed")
+	fmt.Println("  revert          Revert a tender's commits locally")
+	fmt.Println("  scan-diff       Scan staged changes for secrets")
 	fmt.Println("  help [command]  Show command help")
 	fmt.Println()
 	fmt.Println("Tip:")
@@ -387,6 +1085,18 @@ func fail(err error) {
 	os.Exit(1)
 }
 
+// stringsFlag collects every value of a repeatable flag.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
 func isFlagSet(fs *flag.FlagSet, name string) bool {
 	found := false
 	fs.Visit(func(f *flag.Flag) {
@@ -416,6 +1126,52 @@ func parseTimeoutMinutesFlag(value int) (int, error) {
 	return value, nil
 }
 
+func parseDeliveryFlag(raw string) (string, error) {
+	switch v := strings.ToLower(strings.TrimSpace(raw)); v {
+	case tender.DeliveryPush, tender.DeliveryPR:
+		return v, nil
+	default:
+		return "", fmt.Errorf("invalid value for --deliver: %q (expected push/pr)", raw)
+	}
+}
+
+// parseVerifyFailureFlag stores discard, the default, as an empty
+// VerifyFailure.
+func parseVerifyFailureFlag(raw string) (string, error) {
+	switch v := strings.ToLower(strings.TrimSpace(raw)); v {
+	case tender.VerifyDiscard:
+		return "", nil
+	case tender.VerifyBranch:
+		return v, nil
+	default:
+		return "", fmt.Errorf("invalid value for --verify-failure: %q (expected discard/branch)", raw)
+	}
+}
+
+// parseAfterWhenFlag stores completed, the default, as an empty AfterWhen.
+func parseAfterWhenFlag(raw string) (string, error) {
+	switch v := strings.ToLower(strings.TrimSpace(raw)); v {
+	case tender.AfterCompleted:
+		return "", nil
+	case tender.AfterSuccess, tender.AfterPushed:
+		return v, nil
+	default:
+		return "", fmt.Errorf("invalid value for --after-when: %q (expected completed/success/pushed)", raw)
+	}
+}
+
+func parseInputFlags(raw []string) ([]tender.DispatchInput, error) {
+	var inputs []tender.DispatchInput
+	for _, r := range raw {
+		in, err := tender.ParseInputDeclaration(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for --input: %v", err)
+		}
+		inputs = append(inputs, in)
+	}
+	return inputs, nil
+}
+
 func findTenderByName(tenders []tender.Tender, name string) (tender.Tender, bool) {
 	needle := strings.TrimSpace(strings.ToLower(name))
 	for _, t := range tenders {
@@ -469,8 +1225,22 @@ func printCommandHelp(command string) error {
 		printRemoveHelp()
 	case "ls":
 		printListHelp()
+	case "status":
+		printStatusHelp()
+	case "logs":
+		printLogsHelp()
 	case "init":
 		printInitHelp()
+	case "doctor":
+		printDoctorHelp()
+	case "schedule":
+		printScheduleHelp()
+	case "scan-diff":
+		printScanDiffHelp()
+	case "revert":
+		printRevertHelp()
+	case "history":
+		printHistoryHelp()
 	default:
 		return fmt.Errorf("unknown command %q", command)
 	}
@@ -485,6 +1255,22 @@ func printAddHelp() {
 	fmt.Println("  - Provide the tender name either as positional <name> or --name.")
 	fmt.Println("  - --manual defaults to true, --push defaults to false.")
 	fmt.Println("  - --timeout-minutes defaults to 30.")
+	fmt.Println("  - --deliver defaults to push; pr commits to tender/<name>/<run-id> and opens or updates a pull request.")
+	fmt.Println("  - --verify runs a command (e.g. \"make check-fast\") after the agent and before delivery; if it fails the run fails and the changes are discarded, or pushed to tender/<name>/unverified-<run-id> with --verify-failure branch.")
+	fmt.Println("  - --allow-path and --deny-path take git pathspec globs (`*` stays within a directory, `**` crosses them); with --max-files and --max-lines they stop a run before it commits anything, listing the violations in the job summary.")
+	fmt.Println("  - --branch defaults to the repository's default branch (origin/HEAD, else main or master).")
+	fmt.Println("  - --model must name a provider configured in opencode.json; its API key secret is wired into the workflow.")
+	fmt.Println("  - Repeat --cron to give a tender several schedules, e.g. weekday mornings plus a Sunday deep run.")
+	fmt.Println("  - --prompt-file keeps the prompt in a repository file (e.g. .tender/prompts/<name>.md) that each run reads from its checkout; a missing file is created from --prompt.")
+	fmt.Println("  - Prompts may use {{sha}}, {{changed_files}}, {{date}}, {{previous_commit}} and {{actor}}; the workflow fills them in before opencode runs.")
+	fmt.Println("  - --timezone reads --cron as local time in that IANA zone and writes the matching UTC cron; run tender doctor after daylight-saving changes.")
+	fmt.Println("  - Repeat --input to declare workflow_dispatch inputs besides prompt, e.g. --input dry_run:boolean --input scope:choice:docs,tests=tests.")
+	fmt.Println("  - Runs see an input as TENDER_INPUT_<NAME> and prompts as {{input.<name>}}; scheduled and push runs get its default.")
+	fmt.Println("  - --issue-label runs the tender when an issue is labelled tender:<name>; --pr-comment when a maintainer comments `/tender <name>` on a pull request; --pr-open when a pull request is opened against --branch.")
+	fmt.Println("  - Event-triggered prompts may also use {{event_title}}, {{event_body}} (issue, pull request or comment text) and {{pr_diff}}.")
+	fmt.Println("  - --after <tender> runs this tender whenever that tender's workflow completes; --after-when success skips failed upstream runs and pushed also skips runs that pushed no commit (not allowed after a --deliver pr tender).")
+	fmt.Println("  - Chains may not loop back on themselves, and GitHub only starts chained runs from workflows on the default branch.")
+	fmt.Println("  - --secret-scan true runs tender scan-diff on the changes before delivery; a finding stops the run with a redacted report in the job summary.")
 }
 
 func printUpdateHelp() {
@@ -493,8 +1279,20 @@ func printUpdateHelp() {
 	fmt.Println()
 	fmt.Println("Notes:")
 	fmt.Println("  - Target tender name is required as positional <name>.")
-	fmt.Println("  - Use --clear-cron to remove schedule.")
+	fmt.Println("  - --cron replaces all schedules; repeat it to set several. Use --clear-cron to remove them.")
+	fmt.Println("  - --prompt-file moves the tender to a prompt file; a missing file is created from --prompt or the current inline prompt. A non-empty --prompt on its own switches back to an inline prompt.")
+	fmt.Println("  - --input replaces all declared inputs; repeat it to set several. Use --clear-inputs to remove them.")
+	fmt.Println("  - Use --issue-label, --pr-comment and --pr-open true|false to switch the issue and pull request triggers.")
+	fmt.Println("  - Use --after <tender> and --after-when completed|success|pushed to chain the tender after another one, or --after \"\" to unchain it.")
+	fmt.Println("  - Use --timezone <zone> to read the schedule as local time in that zone, or --timezone UTC to go back to UTC.")
+	fmt.Println("  - Any update of a tender with a timezone recomputes its UTC cron for the current daylight-saving offset.")
 	fmt.Println("  - Use --timeout-minutes to override the workflow job timeout.")
+	fmt.Println("  - Use --deliver push|pr to switch between pushing to the branch and opening a pull request.")
+	fmt.Println("  - Use --verify \"<command>\" to gate delivery on a command, or --verify \"\" to remove the gate; --verify-failure discard|branch picks what happens to changes that fail it.")
+	fmt.Println("  - --allow-path and --deny-path replace the current globs; repeat them to set several, or pass \"\" to clear. --max-files 0 and --max-lines 0 remove the limits.")
+	fmt.Println("  - Use --secret-scan true|false to switch the secret scan before delivery.")
+	fmt.Println("  - Use --branch to change the branch the tender checks out, pushes to and targets with pull requests.")
+	fmt.Println("  - Use --model provider/model to pin a model, or --model \"\" to fall back to the agent's model.")
 }
 
 func printRunHelp() {
@@ -502,7 +1300,18 @@ func printRunHelp() {
 	fmt.Printf("  %s\n", runUsageLine)
 	fmt.Println()
 	fmt.Println("Notes:")
-	fmt.Println("  - Requires GitHub CLI auth for the repository.")
+	fmt.Println("  - Dispatches via the GitHub API using GH_TOKEN/GITHUB_TOKEN or your gh login; falls back to gh workflow run.")
+	fmt.Println("  - --input key=value sets a declared input for this run; unknown keys and values the input's type rejects fail before dispatching.")
+	fmt.Println("  - --local runs the agent on this machine in a temporary git worktree of HEAD and prints the diff; nothing is pushed.")
+	fmt.Println("  - --wait follows the dispatched run, printing step transitions until it completes.")
+	fmt.Println("  - With --wait the exit code reflects the run: 0 success, 1 failure, 3 cancelled, 4 timed out, 5 other.")
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
 }
 
 func printRemoveHelp() {
@@ -519,6 +1328,84 @@ func printListHelp() {
 	fmt.Println()
 	fmt.Println("Notes:")
 	fmt.Println("  - Lists tender workflows currently managed in .github/workflows.")
+	fmt.Println("  - Reports tender workflows that could not be parsed, with the reason.")
+}
+
+func printStatusHelp() {
+	fmt.Println("Command: status")
+	fmt.Printf("  %s\n", statusUsageLine)
+	fmt.Println()
+	fmt.Println("Notes:")
+	fmt.Println("  - Without <name>, shows the latest run of every tender; with <name>, its recent runs.")
+	fmt.Println("  - PUSHED is yes when the run committed to the target branch, or the pull request it opened.")
+	fmt.Println("  - Uses GH_TOKEN, GITHUB_TOKEN or GitHub CLI auth; GITHUB_API_URL overrides the API endpoint.")
+}
+
+func printLogsHelp() {
+	fmt.Println("Command: logs")
+	fmt.Printf("  %s\n", logsUsageLine)
+	fmt.Println()
+	fmt.Println("Notes:")
+	fmt.Println("  - Shows the latest run unless --run is given.")
+	fmt.Println("  - --step defaults to opencode (the Run OpenCode step); install, checkout or any step name also work.")
+	fmt.Println("  - Output is paged with $PAGER (default: less -FRX) when stdout is a terminal.")
+}
+
+func printDoctorHelp() {
+	fmt.Println("Command: doctor")
+	fmt.Printf("  %s\n", doctorUsageLine)
+	fmt.Println()
+	fmt.Println("Notes:")
+	fmt.Println("  - Reports tender workflows that do not load or validate.")
+	fmt.Println("  - Warns when a daylight-saving change has moved a timezone schedule off its local time, and notes changes due within 14 days.")
+	fmt.Println("  - Exits 1 when there are warnings.")
+}
+
+func printScheduleHelp() {
+	fmt.Println("Command: schedule")
+	fmt.Printf("  %s\n", scheduleUsageLine)
+	fmt.Println()
+	fmt.Println("Notes:")
+	fmt.Println("  - Lists every scheduled run over the next week (UTC, starting today), assuming each run lasts its full timeout.")
+	fmt.Println("  - Tenders delivering to the same branch share a concurrency group, so a run that starts while another may still be going waits for it.")
+	fmt.Println("  - --stagger suggests new start minutes, in 5-minute steps within the hour, that remove or shrink those waits; --apply writes them.")
+	fmt.Println("  - Only schedules with a single start minute are moved; timezone tenders keep their timezone.")
+}
+
+func printHistoryHelp() {
+	fmt.Println("Command: history")
+	fmt.Printf("  %s\n", historyUsageLine)
+	fmt.Println()
+	fmt.Println("Notes:")
+	fmt.Println("  - Reads the tender(<name>): autonomous update commits reachable from HEAD; no GitHub access is needed.")
+	fmt.Println("  - Shows per tender: commits (and how many were reverted), files touched, lines added and removed, and a timeline, newest first.")
+	fmt.Println("  - With <name>, also lists every file the tender touched; removed tenders' commits can be shown by name too.")
+	fmt.Println("  - --json prints the same data, including each commit's files, for scripts.")
+	fmt.Println("  - Pull the target branch first so the latest runs are in local history.")
+}
+
+func printRevertHelp() {
+	fmt.Println("Command: revert")
+	fmt.Printf("  %s\n", revertUsageLine)
+	fmt.Println()
+	fmt.Println("Notes:")
+	fmt.Println("  - Finds the tender(<name>): autonomous update commits reachable from HEAD, skipping ones already reverted.")
+	fmt.Println("  - Reverts the latest commit by default; --last, --since or --run (commits made while that workflow run was going) pick others.")
+	fmt.Println("  - Shows the combined diff and asks before creating one revert commit per tender commit; --yes skips the question.")
+	fmt.Println("  - Nothing is pushed. Pull the target branch first so the tender's latest commits are in local history.")
+	fmt.Println("  - --run matches commits by date; for --deliver pr tenders, rebase merges change it, so use --since or --last (squash merges are reverted with git revert).")
+	fmt.Println("  - If a revert conflicts with later commits, nothing is changed and the conflicting commit and files are reported.")
+}
+
+func printScanDiffHelp() {
+	fmt.Println("Command: scan-diff")
+	fmt.Printf("  %s\n", scanDiffUsageLine)
+	fmt.Println()
+	fmt.Println("Notes:")
+	fmt.Println("  - Scans the lines added between --base (default HEAD) and the index, so stage changes with git add first.")
+	fmt.Println("  - Looks for cloud, GitHub, Slack, OpenAI, Anthropic, Google and Stripe keys, private keys, quoted secret assignments and .env files; it needs no network access.")
+	fmt.Println("  - Findings are printed redacted with a sha256 fingerprint. To accept one, add the path glob, rule:<id> or sha256:<fingerprint> to .tender/secret-scan-ignore; the file is read from --base.")
+	fmt.Println("  - Exit code: 0 clean, 1 secrets found, 2 error.")
 }
 
 func printInitHelp() {
@@ -529,6 +1416,29 @@ func printInitHelp() {
 	fmt.Println("  - Creates .github/workflows if it does not exist.")
 }
 
+// pageOutput writes text through $PAGER when stdout is a terminal, and
+// straight to stdout otherwise.
+func pageOutput(text string) error {
+	info, err := os.Stdout.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		_, err := fmt.Fprint(os.Stdout, text)
+		return err
+	}
+	pager := strings.Fields(os.Getenv("PAGER"))
+	if len(pager) == 0 {
+		pager = []string{"less", "-FRX"}
+	}
+	if _, err := exec.LookPath(pager[0]); err != nil {
+		_, err := fmt.Fprint(os.Stdout, text)
+		return err
+	}
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 func requireCustomAgent(root, name string) error {
 	agentName := strings.TrimSpace(name)
 	if agentName == "" {
@@ -38,8 +38,12 @@ func TestUsage(t *testing.T) {
 			"add             Add a tender non-interactively (agent-friendly)",
 			"update          Update a tender non-interactively (agent-friendly)",
 			"ls              List managed tender workflows",
-			"run             Trigger an on-demand tender now via GitHub CLI",
+			"status          Show recent GitHub Actions runs per tender",
+			"logs            Show the OpenCode output of a tender run",
+			"run             Trigger an on-demand tender now via the GitHub API",
 			"rm              Remove a tender workflow",
+			"doctor          Check tender workflows for problems",
+			"schedule        Show the week's scheduled runs and overlaps",
 			"help [command]  Show command help",
 			"Use `tender <command> --help` to show command-specific usage and flags.",
 		}
@@ -1,3 +1,10 @@
 module tender
 
 go 1.17
+
+require gopkg.in/yaml.v3 v3.0.1
+
+require (
+	golang.org/x/crypto v0.8.0
+	golang.org/x/sys v0.7.0 // indirect
+)
@@ -0,0 +1,262 @@
+// Package cron parses the five-field cron expressions GitHub Actions schedules
+// accept and computes when they fire. All times are UTC, like Actions.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinInterval is the shortest interval GitHub Actions runs a schedule at.
+const MinInterval = 5 * time.Minute
+
+// searchLimit bounds Next for expressions that (almost) never fire, such as
+// February 30th.
+const searchLimit = 5 * 366 * 24 * time.Hour
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr                     string
+	minute, hour, dom, month uint64 // bit n set when value n matches
+	dow                      uint64
+	domAny, dowAny           bool // field starts with "*", as in Vixie cron
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day-of-month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	dowField = field{name: "day-of-week", min: 0, max: 6, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// Parse parses a five-field cron expression: minute, hour, day of month, month
+// and day of week. Fields accept *, values, ranges (1-5), steps (*/15, 1-30/5,
+// 10/20), comma lists and month/weekday names (JAN, MON-FRI). Like POSIX cron,
+// a schedule restricting both day of month and day of week fires when either
+// matches.
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron must have 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+	s := &Schedule{expr: strings.Join(fields, " ")}
+	var err error
+	if s.minute, _, err = parseField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+	if s.hour, _, err = parseField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+	if s.dom, s.domAny, err = parseField(fields[2], domField); err != nil {
+		return nil, err
+	}
+	if s.month, _, err = parseField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+	if s.dow, s.dowAny, err = parseField(fields[4], dowField); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate parses expr and rejects schedules GitHub Actions will not run as
+// written: ones firing more often than every MinInterval and ones that never
+// fire.
+func Validate(expr string) error {
+	s, err := Parse(expr)
+	if err != nil {
+		return err
+	}
+	if gap := s.shortestGap(); gap < MinInterval {
+		return fmt.Errorf("cron %q fires every %s; GitHub Actions runs schedules at most every %s", s.expr, formatGap(gap), formatGap(MinInterval))
+	}
+	if s.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return fmt.Errorf("cron %q never fires", s.expr)
+	}
+	return nil
+}
+
+// String returns the expression with normalised spacing.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first fire time strictly after t, in UTC, or the zero time
+// when the schedule does not fire within five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+	for t.Before(limit) {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// NextN returns up to n fire times after t.
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	var times []time.Time
+	for len(times) < n {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// shortestGap is the smallest distance between two fire times: between listed
+// minutes of an hour, or from the last minute of one hour to the first of the
+// next when consecutive hours fire.
+func (s *Schedule) shortestGap() time.Duration {
+	var minutes []int
+	for m := 0; m < 60; m++ {
+		if has(s.minute, m) {
+			minutes = append(minutes, m)
+		}
+	}
+	shortest := 24 * time.Hour
+	for i := 1; i < len(minutes); i++ {
+		if gap := time.Duration(minutes[i]-minutes[i-1]) * time.Minute; gap < shortest {
+			shortest = gap
+		}
+	}
+	for h := 0; h < 24; h++ {
+		if has(s.hour, h) && has(s.hour, (h+1)%24) {
+			if gap := time.Duration(60-minutes[len(minutes)-1]+minutes[0]) * time.Minute; gap < shortest {
+				shortest = gap
+			}
+			break
+		}
+	}
+	return shortest
+}
+
+func formatGap(d time.Duration) string {
+	if d == time.Minute {
+		return "minute"
+	}
+	return fmt.Sprintf("%d minutes", int(d/time.Minute))
+}
+
+func has(set uint64, n int) bool {
+	return set&(1<<uint(n)) != 0
+}
+
+// parseField returns the values matched by one field and whether it starts
+// with "*".
+func parseField(raw string, f field) (uint64, bool, error) {
+	var set uint64
+	for _, part := range strings.Split(raw, ",") {
+		bits, err := parsePart(part, f)
+		if err != nil {
+			return 0, false, fmt.Errorf("%s field %q: %w", f.name, raw, err)
+		}
+		set |= bits
+	}
+	return set, strings.HasPrefix(raw, "*"), nil
+}
+
+func parsePart(part string, f field) (uint64, error) {
+	if part == "" {
+		return 0, fmt.Errorf("empty list entry")
+	}
+	rangePart, step := part, 1
+	if i := strings.Index(part, "/"); i >= 0 {
+		rangePart = part[:i]
+		n, err := strconv.Atoi(part[i+1:])
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid step %q", part[i+1:])
+		}
+		step = n
+	}
+
+	var lo, hi int
+	switch {
+	case rangePart == "*":
+		lo, hi = f.min, f.max
+	case strings.Contains(rangePart, "-"):
+		i := strings.Index(rangePart, "-")
+		var err error
+		if lo, err = parseValue(rangePart[:i], f); err != nil {
+			return 0, err
+		}
+		if hi, err = parseValue(rangePart[i+1:], f); err != nil {
+			return 0, err
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("range %q runs backwards", rangePart)
+		}
+	default:
+		v, err := parseValue(rangePart, f)
+		if err != nil {
+			return 0, err
+		}
+		lo, hi = v, v
+		if strings.Contains(part, "/") {
+			hi = f.max // "10/20" means from 10 every 20
+		}
+	}
+
+	var bits uint64
+	for n := lo; n <= hi; n += step {
+		bits |= 1 << uint(n)
+	}
+	return bits, nil
+}
+
+func parseValue(raw string, f field) (int, error) {
+	if v, ok := f.names[strings.ToUpper(raw)]; ok {
+		return v, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		if raw == "" {
+			return 0, fmt.Errorf("missing value")
+		}
+		return 0, fmt.Errorf("invalid value %q", raw)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, f.min, f.max)
+	}
+	return n, nil
+}
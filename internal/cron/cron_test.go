@@ -0,0 +1,137 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// cron.go tests
+
+func mustParse(t *testing.T, expr string) *Schedule {
+	t.Helper()
+	s, err := Parse(expr)
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", expr, err)
+	}
+	return s
+}
+
+func TestParse(t *testing.T) {
+	t.Run("accepts the full field syntax", func(t *testing.T) {
+		for _, expr := range []string{
+			"0 9 * * 1",
+			"*/15 * * * *",
+			"5,35 8-18/2 1,15 * MON-FRI",
+			"30 2 * jan,Jul sun",
+			"10/20 * * * *",
+		} {
+			mustParse(t, expr)
+		}
+	})
+
+	t.Run("rejects malformed fields", func(t *testing.T) {
+		cases := map[string]string{
+			"99 * * * *":    `minute field "99": value 99 out of range 0-59`,
+			"0 24 * * *":    `hour field "24": value 24 out of range 0-23`,
+			"0 0 0 * *":     `day-of-month field "0": value 0 out of range 1-31`,
+			"0 0 * 13 *":    `month field "13": value 13 out of range 1-12`,
+			"0 0 * * 7":     `day-of-week field "7": value 7 out of range 0-6`,
+			"0 0 * * FUN":   `day-of-week field "FUN": invalid value "FUN"`,
+			"*/0 * * * *":   `minute field "*/0": invalid step "0"`,
+			"0 5-2 * * *":   `hour field "5-2": range "5-2" runs backwards`,
+			"0,,5 * * * *":  `minute field "0,,5": empty list entry`,
+			"0 9 * *":       `must have 5 fields`,
+			"0 0 L * *":     `day-of-month field "L": invalid value "L"`,
+			"@daily":        `must have 5 fields`,
+			"0 0 ? * MON#2": `day-of-month field "?": invalid value "?"`,
+		}
+		for expr, want := range cases {
+			_, err := Parse(expr)
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("Parse(%q) error = %v, want %q", expr, err, want)
+			}
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("enforces the GitHub Actions 5-minute floor", func(t *testing.T) {
+		cases := map[string]string{
+			"*/1 * * * *":  "fires every minute",
+			"* 9 * * *":    "fires every minute",
+			"0,3 * * * *":  "fires every 3 minutes",
+			"58 1,2 * * *": "",
+			"58,1 * * * *": "fires every 3 minutes",
+			"58,1 9 * * *": "",
+			"*/5 * * * *":  "",
+			"0 9 * * 1":    "",
+		}
+		for expr, want := range cases {
+			err := Validate(expr)
+			if want == "" {
+				if err != nil {
+					t.Fatalf("Validate(%q) returned error: %v", expr, err)
+				}
+				continue
+			}
+			if err == nil || !strings.Contains(err.Error(), want) || !strings.Contains(err.Error(), "at most every 5 minutes") {
+				t.Fatalf("Validate(%q) error = %v, want %q", expr, err, want)
+			}
+		}
+	})
+
+	t.Run("rejects schedules that never fire", func(t *testing.T) {
+		if err := Validate("0 0 30 2 *"); err == nil || !strings.Contains(err.Error(), "never fires") {
+			t.Fatalf("expected never fires error, got %v", err)
+		}
+		if err := Validate("0 0 29 2 *"); err != nil {
+			t.Fatalf("leap days should validate: %v", err)
+		}
+	})
+}
+
+func TestNext(t *testing.T) {
+	from := time.Date(2026, 10, 17, 9, 30, 45, 0, time.UTC) // a Saturday
+
+	t.Run("computes upcoming fire times", func(t *testing.T) {
+		cases := map[string][]string{
+			"0 9 * * 1":            {"2026-10-19 09:00", "2026-10-26 09:00"},
+			"*/20 * * * *":         {"2026-10-17 09:40", "2026-10-17 10:00", "2026-10-17 10:20"},
+			"30 9 * * *":           {"2026-10-18 09:30", "2026-10-19 09:30"},
+			"15 3 1 JAN,JUL *":     {"2027-01-01 03:15", "2027-07-01 03:15"},
+			"0 12 * * MON-FRI":     {"2026-10-19 12:00", "2026-10-20 12:00"},
+			"0 0 13 * FRI":         {"2026-10-23 00:00", "2026-10-30 00:00", "2026-11-06 00:00"},
+			"0 0 */10 * *":         {"2026-10-21 00:00", "2026-10-31 00:00", "2026-11-01 00:00"},
+			"0 6 29 2 *":           {"2028-02-29 06:00"},
+			"45 23 31 DEC SAT,SUN": {"2026-12-05 23:45", "2026-12-06 23:45", "2026-12-12 23:45"},
+		}
+		for expr, want := range cases {
+			var got []string
+			for _, next := range mustParse(t, expr).NextN(from, len(want)) {
+				got = append(got, next.Format("2006-01-02 15:04"))
+			}
+			if strings.Join(got, ", ") != strings.Join(want, ", ") {
+				t.Fatalf("NextN(%q) = %v, want %v", expr, got, want)
+			}
+		}
+	})
+
+	t.Run("returns times strictly after the given time in UTC", func(t *testing.T) {
+		s := mustParse(t, "0 10 * * *")
+		at := time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC)
+		if got := s.Next(at); !got.Equal(at.Add(24 * time.Hour)) {
+			t.Fatalf("Next(%v) = %v", at, got)
+		}
+		local := at.In(time.FixedZone("CEST", 2*60*60))
+		if got := s.Next(local.Add(-time.Minute)); got.Location() != time.UTC || !got.Equal(at) {
+			t.Fatalf("Next(%v) = %v, want %v UTC", local, got, at)
+		}
+	})
+
+	t.Run("returns zero for schedules that never fire", func(t *testing.T) {
+		if got := mustParse(t, "0 0 31 4 *").Next(from); !got.IsZero() {
+			t.Fatalf("expected zero time, got %v", got)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	weekdayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+	monthNames   = []string{"", "January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+)
+
+// Describe renders the schedule in plain English, for example
+// "every 6 hours on weekdays" or "at 02:30 UTC on day 1 of the month".
+func (s *Schedule) Describe() string {
+	return s.DescribeIn("UTC")
+}
+
+// DescribeIn is Describe for an expression written in another time zone; zone
+// replaces "UTC" after clock times.
+func (s *Schedule) DescribeIn(zone string) string {
+	fields := strings.Fields(s.expr)
+	parts := []string{s.describeTime(fields[0], fields[1], zone)}
+	if days := s.describeDays(fields[2], fields[4]); days != "" {
+		parts = append(parts, days)
+	}
+	if months := values(s.month, monthField); len(months) < 12 {
+		parts = append(parts, "in "+joinValues(months, func(m int) string { return monthNames[m] }))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (s *Schedule) describeTime(minuteRaw, hourRaw, zone string) string {
+	minutes := values(s.minute, minuteField)
+	hours := values(s.hour, hourField)
+
+	if len(minutes) == 1 {
+		m := minutes[0]
+		atMinute := ""
+		if m != 0 {
+			atMinute = fmt.Sprintf(" at :%02d", m)
+		}
+		if len(hours) == 24 {
+			return "every hour" + atMinute
+		}
+		if step, ok := stepOf(hourRaw); ok {
+			if strings.HasPrefix(hourRaw, "*/") {
+				return fmt.Sprintf("every %d hours%s", step, atMinute)
+			}
+			return fmt.Sprintf("every %d hours from %s to %s %s", step, clock(hours[0], m), clock(hours[len(hours)-1], m), zone)
+		}
+		if isRange(hourRaw) {
+			return fmt.Sprintf("every hour from %s to %s %s", clock(hours[0], m), clock(hours[len(hours)-1], m), zone)
+		}
+		if len(hours) <= 4 {
+			return "at " + joinValues(hours, func(h int) string { return clock(h, m) }) + " " + zone
+		}
+		return fmt.Sprintf("at :%02d past hours %s %s", m, joinValues(hours, strconv.Itoa), zone)
+	}
+
+	var phrase string
+	if step, ok := stepOf(minuteRaw); ok && strings.HasPrefix(minuteRaw, "*/") {
+		phrase = fmt.Sprintf("every %d minutes", step)
+	} else if len(minutes) == 60 {
+		phrase = "every minute"
+	} else if step, ok := stepOf(minuteRaw); ok {
+		phrase = fmt.Sprintf("every %d minutes from :%02d to :%02d", step, minutes[0], minutes[len(minutes)-1])
+	} else {
+		phrase = "at " + joinValues(minutes, func(m int) string { return fmt.Sprintf(":%02d", m) })
+		if len(hours) == 24 {
+			return "every hour " + phrase
+		}
+	}
+	switch {
+	case len(hours) == 24:
+		return phrase
+	case len(hours) == 1:
+		return fmt.Sprintf("%s from %s to %s %s", phrase, clock(hours[0], 0), clock(hours[0], 59), zone)
+	default:
+		return fmt.Sprintf("%s during hours %s %s", phrase, joinValues(hours, strconv.Itoa), zone)
+	}
+}
+
+// describeDays renders the day-of-month and day-of-week fields. When both are
+// restricted the schedule fires on either, as in POSIX cron.
+func (s *Schedule) describeDays(domRaw, dowRaw string) string {
+	var dom, dow string
+	if days := values(s.dom, domField); len(days) < 31 {
+		if len(days) == 1 {
+			dom = fmt.Sprintf("on day %d of the month", days[0])
+		} else {
+			dom = "on days " + joinValues(days, strconv.Itoa) + " of the month"
+		}
+	}
+	if days := values(s.dow, dowField); len(days) < 7 {
+		dow = "on " + describeWeekdays(days)
+	}
+	switch {
+	case dom == "":
+		return dow
+	case dow == "":
+		return dom
+	case s.domAny || s.dowAny:
+		return dom + " if it is also " + strings.TrimPrefix(dow, "on ")
+	default:
+		return dom + " or " + dow
+	}
+}
+
+func describeWeekdays(days []int) string {
+	switch fmt.Sprint(days) {
+	case "[1 2 3 4 5]":
+		return "weekdays"
+	case "[0 6]":
+		return "weekends"
+	}
+	if len(days) == 1 {
+		return weekdayNames[days[0]] + "s"
+	}
+	return joinValues(days, func(d int) string { return weekdayNames[d][:3] })
+}
+
+// values lists the values set in a field, in order.
+func values(set uint64, f field) []int {
+	var out []int
+	for n := f.min; n <= f.max; n++ {
+		if has(set, n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// joinValues renders values as an English list, collapsing runs of three or
+// more consecutive values into ranges: "1-5, 10 and 15".
+func joinValues(vals []int, name func(int) string) string {
+	var items []string
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, name(vals[i])+"-"+name(vals[j]))
+			i = j + 1
+			continue
+		}
+		items = append(items, name(vals[i]))
+		i++
+	}
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+func stepOf(raw string) (int, bool) {
+	i := strings.Index(raw, "/")
+	if i < 0 || strings.Contains(raw, ",") {
+		return 0, false
+	}
+	step, err := strconv.Atoi(raw[i+1:])
+	return step, err == nil && step > 1
+}
+
+func isRange(raw string) bool {
+	return strings.Contains(raw, "-") && !strings.ContainsAny(raw, ",/")
+}
+
+func clock(hour, minute int) string {
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
@@ -0,0 +1,58 @@
+package cron
+
+import "testing"
+
+// describe.go tests
+
+func TestDescribe(t *testing.T) {
+	t.Run("renders cron expressions in English", func(t *testing.T) {
+		cases := map[string]string{
+			"0 */6 * * 1-5":       "every 6 hours on weekdays",
+			"30 2 1 * *":          "at 02:30 UTC on day 1 of the month",
+			"15 */4 * * *":        "every 4 hours at :15",
+			"0 * * * 0,6":         "every hour on weekends",
+			"0 9,13,17 * * *":     "at 09:00, 13:00 and 17:00 UTC",
+			"30 9-17 * * MON-FRI": "every hour from 09:30 to 17:30 UTC on weekdays",
+			"0 8-20/4 * * *":      "every 4 hours from 08:00 to 20:00 UTC",
+			"*/15 * * * *":        "every 15 minutes",
+			"*/10 9 * * *":        "every 10 minutes from 09:00 to 09:59 UTC",
+			"0,30 * * * *":        "every hour at :00 and :30",
+			"0,30 9-17 * * *":     "at :00 and :30 during hours 9-17 UTC",
+			"0 0 1,15 * *":        "at 00:00 UTC on days 1 and 15 of the month",
+			"0 6 * JAN,JUL *":     "at 06:00 UTC in January and July",
+			"45 23 1 * FRI":       "at 23:45 UTC on day 1 of the month or on Fridays",
+			"0 12 * * 3":          "at 12:00 UTC on Wednesdays",
+			"0 12 * * 1,3,5":      "at 12:00 UTC on Mon, Wed and Fri",
+			"0 3 */10 * *":        "at 03:00 UTC on days 1, 11, 21 and 31 of the month",
+			"0 0,2,4,6,8 * * *":   "at :00 past hours 0, 2, 4, 6 and 8 UTC",
+			"0 4 1-7 3-5 *":       "at 04:00 UTC on days 1-7 of the month in March-May",
+		}
+		for expr, want := range cases {
+			s, err := Parse(expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", expr, err)
+			}
+			if got := s.Describe(); got != want {
+				t.Fatalf("Describe(%q) = %q, want %q", expr, got, want)
+			}
+		}
+	})
+
+	t.Run("names the zone a schedule is written in", func(t *testing.T) {
+		cases := map[string]string{
+			"0 9 * * 1-5":     "at 09:00 Europe/Berlin on weekdays",
+			"30 8-18 * * *":   "every hour from 08:30 to 18:30 Europe/Berlin",
+			"*/15 * * * *":    "every 15 minutes",
+			"0,30 9-17 * * *": "at :00 and :30 during hours 9-17 Europe/Berlin",
+		}
+		for expr, want := range cases {
+			s, err := Parse(expr)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", expr, err)
+			}
+			if got := s.DescribeIn("Europe/Berlin"); got != want {
+				t.Fatalf("DescribeIn(%q) = %q, want %q", expr, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,150 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ToUTC converts expr, read as wall-clock time in loc, into the UTC cron
+// expressions that fire at the same instants while loc keeps the UTC offset it
+// has at the given time. Times that cross midnight in UTC land in a separate
+// expression with their weekdays shifted, so one local schedule may need
+// several UTC ones. Schedules restricting the day of month or month can only
+// be converted when no time crosses midnight.
+func ToUTC(expr string, loc *time.Location, at time.Time) ([]string, error) {
+	s, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	_, offset := at.In(loc).Zone()
+	if offset%60 != 0 {
+		return nil, fmt.Errorf("%s is %ds off UTC at %s; cron only has minute precision", loc, offset, at.Format("2006-01-02"))
+	}
+	if offset == 0 {
+		return []string{s.expr}, nil
+	}
+	fields := strings.Fields(s.expr)
+	shift := -offset / 60
+
+	// times[d] holds the UTC minute-of-day values that fall d-1 days after
+	// the local day.
+	var times [3][]int
+	for h := 0; h < 24; h++ {
+		if !has(s.hour, h) {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if !has(s.minute, m) {
+				continue
+			}
+			utc := h*60 + m + shift
+			day := 1
+			switch {
+			case utc < 0:
+				utc += 24 * 60
+				day = 0
+			case utc >= 24*60:
+				utc -= 24 * 60
+				day = 2
+			}
+			times[day] = append(times[day], utc)
+		}
+	}
+
+	anyDay := s.dom == fullSet(domField) && s.month == fullSet(monthField) && s.dow == fullSet(dowField)
+	if anyDay {
+		times = [3][]int{nil, append(append(times[0], times[1]...), times[2]...), nil}
+	}
+
+	var out []string
+	for day, group := range times {
+		if len(group) == 0 {
+			continue
+		}
+		minuteRaw, hourRaw, err := timeFields(group, s.expr)
+		if err != nil {
+			return nil, err
+		}
+		if offset%3600 == 0 {
+			minuteRaw = fields[0]
+		}
+		domRaw, monthRaw, dowRaw := fields[2], fields[3], fields[4]
+		if delta := day - 1; delta != 0 {
+			if s.dom != fullSet(domField) || s.month != fullSet(monthField) {
+				return nil, fmt.Errorf("cron %q crosses midnight in UTC and restricts the day of month or month; it cannot be converted from %s", s.expr, loc)
+			}
+			if s.dow != fullSet(dowField) {
+				dowRaw = formatSet(shiftWeekdays(s.dow, delta), dowField)
+			}
+		}
+		out = append(out, strings.Join([]string{minuteRaw, hourRaw, domRaw, monthRaw, dowRaw}, " "))
+	}
+	return out, nil
+}
+
+// timeFields renders minute-of-day values as minute and hour fields. Every
+// listed minute must fire in every listed hour, as one cron expression cannot
+// say otherwise.
+func timeFields(times []int, expr string) (string, string, error) {
+	var minutes, hours uint64
+	seen := map[int]bool{}
+	for _, t := range times {
+		minutes |= 1 << uint(t%60)
+		hours |= 1 << uint(t/60)
+		seen[t] = true
+	}
+	for h := 0; h < 24; h++ {
+		for m := 0; m < 60; m++ {
+			if has(hours, h) && has(minutes, m) && !seen[h*60+m] {
+				return "", "", fmt.Errorf("cron %q fires at different minutes in different UTC hours; it cannot be written as one UTC cron", expr)
+			}
+		}
+	}
+	return formatSet(minutes, minuteField), formatSet(hours, hourField), nil
+}
+
+func shiftWeekdays(set uint64, delta int) uint64 {
+	var out uint64
+	for d := 0; d < 7; d++ {
+		if has(set, d) {
+			out |= 1 << uint((d+delta+7)%7)
+		}
+	}
+	return out
+}
+
+func fullSet(f field) uint64 {
+	var set uint64
+	for n := f.min; n <= f.max; n++ {
+		set |= 1 << uint(n)
+	}
+	return set
+}
+
+// formatSet renders a field's values compactly: "*", or a comma list with runs
+// of three or more written as ranges ("1-5,7").
+func formatSet(set uint64, f field) string {
+	if set == fullSet(f) {
+		return "*"
+	}
+	vals := values(set, f)
+	var items []string
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			items = append(items, strconv.Itoa(vals[i])+"-"+strconv.Itoa(vals[j]))
+		case j > i:
+			items = append(items, strconv.Itoa(vals[i]), strconv.Itoa(vals[j]))
+		default:
+			items = append(items, strconv.Itoa(vals[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,111 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// timezone.go tests
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	return loc
+}
+
+func TestToUTC(t *testing.T) {
+	summer := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	winter := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("shifts hours by the offset in effect", func(t *testing.T) {
+		berlin := mustLoad(t, "Europe/Berlin")
+		cases := []struct {
+			expr string
+			at   time.Time
+			want string
+		}{
+			{"0 9 * * 1-5", summer, "0 7 * * 1-5"},
+			{"0 9 * * 1-5", winter, "0 8 * * 1-5"},
+			{"*/15 9-17 * * *", summer, "*/15 7-15 * * *"},
+			{"30 2 1 * *", winter, "30 1 1 * *"},
+			{"0 0,12 * * *", summer, "0 10,22 * * *"},
+		}
+		for _, tc := range cases {
+			got, err := ToUTC(tc.expr, berlin, tc.at)
+			if err != nil {
+				t.Fatalf("ToUTC(%q) returned error: %v", tc.expr, err)
+			}
+			if strings.Join(got, " | ") != tc.want {
+				t.Fatalf("ToUTC(%q, %s) = %q, want %q", tc.expr, tc.at.Format("Jan"), got, tc.want)
+			}
+		}
+	})
+
+	t.Run("shifts weekdays when a time crosses midnight", func(t *testing.T) {
+		newYork := mustLoad(t, "America/New_York")
+		got, err := ToUTC("0 9,21 * * MON-FRI", newYork, winter)
+		if err != nil {
+			t.Fatalf("ToUTC returned error: %v", err)
+		}
+		if want := "0 14 * * MON-FRI | 0 2 * * 2-6"; strings.Join(got, " | ") != want {
+			t.Fatalf("ToUTC = %q, want %q", got, want)
+		}
+
+		tokyo := mustLoad(t, "Asia/Tokyo")
+		got, err = ToUTC("0 6 * * 0,6", tokyo, winter)
+		if err != nil {
+			t.Fatalf("ToUTC returned error: %v", err)
+		}
+		if want := "0 21 * * 5,6"; strings.Join(got, " | ") != want {
+			t.Fatalf("ToUTC = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("handles half-hour offsets", func(t *testing.T) {
+		kolkata := mustLoad(t, "Asia/Kolkata")
+		got, err := ToUTC("0 9 * * *", kolkata, winter)
+		if err != nil {
+			t.Fatalf("ToUTC returned error: %v", err)
+		}
+		if want := "30 3 * * *"; strings.Join(got, " | ") != want {
+			t.Fatalf("ToUTC = %q, want %q", got, want)
+		}
+		if _, err := ToUTC("0,45 9,10 * * *", kolkata, winter); err == nil || !strings.Contains(err.Error(), "different minutes") {
+			t.Fatalf("expected uneven minutes error, got %v", err)
+		}
+	})
+
+	t.Run("fires at the same instants as the local schedule", func(t *testing.T) {
+		berlin := mustLoad(t, "Europe/Berlin")
+		local := mustParse(t, "30 8 * * 1-5")
+		utc, err := ToUTC(local.String(), berlin, winter)
+		if err != nil {
+			t.Fatalf("ToUTC returned error: %v", err)
+		}
+		next := mustParse(t, utc[0]).NextN(winter, 5)
+		for _, at := range next {
+			wall := at.In(berlin)
+			if wall.Hour() != 8 || wall.Minute() != 30 || wall.Weekday() == time.Saturday || wall.Weekday() == time.Sunday {
+				t.Fatalf("UTC cron %q fires at %v local", utc[0], wall)
+			}
+		}
+	})
+
+	t.Run("rejects day-of-month schedules that cross midnight", func(t *testing.T) {
+		newYork := mustLoad(t, "America/New_York")
+		if _, err := ToUTC("0 22 1 * *", newYork, winter); err == nil || !strings.Contains(err.Error(), "crosses midnight") {
+			t.Fatalf("expected crosses midnight error, got %v", err)
+		}
+	})
+
+	t.Run("leaves UTC schedules alone", func(t *testing.T) {
+		got, err := ToUTC("5,35  8-18/2 * * MON", time.UTC, summer)
+		if err != nil || strings.Join(got, " | ") != "5,35 8-18/2 * * MON" {
+			t.Fatalf("ToUTC = %q, %v", got, err)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WorkflowRun is the subset of a GitHub Actions run tender reports on.
+type WorkflowRun struct {
+	ID           int64     `json:"id"`
+	Event        string    `json:"event"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	HeadBranch   string    `json:"head_branch"`
+	HTMLURL      string    `json:"html_url"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	RunStartedAt time.Time `json:"run_started_at"`
+}
+
+// Job is one job of a run with its step progress.
+type Job struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	Steps      []Step `json:"steps"`
+}
+
+// Step is one step of a job.
+type Step struct {
+	Name       string `json:"name"`
+	Number     int    `json:"number"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// DispatchWorkflow triggers a workflow_dispatch run of workflowFile on ref.
+func (c *Client) DispatchWorkflow(workflowFile, ref string, inputs map[string]string) error {
+	body := map[string]interface{}{"ref": ref}
+	if len(inputs) > 0 {
+		body["inputs"] = inputs
+	}
+	return c.send(http.MethodPost, c.repoPath("/actions/workflows/%s/dispatches", url.PathEscape(workflowFile)), body)
+}
+
+// ListWorkflowRuns returns the newest runs of a workflow, optionally only
+// those triggered by event.
+func (c *Client) ListWorkflowRuns(workflowFile string, limit int, event string) ([]WorkflowRun, error) {
+	var resp struct {
+		WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+	}
+	query := url.Values{"per_page": {fmt.Sprint(limit)}}
+	if event != "" {
+		query.Set("event", event)
+	}
+	if err := c.getJSON(c.repoPath("/actions/workflows/%s/runs", url.PathEscape(workflowFile)), query, &resp); err != nil {
+		return nil, err
+	}
+	return resp.WorkflowRuns, nil
+}
+
+// GetRun returns one workflow run.
+func (c *Client) GetRun(runID int64) (WorkflowRun, error) {
+	var run WorkflowRun
+	err := c.getJSON(c.repoPath("/actions/runs/%d", runID), nil, &run)
+	return run, err
+}
+
+// ListRunJobs returns the jobs of a run with their steps.
+func (c *Client) ListRunJobs(runID int64) ([]Job, error) {
+	var resp struct {
+		Jobs []Job `json:"jobs"`
+	}
+	if err := c.getJSON(c.repoPath("/actions/runs/%d/jobs", runID), nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Jobs, nil
+}
+
+// DownloadRunLogs returns the zip archive of a run's logs. GitHub answers with
+// a redirect to short-lived storage, which the HTTP client follows.
+func (c *Client) DownloadRunLogs(runID int64) ([]byte, error) {
+	resp, err := c.do(http.MethodGet, c.repoPath("/actions/runs/%d/logs", runID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,137 @@
+// Package github is a small GitHub REST client covering what tender needs:
+// workflow dispatch, run listing, run logs and Actions secrets.
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the REST API of github.com.
+const DefaultBaseURL = "https://api.github.com"
+
+// Client talks to the REST API of one repository. BaseURL points at
+// github.com, a GitHub Enterprise Server (https://HOST/api/v3) or a test
+// server.
+type Client struct {
+	BaseURL string
+	Token   string
+	Repo    string // owner/name
+	HTTP    *http.Client
+}
+
+// New returns a client for repo using the API base URL from the environment.
+func New(repo, token string) *Client {
+	return &Client{
+		BaseURL: BaseURLFromEnv(),
+		Token:   token,
+		Repo:    repo,
+		HTTP:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// BaseURLFromEnv returns GITHUB_API_URL (set on Actions runners and GHES),
+// the GHES API of GH_HOST as gh uses it, or DefaultBaseURL.
+func BaseURLFromEnv() string {
+	if base := strings.TrimRight(strings.TrimSpace(os.Getenv("GITHUB_API_URL")), "/"); base != "" {
+		return base
+	}
+	if host := strings.ToLower(strings.TrimSpace(os.Getenv("GH_HOST"))); host != "" && host != "github.com" {
+		return "https://" + host + "/api/v3"
+	}
+	return DefaultBaseURL
+}
+
+// HostFromEnv returns the host of the configured API, github.com by default.
+func HostFromEnv() string {
+	return HostFromBaseURL(BaseURLFromEnv())
+}
+
+// APIError is a non-2xx answer from the API.
+type APIError struct {
+	Path       string
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("GitHub API %s: %s %s", e.Path, e.Status, e.Body)
+}
+
+// IsNotFound reports whether err is a 404 from the API.
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+func (c *Client) do(method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	endpoint := strings.TrimRight(c.BaseURL, "/") + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GitHub API request failed: %w", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &APIError{Path: path, StatusCode: resp.StatusCode, Status: resp.Status, Body: strings.TrimSpace(string(data))}
+	}
+	return resp, nil
+}
+
+func (c *Client) getJSON(path string, query url.Values, v interface{}) error {
+	resp, err := c.do(http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// send issues a request whose response body tender does not need.
+func (c *Client) send(method, path string, body interface{}) error {
+	resp, err := c.do(method, path, nil, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (c *Client) repoPath(format string, args ...interface{}) string {
+	return "/repos/" + c.Repo + fmt.Sprintf(format, args...)
+}
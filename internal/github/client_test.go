@@ -0,0 +1,57 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// client.go tests
+
+func TestClient(t *testing.T) {
+	t.Run("reads the base URL from the environment", func(t *testing.T) {
+		t.Setenv("GITHUB_API_URL", "")
+		if got := BaseURLFromEnv(); got != DefaultBaseURL {
+			t.Fatalf("BaseURLFromEnv() = %q, want %q", got, DefaultBaseURL)
+		}
+		t.Setenv("GITHUB_API_URL", "https://ghe.example.com/api/v3/")
+		if got := New("acme/widgets", "").BaseURL; got != "https://ghe.example.com/api/v3" {
+			t.Fatalf("BaseURL = %q", got)
+		}
+	})
+
+	t.Run("sends credentials and API headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Accept") != "application/vnd.github+json" {
+				http.Error(w, "bad request headers", http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"default_branch": "trunk"}`)
+		}))
+		defer server.Close()
+
+		client := &Client{BaseURL: server.URL, Token: "secret", Repo: "acme/widgets"}
+		branch, err := client.DefaultBranch()
+		if err != nil || branch != "trunk" {
+			t.Fatalf("DefaultBranch() = %q, %v", branch, err)
+		}
+	})
+
+	t.Run("returns API errors with status and body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"message": "Not Found"}`, http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		client := &Client{BaseURL: server.URL, Repo: "acme/widgets"}
+		_, err := client.GetRun(9)
+		if !IsNotFound(err) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+		want := `GitHub API /repos/acme/widgets/actions/runs/9: 404 Not Found {"message": "Not Found"}`
+		if err.Error() != want {
+			t.Fatalf("error = %q, want %q", err.Error(), want)
+		}
+	})
+}
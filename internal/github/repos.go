@@ -0,0 +1,53 @@
+package github
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// DefaultBranch returns the repository's default branch.
+func (c *Client) DefaultBranch() (string, error) {
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := c.getJSON(c.repoPath(""), nil, &repo); err != nil {
+		return "", err
+	}
+	return repo.DefaultBranch, nil
+}
+
+// CountCommits returns how many commits on branch match the filters in query
+// (author, since, until), capped at one page.
+func (c *Client) CountCommits(branch string, query url.Values) (int, error) {
+	var commits []json.RawMessage
+	q := url.Values{"sha": {branch}, "per_page": {"1"}}
+	for k, v := range query {
+		q[k] = v
+	}
+	if err := c.getJSON(c.repoPath("/commits"), q, &commits); err != nil {
+		return 0, err
+	}
+	return len(commits), nil
+}
+
+// PullRequest is the subset of a pull request tender reports on.
+type PullRequest struct {
+	Number int    `json:"number"`
+	State  string `json:"state"`
+}
+
+// FindPullRequest returns the newest pull request from headBranch in any
+// state, or nil when there is none.
+func (c *Client) FindPullRequest(headBranch string) (*PullRequest, error) {
+	owner := strings.SplitN(c.Repo, "/", 2)[0]
+	var prs []PullRequest
+	q := url.Values{"head": {owner + ":" + headBranch}, "state": {"all"}, "per_page": {"1"}}
+	if err := c.getJSON(c.repoPath("/pulls"), q, &prs); err != nil {
+		return nil, err
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return &prs[0], nil
+}
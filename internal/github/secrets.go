@@ -0,0 +1,71 @@
+package github
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// Secret is an Actions repository secret. The API never returns values.
+type Secret struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ListSecrets returns the names of the repository's Actions secrets.
+func (c *Client) ListSecrets() ([]Secret, error) {
+	var resp struct {
+		Secrets []Secret `json:"secrets"`
+	}
+	if err := c.getJSON(c.repoPath("/actions/secrets"), url.Values{"per_page": {"100"}}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Secrets, nil
+}
+
+// SetSecret creates or updates an Actions repository secret. The value is
+// sealed with the repository's public key before it leaves the machine.
+func (c *Client) SetSecret(name, value string) error {
+	var key struct {
+		KeyID string `json:"key_id"`
+		Key   string `json:"key"`
+	}
+	if err := c.getJSON(c.repoPath("/actions/secrets/public-key"), nil, &key); err != nil {
+		return err
+	}
+	sealed, err := sealSecret(key.Key, value)
+	if err != nil {
+		return err
+	}
+	return c.send(http.MethodPut, c.repoPath("/actions/secrets/%s", url.PathEscape(name)), map[string]string{
+		"encrypted_value": sealed,
+		"key_id":          key.KeyID,
+	})
+}
+
+// DeleteSecret removes an Actions repository secret.
+func (c *Client) DeleteSecret(name string) error {
+	return c.send(http.MethodDelete, c.repoPath("/actions/secrets/%s", url.PathEscape(name)), nil)
+}
+
+// sealSecret encrypts value for the base64 Curve25519 public key GitHub hands
+// out, as a libsodium sealed box.
+func sealSecret(publicKey, value string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(raw) != 32 {
+		return "", fmt.Errorf("invalid repository public key")
+	}
+	var recipient [32]byte
+	copy(recipient[:], raw)
+	sealed, err := box.SealAnonymous(nil, []byte(value), &recipient, rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
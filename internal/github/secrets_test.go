@@ -0,0 +1,88 @@
+package github
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// secrets.go tests
+
+func TestSecrets(t *testing.T) {
+	t.Run("seals values with the repository public key", func(t *testing.T) {
+		pub, priv, err := box.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("GenerateKey: %v", err)
+		}
+		var stored struct {
+			EncryptedValue string `json:"encrypted_value"`
+			KeyID          string `json:"key_id"`
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/widgets/actions/secrets/public-key":
+				fmt.Fprintf(w, `{"key_id": "k1", "key": %q}`, base64.StdEncoding.EncodeToString(pub[:]))
+			case r.Method == http.MethodPut && r.URL.Path == "/repos/acme/widgets/actions/secrets/OPENAI_API_KEY":
+				if err := json.NewDecoder(r.Body).Decode(&stored); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+
+		client := &Client{BaseURL: server.URL, Repo: "acme/widgets"}
+		if err := client.SetSecret("OPENAI_API_KEY", "sk-test"); err != nil {
+			t.Fatalf("SetSecret: %v", err)
+		}
+		if stored.KeyID != "k1" {
+			t.Fatalf("key_id = %q", stored.KeyID)
+		}
+		sealed, err := base64.StdEncoding.DecodeString(stored.EncryptedValue)
+		if err != nil {
+			t.Fatalf("encrypted_value is not base64: %v", err)
+		}
+		plain, ok := box.OpenAnonymous(nil, sealed, pub, priv)
+		if !ok || string(plain) != "sk-test" {
+			t.Fatalf("could not open sealed secret: %q, %v", plain, ok)
+		}
+	})
+
+	t.Run("lists and deletes secrets", func(t *testing.T) {
+		deleted := ""
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, `{"total_count": 2, "secrets": [{"name": "ANTHROPIC_API_KEY"}, {"name": "OPENAI_API_KEY"}]}`)
+			case http.MethodDelete:
+				deleted = r.URL.Path
+				w.WriteHeader(http.StatusNoContent)
+			}
+		}))
+		defer server.Close()
+
+		client := &Client{BaseURL: server.URL, Repo: "acme/widgets"}
+		secrets, err := client.ListSecrets()
+		if err != nil || len(secrets) != 2 || secrets[1].Name != "OPENAI_API_KEY" {
+			t.Fatalf("ListSecrets() = %+v, %v", secrets, err)
+		}
+		if err := client.DeleteSecret("OPENAI_API_KEY"); err != nil || deleted != "/repos/acme/widgets/actions/secrets/OPENAI_API_KEY" {
+			t.Fatalf("DeleteSecret: %v (deleted %q)", err, deleted)
+		}
+	})
+
+	t.Run("rejects malformed public keys", func(t *testing.T) {
+		if _, err := sealSecret("bm90IGEga2V5", "value"); err == nil {
+			t.Fatal("expected invalid key error")
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Token returns credentials for the API at baseURL, the same way the GitHub
+// CLI finds them: GH_TOKEN/GITHUB_TOKEN (GH_ENTERPRISE_TOKEN/
+// GITHUB_ENTERPRISE_TOKEN for other hosts), then the oauth_token in gh's
+// hosts.yml, then `gh auth token` for credentials gh keeps in the system
+// keyring. An empty token still works for public repositories.
+func Token(baseURL string) string {
+	host := HostFromBaseURL(baseURL)
+	keys := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if host != "github.com" {
+		keys = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN", "GH_TOKEN", "GITHUB_TOKEN"}
+	}
+	for _, key := range keys {
+		if token := strings.TrimSpace(os.Getenv(key)); token != "" {
+			return token
+		}
+	}
+	if token := ghConfigToken(host); token != "" {
+		return token
+	}
+	return ghAuthToken(host)
+}
+
+// HostFromBaseURL maps an API base URL to the host gh stores credentials
+// under: api.github.com is github.com, GHES URLs use their own host.
+func HostFromBaseURL(baseURL string) string {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return "github.com"
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "api.github.com" {
+		return "github.com"
+	}
+	return host
+}
+
+// ghConfigDir follows gh's lookup: GH_CONFIG_DIR, XDG_CONFIG_HOME/gh,
+// %AppData%/GitHub CLI on Windows, else ~/.config/gh.
+func ghConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI")
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh")
+}
+
+func ghConfigToken(host string) string {
+	dir := ghConfigDir()
+	if dir == "" {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "hosts.yml"))
+	if err != nil {
+		return ""
+	}
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(hosts[host].OAuthToken)
+}
+
+func ghAuthToken(host string) string {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "gh", "auth", "token", "--hostname", host)
+	cmd.Stderr = io.Discard
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,86 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// token.go tests
+
+func TestToken(t *testing.T) {
+	setup := func(t *testing.T, hosts string) {
+		t.Helper()
+		for _, key := range []string{"GH_TOKEN", "GITHUB_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+			t.Setenv(key, "")
+		}
+		dir := t.TempDir()
+		t.Setenv("GH_CONFIG_DIR", dir)
+		t.Setenv("PATH", t.TempDir())
+		if hosts != "" {
+			if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0o600); err != nil {
+				t.Fatalf("write hosts.yml: %v", err)
+			}
+		}
+	}
+	const hosts = `github.com:
+    user: octocat
+    oauth_token: gho_public
+ghe.example.com:
+    oauth_token: gho_enterprise
+`
+
+	t.Run("prefers environment tokens", func(t *testing.T) {
+		setup(t, hosts)
+		t.Setenv("GITHUB_TOKEN", "from-github-token")
+		if got := Token(DefaultBaseURL); got != "from-github-token" {
+			t.Fatalf("Token() = %q", got)
+		}
+		t.Setenv("GH_TOKEN", "from-gh-token")
+		if got := Token(DefaultBaseURL); got != "from-gh-token" {
+			t.Fatalf("Token() = %q", got)
+		}
+	})
+
+	t.Run("uses enterprise tokens for other hosts", func(t *testing.T) {
+		setup(t, hosts)
+		t.Setenv("GH_TOKEN", "public")
+		t.Setenv("GH_ENTERPRISE_TOKEN", "enterprise")
+		if got := Token("https://ghe.example.com/api/v3"); got != "enterprise" {
+			t.Fatalf("Token() = %q", got)
+		}
+	})
+
+	t.Run("falls back to gh hosts.yml", func(t *testing.T) {
+		setup(t, hosts)
+		if got := Token(DefaultBaseURL); got != "gho_public" {
+			t.Fatalf("Token() = %q", got)
+		}
+		if got := Token("https://ghe.example.com/api/v3"); got != "gho_enterprise" {
+			t.Fatalf("Token() = %q", got)
+		}
+	})
+
+	t.Run("returns empty without credentials", func(t *testing.T) {
+		setup(t, "")
+		if got := Token(DefaultBaseURL); got != "" {
+			t.Fatalf("Token() = %q, want empty", got)
+		}
+	})
+}
+
+func TestHostFromBaseURL(t *testing.T) {
+	t.Run("maps API URLs to gh hosts", func(t *testing.T) {
+		cases := map[string]string{
+			"https://api.github.com":         "github.com",
+			"https://ghe.example.com/api/v3": "ghe.example.com",
+			"http://127.0.0.1:4321":          "127.0.0.1",
+			"not a url":                      "github.com",
+		}
+		for baseURL, want := range cases {
+			if got := HostFromBaseURL(baseURL); got != want {
+				t.Fatalf("HostFromBaseURL(%q) = %q, want %q", baseURL, got, want)
+			}
+		}
+	})
+}
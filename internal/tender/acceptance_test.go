@@ -71,7 +71,7 @@ func TestAcceptanceScheduleOnlyExcludedFromManualEventInAct(t *testing.T) {
 		Name:   "nightly-refactor",
 		Agent:  "refactor_bot",
 		Prompt: "",
-		Cron:   "0 2 * * 1,2,3,4,5",
+		Crons:  []string{"0 2 * * 1,2,3,4,5"},
 		Manual: false,
 	})
 	if err != nil {
@@ -101,7 +101,7 @@ func TestAcceptanceManualOnlyExcludedFromScheduleEventInAct(t *testing.T) {
 		Name:   "adhoc-fixer",
 		Agent:  "fixer",
 		Prompt: "Fix obvious issues",
-		Cron:   "",
+		Crons:  nil,
 		Manual: true,
 	})
 	if err != nil {
@@ -186,9 +186,14 @@ func TestAcceptanceTTY_NumberThenEnter_DoesNotSkipName(t *testing.T) {
 		"expect \"Agent\"",
 		"expect -re {Choose .*:}",
 		"send \"1\\r\"",
+		"expect \"Target branch\"",
+		"send \"\\r\"",
 		"expect \"Run on every push to main?\"",
 		"expect -re {Choose .*:}",
 		"send \"2\\r\"",
+		"expect \"How should changes land?\"",
+		"expect -re {Choose .*:}",
+		"send \"\\r\"",
 		"expect \"Timeout in minutes\"",
 		"send \"\\r\"",
 		"expect \"Enable recurring schedule?\"",
@@ -239,9 +244,14 @@ func TestAcceptanceTTY_AgentPagingUsesSingleKeyScroll(t *testing.T) {
 		"expect \"Showing 9-10 of 10 (page 2/2)\"",
 		"expect -re {Choose 1-8, 9\\(up\\), 0\\(down\\).*:}",
 		"send \"2\\r\"",
+		"expect \"Target branch\"",
+		"send \"\\r\"",
 		"expect \"Run on every push to main?\"",
 		"expect -re {Choose .*:}",
 		"send \"2\\r\"",
+		"expect \"How should changes land?\"",
+		"expect -re {Choose .*:}",
+		"send \"\\r\"",
 		"expect \"Timeout in minutes\"",
 		"send \"\\r\"",
 		"expect \"Enable recurring schedule?\"",
@@ -400,15 +410,18 @@ func buildTenderCLI(t *testing.T) string {
 func runInteractiveAdd(t *testing.T, fixture string, cli string, name string) {
 	t.Helper()
 	// Scripted input for interactive flow:
-	// action(add) -> name -> agent(default) -> push(default no) -> timeout(default) ->
-	// enable schedule -> weekly -> monday -> 09:00 -> continue -> quit.
+	// action(add) -> name -> agent(default) -> branch(default) -> push(default no) -> delivery(default push) -> timeout(default) ->
+	// enable schedule -> timezone(default UTC) -> weekly -> monday -> 09:00 -> continue -> quit.
 	input := strings.Join([]string{
 		"1",
 		name,
 		"",
 		"",
 		"",
+		"",
+		"",
 		"1",
+		"",
 		"3",
 		"4",
 		"3",
@@ -0,0 +1,214 @@
+package tender
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Chained tenders run after another tender's workflow completes. AfterWhen
+// narrows that down to upstream runs that succeeded, or that pushed a commit
+// to the target branch; an empty AfterWhen means AfterCompleted.
+const (
+	AfterCompleted = "completed"
+	AfterSuccess   = "success"
+	AfterPushed    = "pushed"
+)
+
+func normalizeAfterWhen(when string) string {
+	if w := strings.ToLower(strings.TrimSpace(when)); w != "" {
+		return w
+	}
+	return AfterCompleted
+}
+
+func validateAfter(t Tender) error {
+	after := strings.TrimSpace(t.After)
+	if after == "" {
+		if strings.TrimSpace(t.AfterWhen) != "" {
+			return fmt.Errorf("after-when needs an upstream tender; set after")
+		}
+		return nil
+	}
+	if strings.ContainsAny(after, "\r\n") || strings.Contains(after, "/") {
+		return fmt.Errorf("after %q is not a tender name", after)
+	}
+	if strings.EqualFold(after, strings.TrimSpace(t.Name)) {
+		return fmt.Errorf("tender %q cannot run after itself", strings.TrimSpace(t.Name))
+	}
+	switch normalizeAfterWhen(t.AfterWhen) {
+	case AfterCompleted, AfterSuccess, AfterPushed:
+		return nil
+	}
+	return fmt.Errorf("after-when must be %q, %q or %q", AfterCompleted, AfterSuccess, AfterPushed)
+}
+
+// validateChain checks t's upstream against the loaded tenders: it must exist,
+// following After links from it must not lead back to t, and tenders that only
+// follow pushes must not follow one that delivers through pull requests. An entry named
+// like t is taken to be t's saved version and is ignored.
+func validateChain(t Tender, tenders []Tender) error {
+	name := strings.TrimSpace(t.Name)
+	byName := map[string]Tender{}
+	for _, other := range tenders {
+		if !strings.EqualFold(strings.TrimSpace(other.Name), name) {
+			byName[strings.ToLower(strings.TrimSpace(other.Name))] = other
+		}
+	}
+	for _, other := range byName {
+		if strings.EqualFold(strings.TrimSpace(other.After), name) {
+			if err := validatePushedChain(t, other); err != nil {
+				return err
+			}
+		}
+	}
+	path := []string{name}
+	seen := map[string]bool{}
+	for next := strings.TrimSpace(t.After); next != ""; {
+		path = append(path, next)
+		if strings.EqualFold(next, name) {
+			return fmt.Errorf("tender chain %s is a cycle", strings.Join(path, " -> "))
+		}
+		upstream, ok := byName[strings.ToLower(next)]
+		if !ok {
+			if len(path) == 2 {
+				return fmt.Errorf("tender %q runs after %q, which does not exist", name, next)
+			}
+			return nil
+		}
+		if len(path) == 2 {
+			if err := validatePushedChain(upstream, t); err != nil {
+				return err
+			}
+		}
+		if seen[strings.ToLower(next)] {
+			// A cycle that does not pass through t is reported for the
+			// tenders on it.
+			return nil
+		}
+		seen[strings.ToLower(next)] = true
+		next = strings.TrimSpace(upstream.After)
+	}
+	return nil
+}
+
+// chainDependents lists the tenders that run after name.
+func chainDependents(tenders []Tender, name string) []string {
+	var out []string
+	for _, t := range tenders {
+		if strings.EqualFold(strings.TrimSpace(t.After), strings.TrimSpace(name)) {
+			out = append(out, t.Name)
+		}
+	}
+	return out
+}
+
+// renderChainTrigger returns the `on:` entry that starts t after its upstream
+// tender's workflow, which GitHub matches by workflow name.
+func renderChainTrigger(t Tender) []workflowBlock {
+	after := strings.TrimSpace(t.After)
+	if after == "" {
+		return nil
+	}
+	return []workflowBlock{{Key: "workflow_run", Lines: []string{
+		"workflow_run:",
+		"  workflows: [" + strconv.Quote(tenderNamePrefix+after) + "]",
+		"  types: [completed]",
+	}}}
+}
+
+// chainGuard skips runs whose upstream failed when t only follows successful
+// or pushing upstream runs.
+func chainGuard(t Tender) []string {
+	if strings.TrimSpace(t.After) == "" || normalizeAfterWhen(t.AfterWhen) == AfterCompleted {
+		return nil
+	}
+	return []string{"github.event_name != 'workflow_run' || github.event.workflow_run.conclusion == 'success'"}
+}
+
+// upstreamPushedCondition gates the steps after chainCheckStep.
+const upstreamPushedCondition = "steps.upstream.outputs.pushed == 'true'"
+
+// followsPushes reports whether t only follows upstream runs that pushed a
+// commit.
+func followsPushes(t Tender) bool {
+	return strings.TrimSpace(t.After) != "" && normalizeAfterWhen(t.AfterWhen) == AfterPushed
+}
+
+// chainCheckStep looks for the upstream tender's commit on the target branch
+// and reports in the `pushed` output whether there is one, so the rest of the
+// run is skipped, and says so in the job summary, when there is not. Manual
+// runs always go ahead.
+func chainCheckStep(t Tender) []workflowBlock {
+	if !followsPushes(t) {
+		return nil
+	}
+	return []workflowBlock{{Key: "Check upstream push", Lines: []string{
+		"- name: Check upstream push",
+		"  id: upstream",
+		"  shell: bash",
+		"  env:",
+		"    UPSTREAM_NAME: ${{ github.event.workflow_run.name || '' }}",
+		"    UPSTREAM_SHA: ${{ github.event.workflow_run.head_sha || '' }}",
+		"  run: |",
+		"    set -euo pipefail",
+		"    PUSHED=true",
+		"    if [ -n \"${UPSTREAM_SHA:-}\" ]; then",
+		"      UPSTREAM_COMMIT=\"" + fmt.Sprintf(commitMessageFormat, "${UPSTREAM_NAME#"+tenderNamePrefix+"}") + "\"",
+		"      if [ -z \"$(git log --format=%H -F --grep=\"$UPSTREAM_COMMIT\" \"$UPSTREAM_SHA..HEAD\" || true)\" ]; then",
+		"        PUSHED=false",
+		"        echo \"$UPSTREAM_NAME pushed no commit; nothing to do\"",
+		"        printf '### Skipped\\n\\n%s pushed no commit, so this run did nothing.\\n' \"$UPSTREAM_NAME\" >> \"$GITHUB_STEP_SUMMARY\"",
+		"      fi",
+		"    fi",
+		"    echo \"pushed=$PUSHED\" >> \"$GITHUB_OUTPUT\"",
+	}}}
+}
+
+// gateOnUpstreamPush skips step when chainCheckStep found no upstream commit.
+// Steps with a condition of their own keep it.
+func gateOnUpstreamPush(t Tender, step workflowBlock) workflowBlock {
+	if !followsPushes(t) || (len(step.Lines) > 1 && strings.HasPrefix(step.Lines[1], "  if:")) {
+		return step
+	}
+	lines := append([]string{step.Lines[0], "  if: " + upstreamPushedCondition}, step.Lines[1:]...)
+	return workflowBlock{Key: step.Key, Lines: lines}
+}
+
+// validatePushedChain rejects following an upstream's pushes when the
+// upstream delivers through pull requests: its runs only push a per-run
+// branch, so the downstream would never run.
+func validatePushedChain(upstream, downstream Tender) error {
+	if normalizeAfterWhen(downstream.AfterWhen) != AfterPushed || normalizeDelivery(upstream.Delivery) != DeliveryPR {
+		return nil
+	}
+	return fmt.Errorf("tender %q runs after %q pushes, but %q delivers through pull requests and never pushes to its branch; use after-when success", strings.TrimSpace(downstream.Name), strings.TrimSpace(upstream.Name), strings.TrimSpace(upstream.Name))
+}
+
+// chainTriggerSummaries describe a tender's upstream for listings.
+func chainTriggerSummaries(t Tender) []string {
+	after := strings.TrimSpace(t.After)
+	if after == "" {
+		return nil
+	}
+	if when := normalizeAfterWhen(t.AfterWhen); when != AfterCompleted {
+		return []string{"after(" + after + ", " + when + ")"}
+	}
+	return []string{"after(" + after + ")"}
+}
+
+// parseChainTrigger reads the upstream tender from on.workflow_run, which must
+// name exactly one tender workflow.
+func parseChainTrigger(value *yaml.Node) (string, error) {
+	var trigger workflowRunTrigger
+	if value == nil || value.Decode(&trigger) != nil || len(trigger.Workflows) != 1 {
+		return "", fmt.Errorf("on.workflow_run must list one tender workflow")
+	}
+	name := strings.TrimSpace(trigger.Workflows[0])
+	if !strings.HasPrefix(name, tenderNamePrefix) || strings.TrimPrefix(name, tenderNamePrefix) == "" {
+		return "", fmt.Errorf("on.workflow_run: %q is not a tender workflow", name)
+	}
+	return strings.TrimSpace(strings.TrimPrefix(name, tenderNamePrefix)), nil
+}
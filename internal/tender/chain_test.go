@@ -0,0 +1,170 @@
+package tender
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// chain.go tests
+
+func TestChainedTenders(t *testing.T) {
+	base := Tender{Name: "implement", Agent: "Build", After: "write-tests", AfterWhen: AfterPushed}
+
+	t.Run("renders the workflow_run trigger and its guards", func(t *testing.T) {
+		content := RenderWorkflow(base)
+		if !containsAll(content,
+			"  workflow_run:\n    workflows: [\"tender/write-tests\"]\n    types: [completed]\n",
+			"    if: ${{ github.event_name != 'workflow_run' || github.event.workflow_run.conclusion == 'success' }}\n",
+			"      TENDER_AFTER_WHEN: \"pushed\"\n",
+			"UPSTREAM_SHA: ${{ github.event.workflow_run.head_sha || '' }}",
+			`UPSTREAM_COMMIT="tender(${UPSTREAM_NAME#tender/}): autonomous update"`,
+		) {
+			t.Fatalf("rendered workflow is missing chain content:\n%s", content)
+		}
+		check := strings.Index(content, "- name: Check upstream push")
+		if check < strings.Index(content, "- name: Prepare main") || check > strings.Index(content, "- name: Run OpenCode") {
+			t.Fatalf("expected the upstream check before Run OpenCode:\n%s", content)
+		}
+		for _, step := range []string{"Run OpenCode", "Commit and push main"} {
+			if !strings.Contains(content, "      - name: "+step+"\n        if: steps.upstream.outputs.pushed == 'true'\n") {
+				t.Fatalf("expected %s to be skipped when the upstream pushed nothing:\n%s", step, content)
+			}
+		}
+		if !containsAll(content, `echo "pushed=$PUSHED" >> "$GITHUB_OUTPUT"`, "### Skipped") || strings.Contains(content, "exit 0\n        fi\n      fi\n      DISPATCH_PROMPT") {
+			t.Fatalf("expected the check to set an output instead of ending Run OpenCode:\n%s", content)
+		}
+		gated := base
+		gated.Verify, gated.VerifyFailure = "make check-fast", VerifyBranch
+		if !strings.Contains(RenderWorkflow(gated), "      - name: Verify changes\n        if: steps.upstream.outputs.pushed == 'true'\n") ||
+			!strings.Contains(RenderWorkflow(gated), "      - name: Push unverified changes\n        if: failure() && steps.verify.outcome == 'failure'\n") {
+			t.Fatalf("expected verify to be gated and the review branch step to keep its condition:\n%s", RenderWorkflow(gated))
+		}
+		completed := RenderWorkflow(Tender{Name: "implement", Agent: "Build", After: "write-tests"})
+		if strings.Contains(completed, "    if:") || strings.Contains(completed, "TENDER_AFTER_WHEN") || strings.Contains(completed, "UPSTREAM_SHA") {
+			t.Fatalf("expected no guards for completed upstream runs:\n%s", completed)
+		}
+	})
+
+	t.Run("round-trips through the workflow file", func(t *testing.T) {
+		for _, when := range []string{"", AfterSuccess, AfterPushed} {
+			tender := base
+			tender.AfterWhen = when
+			parsed, err := parseTenderWorkflow(RenderWorkflow(tender))
+			if err != nil {
+				t.Fatalf("parseTenderWorkflow returned error: %v", err)
+			}
+			if parsed.After != "write-tests" || parsed.AfterWhen != when || parsed.Manual {
+				t.Fatalf("round trip lost the chain: %+v", parsed)
+			}
+		}
+		foreign := strings.Replace(RenderWorkflow(base), `"tender/write-tests"`, `"CI"`, 1)
+		if _, err := parseTenderWorkflow(foreign); err == nil || !strings.Contains(err.Error(), "not a tender workflow") {
+			t.Fatalf("expected workflow_run error, got %v", err)
+		}
+	})
+
+	t.Run("patches the upstream check in and out", func(t *testing.T) {
+		success := base
+		success.AfterWhen = AfterSuccess
+		got, err := PatchWorkflow(RenderWorkflow(base), success)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if got != RenderWorkflow(success) {
+			t.Fatalf("patched workflow differs from a fresh render:\n%s", got)
+		}
+		if back, err := PatchWorkflow(got, base); err != nil || back != RenderWorkflow(base) {
+			t.Fatalf("expected the check to be patched back in (%v):\n%s", err, back)
+		}
+	})
+
+	t.Run("patches the trigger away when unchained", func(t *testing.T) {
+		got, err := PatchWorkflow(RenderWorkflow(base), Tender{Name: "implement", Agent: "Build", Manual: true})
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if strings.Contains(got, "workflow_run") || strings.Contains(got, "    if:") || strings.Contains(got, "TENDER_AFTER_WHEN") {
+			t.Fatalf("expected the chain to be removed:\n%s", got)
+		}
+	})
+
+	t.Run("validates the upstream against the loaded tenders", func(t *testing.T) {
+		writeTests := Tender{Name: "write-tests", Agent: "Build", Manual: true}
+		if err := ValidateTender(base, writeTests); err != nil {
+			t.Fatalf("ValidateTender returned error: %v", err)
+		}
+		if err := ValidateTender(base, Tender{Name: "other", Agent: "Build", Manual: true}); err == nil || !strings.Contains(err.Error(), "does not exist") {
+			t.Fatalf("expected missing upstream error, got %v", err)
+		}
+		self := base
+		self.After = "Implement"
+		if err := ValidateTender(self); err == nil || !strings.Contains(err.Error(), "cannot run after itself") {
+			t.Fatalf("expected self chain error, got %v", err)
+		}
+		prUpstream := writeTests
+		prUpstream.Delivery = DeliveryPR
+		if err := ValidateTender(base, prUpstream); err == nil || !strings.Contains(err.Error(), "delivers through pull requests") {
+			t.Fatalf("expected a pull request upstream error, got %v", err)
+		}
+		success := base
+		success.AfterWhen = AfterSuccess
+		if err := ValidateTender(success, prUpstream); err != nil {
+			t.Fatalf("ValidateTender returned error: %v", err)
+		}
+		if err := ValidateTender(prUpstream, base); err == nil || !strings.Contains(err.Error(), "delivers through pull requests") {
+			t.Fatalf("expected switching the upstream to pull requests to be rejected, got %v", err)
+		}
+		bad := base
+		bad.AfterWhen = "merged"
+		if err := ValidateTender(bad); err == nil || !strings.Contains(err.Error(), "after-when must be") {
+			t.Fatalf("expected after-when error, got %v", err)
+		}
+	})
+
+	t.Run("detects cycles across tenders", func(t *testing.T) {
+		tenders := []Tender{
+			{Name: "write-tests", Agent: "Build", After: "review"},
+			{Name: "review", Agent: "Build", After: "implement"},
+			{Name: "implement", Agent: "Build", Manual: true},
+		}
+		err := ValidateTender(base, tenders...)
+		if err == nil || !strings.Contains(err.Error(), "tender chain implement -> write-tests -> review -> implement is a cycle") {
+			t.Fatalf("expected cycle error, got %v", err)
+		}
+	})
+
+	t.Run("summarises the upstream", func(t *testing.T) {
+		if got := tenderTriggerSummary(base); got != "after(write-tests, pushed)" {
+			t.Fatalf("tenderTriggerSummary = %q", got)
+		}
+	})
+
+	t.Run("keeps chains intact on save, rename and remove", func(t *testing.T) {
+		root := t.TempDir()
+		if _, err := SaveNewTender(root, base); err == nil || !strings.Contains(err.Error(), "does not exist") {
+			t.Fatalf("expected missing upstream error, got %v", err)
+		}
+		if _, err := SaveNewTender(root, Tender{Name: "write-tests", Agent: "Build", Manual: true}); err != nil {
+			t.Fatalf("SaveNewTender returned error: %v", err)
+		}
+		if _, err := SaveNewTender(root, base); err != nil {
+			t.Fatalf("SaveNewTender returned error: %v", err)
+		}
+		loop := Tender{Name: "write-tests", Agent: "Build", Manual: true, After: "implement"}
+		if err := UpdateTender(root, "write-tests", loop); err == nil || !strings.Contains(err.Error(), "is a cycle") {
+			t.Fatalf("expected cycle error, got %v", err)
+		}
+		renamed := Tender{Name: "tests", Agent: "Build", Manual: true}
+		if err := UpdateTender(root, "write-tests", renamed); err == nil || !strings.Contains(err.Error(), "implement run(s) after it") {
+			t.Fatalf("expected rename error, got %v", err)
+		}
+		if err := RemoveTender(root, "write-tests"); err == nil || !strings.Contains(err.Error(), "implement run(s) after it") {
+			t.Fatalf("expected remove error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(root, WorkflowDir, "write-tests.yml")); err != nil {
+			t.Fatalf("expected upstream workflow to remain: %v", err)
+		}
+	})
+}
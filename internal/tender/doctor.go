@@ -0,0 +1,90 @@
+package tender
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// offsetChangeWindow is how far ahead doctor looks for upcoming UTC offset
+// changes of timezone tenders.
+const offsetChangeWindow = 14 * 24 * time.Hour
+
+// PrintDoctor checks every tender workflow for problems: files that no longer
+// parse, tenders that fail validation (including chains whose upstream is
+// missing or loops back) and timezone schedules whose UTC cron no longer
+// matches their local time after a daylight-saving change. It returns the
+// number of warnings printed; upcoming offset changes are printed as notes and
+// not counted.
+func PrintDoctor(root string, now time.Time, stdout io.Writer) (int, error) {
+	tenders, malformed, err := LoadTenderWorkflows(root)
+	if err != nil {
+		return 0, err
+	}
+	var warnings, notes []string
+	for _, m := range malformed {
+		warnings = append(warnings, fmt.Sprintf("%s: cannot be loaded: %v", m.WorkflowFile, m.Err))
+	}
+	for _, t := range tenders {
+		if err := ValidateTender(t, tenders...); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", t.Name, err))
+			continue
+		}
+		if strings.TrimSpace(t.Timezone) == "" {
+			continue
+		}
+		warning, note := checkTimezoneSchedule(t, now)
+		if warning != "" {
+			warnings = append(warnings, t.Name+": "+warning)
+		}
+		if note != "" {
+			notes = append(notes, t.Name+": "+note)
+		}
+	}
+
+	for _, w := range warnings {
+		_, _ = fmt.Fprintf(stdout, "warning: %s\n", w)
+	}
+	for _, n := range notes {
+		_, _ = fmt.Fprintf(stdout, "note: %s\n", n)
+	}
+	if len(warnings) == 0 && len(notes) == 0 {
+		_, _ = fmt.Fprintf(stdout, "No problems found in %d tender(s).\n", len(tenders))
+	}
+	return len(warnings), nil
+}
+
+// checkTimezoneSchedule reports a timezone tender whose UTC cron has drifted
+// from its local time, or whose zone changes offset soon.
+func checkTimezoneSchedule(t Tender, now time.Time) (warning, note string) {
+	loc, err := LoadTimezone(t.Timezone)
+	if err != nil {
+		return err.Error(), ""
+	}
+	resync := fmt.Sprintf("`tender update %s --timezone %s`", t.Name, loc)
+	local := strings.Join(normalizeCrons(t.LocalCrons), localCronSeparator)
+	want, err := scheduleDrift(t, now)
+	if err != nil {
+		return fmt.Sprintf("cannot convert %q in %s to UTC: %v", local, loc, err), ""
+	}
+	if want != nil {
+		return fmt.Sprintf("a daylight-saving change moved the schedule: next run %s, expected %s (%q in %s); run %s to re-sync",
+			nextLocalRun(t.Crons, loc, now), nextLocalRun(want, loc, now), local, loc, resync), ""
+	}
+	if change := nextOffsetChange(loc, now, offsetChangeWindow); !change.IsZero() {
+		return "", fmt.Sprintf("%s changes its UTC offset on %s; run %s afterwards to keep %q", loc, change.Format("Mon 2006-01-02"), resync, local)
+	}
+	return "", ""
+}
+
+func nextLocalRun(crons []string, loc *time.Location, now time.Time) string {
+	next, err := nextCronRun(normalizeCrons(crons), now)
+	if err != nil {
+		return "unknown"
+	}
+	if next.IsZero() {
+		return "never"
+	}
+	return next.In(loc).Format("Mon 2006-01-02 15:04 MST")
+}
@@ -0,0 +1,86 @@
+package tender
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// doctor.go tests
+
+func TestPrintDoctor(t *testing.T) {
+	if _, err := LoadTimezone("Europe/Berlin"); err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	// Europe/Berlin leaves summer time on 2026-10-25.
+	summer := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	beforeChange := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	afterChange := time.Date(2026, 11, 2, 12, 0, 0, 0, time.UTC)
+
+	seed := func(t *testing.T) string {
+		t.Helper()
+		root := t.TempDir()
+		tender, err := SetSchedule(Tender{Name: "nightly", Agent: "Build", Manual: true}, []string{"0 9 * * 1-5"}, "Europe/Berlin", summer)
+		if err != nil {
+			t.Fatalf("SetSchedule returned error: %v", err)
+		}
+		if _, err := SaveNewTender(root, tender); err != nil {
+			t.Fatalf("SaveNewTender returned error: %v", err)
+		}
+		return root
+	}
+
+	t.Run("reports a healthy repository", func(t *testing.T) {
+		var out bytes.Buffer
+		warnings, err := PrintDoctor(seed(t), summer, &out)
+		if err != nil || warnings != 0 {
+			t.Fatalf("PrintDoctor = %d, %v", warnings, err)
+		}
+		if got := out.String(); got != "No problems found in 1 tender(s).\n" {
+			t.Fatalf("unexpected output %q", got)
+		}
+	})
+
+	t.Run("notes an offset change coming up", func(t *testing.T) {
+		var out bytes.Buffer
+		warnings, err := PrintDoctor(seed(t), beforeChange, &out)
+		if err != nil || warnings != 0 {
+			t.Fatalf("PrintDoctor = %d, %v", warnings, err)
+		}
+		want := "note: nightly: Europe/Berlin changes its UTC offset on Sun 2026-10-25; run `tender update nightly --timezone Europe/Berlin` afterwards"
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	})
+
+	t.Run("warns when daylight-saving time moved the schedule", func(t *testing.T) {
+		var out bytes.Buffer
+		warnings, err := PrintDoctor(seed(t), afterChange, &out)
+		if err != nil || warnings != 1 {
+			t.Fatalf("PrintDoctor = %d, %v\n%s", warnings, err, out.String())
+		}
+		want := "warning: nightly: a daylight-saving change moved the schedule: next run Tue 2026-11-03 08:00 CET, expected Tue 2026-11-03 09:00 CET"
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output:\n%s", want, out.String())
+		}
+	})
+
+	t.Run("warns about workflows that do not load", func(t *testing.T) {
+		root := seed(t)
+		broken := "name: \"tender/broken\"\non: [workflow_dispatch]\njobs:\n  tender:\n    env:\n      TENDER_AGENT: \"Build\"\n"
+		if err := os.WriteFile(filepath.Join(root, WorkflowDir, "broken.yml"), []byte(broken), 0o644); err != nil {
+			t.Fatalf("write broken workflow: %v", err)
+		}
+		var out bytes.Buffer
+		warnings, err := PrintDoctor(root, summer, &out)
+		if err != nil || warnings != 1 {
+			t.Fatalf("PrintDoctor = %d, %v\n%s", warnings, err, out.String())
+		}
+		if !strings.Contains(out.String(), "warning: broken.yml: cannot be loaded:") {
+			t.Fatalf("expected malformed workflow warning:\n%s", out.String())
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package tender
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Event triggers start a tender from repository activity: an issue labelled
+// tender:<name>, a `/tender <name>` comment on a pull request, or a pull
+// request opened against the tender's branch.
+const (
+	eventLabelPrefix   = "tender:"
+	eventCommandPrefix = "/tender "
+)
+
+// eventPlaceholders are the prompt placeholders filled in from the triggering
+// event. Runs started otherwise expand them to empty strings.
+var eventPlaceholders = []promptPlaceholder{
+	{Name: "event_title", Help: "title of the triggering issue or pull request", Shell: `"$TENDER_EVENT_TITLE"`},
+	{Name: "event_body", Help: "the triggering comment, or the issue or pull request description", Shell: `"$TENDER_EVENT_BODY"`},
+	{Name: "pr_diff", Help: "diff of the triggering pull request", Shell: `"$PR_DIFF"`},
+}
+
+// eventTenderNameRE limits the names of event-triggered tenders to ones that
+// are easy to type in a label or comment and safe in the job condition.
+var eventTenderNameRE = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+func hasEventTriggers(t Tender) bool {
+	return t.IssueLabel || t.PRComment || t.PullRequest
+}
+
+// hasPullRequestTriggers reports whether runs may need to read a pull request.
+func hasPullRequestTriggers(t Tender) bool {
+	return t.PRComment || t.PullRequest
+}
+
+func validateEventTriggers(t Tender) error {
+	if !hasEventTriggers(t) {
+		return nil
+	}
+	if name := strings.TrimSpace(t.Name); !eventTenderNameRE.MatchString(name) {
+		return fmt.Errorf("name %q must only contain letters, digits, '.', '_' and '-' to use issue or pull request triggers", name)
+	}
+	return nil
+}
+
+// eventLabel is the issue label that starts a tender.
+func eventLabel(t Tender) string {
+	return eventLabelPrefix + strings.TrimSpace(t.Name)
+}
+
+// eventCommand is the pull request comment that starts a tender.
+func eventCommand(t Tender) string {
+	return eventCommandPrefix + strings.TrimSpace(t.Name)
+}
+
+// renderEventTriggers returns the `on:` entries of a tender's event triggers.
+func renderEventTriggers(t Tender) []workflowBlock {
+	var blocks []workflowBlock
+	if t.IssueLabel {
+		blocks = append(blocks, workflowBlock{Key: "issues", Lines: []string{
+			"issues:",
+			"  types: [labeled]",
+		}})
+	}
+	if t.PRComment {
+		blocks = append(blocks, workflowBlock{Key: "issue_comment", Lines: []string{
+			"issue_comment:",
+			"  types: [created]",
+		}})
+	}
+	if t.PullRequest {
+		blocks = append(blocks, workflowBlock{Key: "pull_request", Lines: []string{
+			"pull_request:",
+			"  types: [opened]",
+			"  branches:",
+			"    - " + normalizeBranch(t.Branch),
+		}})
+	}
+	return blocks
+}
+
+// eventGuards are the job conditions that narrow event triggers down to this
+// tender: issues with its label, and pull request comments that start with its
+// command from someone with write access to the repository.
+func eventGuards(t Tender) []string {
+	var guards []string
+	if t.IssueLabel {
+		guards = append(guards, "github.event_name != 'issues' || github.event.label.name == '"+eventLabel(t)+"'")
+	}
+	if t.PRComment {
+		body := "github.event.comment.body"
+		author := "github.event.comment.author_association"
+		guards = append(guards, "github.event_name != 'issue_comment' || (github.event.issue.pull_request && "+
+			"("+author+" == 'OWNER' || "+author+" == 'MEMBER' || "+author+" == 'COLLABORATOR') && "+
+			"("+body+" == '"+eventCommand(t)+"' || startsWith("+body+", '"+eventCommand(t)+" ')))")
+	}
+	return guards
+}
+
+// eventEnvLines pass the event payload to the Run OpenCode step through env,
+// so titles and bodies never become part of the script.
+func eventEnvLines(t Tender) []string {
+	if !hasEventTriggers(t) {
+		return nil
+	}
+	return []string{
+		"    GH_TOKEN: ${{ github.token }}",
+		"    TENDER_EVENT_TITLE: ${{ github.event.issue.title || github.event.pull_request.title || '' }}",
+		"    TENDER_EVENT_BODY: ${{ github.event.comment.body || github.event.issue.body || github.event.pull_request.body || '' }}",
+		"    TENDER_PR_NUMBER: ${{ github.event.pull_request.number || (github.event.issue.pull_request && github.event.issue.number) || '' }}",
+	}
+}
+
+// eventTemplateLines fetch the pull request diff for {{pr_diff}}.
+func eventTemplateLines() []string {
+	return []string{
+		"    PR_DIFF=\"\"",
+		"    if [ -n \"$TENDER_PR_NUMBER\" ]; then",
+		"      PR_DIFF=\"$(gh pr diff \"$TENDER_PR_NUMBER\" || true)\"",
+		"    fi",
+	}
+}
+
+// eventTriggerSummaries describe a tender's event triggers for listings.
+func eventTriggerSummaries(t Tender) []string {
+	var parts []string
+	if t.IssueLabel {
+		parts = append(parts, "on-label("+eventLabel(t)+")")
+	}
+	if t.PRComment {
+		parts = append(parts, "on-comment("+eventCommand(t)+")")
+	}
+	if t.PullRequest {
+		parts = append(parts, "on-pr("+normalizeBranch(t.Branch)+")")
+	}
+	return parts
+}
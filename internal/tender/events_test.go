@@ -0,0 +1,112 @@
+package tender
+
+import (
+	"strings"
+	"testing"
+)
+
+// events.go tests
+
+func TestEventTriggers(t *testing.T) {
+	base := Tender{Name: "triage", Agent: "Build", IssueLabel: true, PRComment: true, PullRequest: true, Prompt: "Fix {{event_title}}: {{event_body}} {{pr_diff}}"}
+
+	t.Run("renders the triggers, job condition and payload env", func(t *testing.T) {
+		content := RenderWorkflow(base)
+		if !containsAll(content,
+			"  issues:\n    types: [labeled]\n",
+			"  issue_comment:\n    types: [created]\n",
+			"  pull_request:\n    types: [opened]\n    branches:\n      - main\n",
+			"  pull-requests: read\n",
+			"github.event.label.name == 'tender:triage'",
+			"startsWith(github.event.comment.body, '/tender triage ')",
+			"github.event.comment.author_association == 'COLLABORATOR'",
+			"TENDER_EVENT_BODY: ${{ github.event.comment.body || github.event.issue.body || github.event.pull_request.body || '' }}",
+			`PR_DIFF="$(gh pr diff "$TENDER_PR_NUMBER" || true)"`,
+			`RUN_PROMPT="${RUN_PROMPT//\{\{pr_diff\}\}/"$PR_DIFF"}"`,
+		) {
+			t.Fatalf("rendered workflow is missing event trigger content:\n%s", content)
+		}
+		if strings.Contains(content, "workflow_dispatch:") {
+			t.Fatalf("expected no manual trigger:\n%s", content)
+		}
+	})
+
+	t.Run("round-trips through the workflow file", func(t *testing.T) {
+		parsed, err := parseTenderWorkflow(RenderWorkflow(base))
+		if err != nil {
+			t.Fatalf("parseTenderWorkflow returned error: %v", err)
+		}
+		if !parsed.IssueLabel || !parsed.PRComment || !parsed.PullRequest || parsed.Manual || parsed.Push {
+			t.Fatalf("round trip lost the event triggers: %+v", parsed)
+		}
+	})
+
+	t.Run("combines the job condition with the push loop guard", func(t *testing.T) {
+		pushOnly := Tender{Name: "triage", Agent: "Build", Push: true}
+		if got := jobCondition(pushOnly); got != pushLoopGuard {
+			t.Fatalf("jobCondition(push) = %q, want %q", got, pushLoopGuard)
+		}
+		labelled := Tender{Name: "triage", Agent: "Build", Push: true, IssueLabel: true}
+		want := "${{ (" + pushLoopGuardExpr + ") && (github.event_name != 'issues' || github.event.label.name == 'tender:triage') }}"
+		if got := jobCondition(labelled); got != want {
+			t.Fatalf("jobCondition = %q, want %q", got, want)
+		}
+		if got := jobCondition(Tender{Name: "triage", Manual: true, PullRequest: true}); got != "" {
+			t.Fatalf("expected no condition for pull request triggers, got %q", got)
+		}
+	})
+
+	t.Run("patches the job condition as triggers change", func(t *testing.T) {
+		pushOnly := Tender{Name: "triage", Agent: "Build", Push: true}
+		labelled := pushOnly
+		labelled.IssueLabel = true
+
+		got, err := PatchWorkflow(RenderWorkflow(pushOnly), labelled)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if !strings.Contains(got, "    if: "+jobCondition(labelled)+"\n") || !strings.Contains(got, "  issues:\n") {
+			t.Fatalf("expected the label trigger and its condition:\n%s", got)
+		}
+		back, err := PatchWorkflow(got, Tender{Name: "triage", Agent: "Build", Manual: true})
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if strings.Contains(back, "    if:") || strings.Contains(back, "issues:") {
+			t.Fatalf("expected the condition and trigger to be removed:\n%s", back)
+		}
+
+		custom := strings.Replace(RenderWorkflow(pushOnly), "    if: "+pushLoopGuard+"\n", "    if: github.repository_owner == 'acme'\n", 1)
+		kept, err := PatchWorkflow(custom, labelled)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if !strings.Contains(kept, "    if: github.repository_owner == 'acme'\n") {
+			t.Fatalf("expected a hand-written condition to survive:\n%s", kept)
+		}
+	})
+
+	t.Run("validates names and event placeholders", func(t *testing.T) {
+		spaced := base
+		spaced.Name = "nightly triage"
+		if err := ValidateTender(spaced); err == nil || !strings.Contains(err.Error(), "issue or pull request triggers") {
+			t.Fatalf("expected name error, got %v", err)
+		}
+		manual := Tender{Name: "triage", Agent: "Build", Manual: true, Prompt: "Fix {{event_body}}"}
+		if err := ValidateTender(manual); err == nil || !strings.Contains(err.Error(), "unknown placeholder {{event_body}}") {
+			t.Fatalf("expected event placeholders to need an event trigger, got %v", err)
+		}
+		if err := ValidateTender(base); err != nil {
+			t.Fatalf("ValidateTender returned error: %v", err)
+		}
+	})
+
+	t.Run("summarises the triggers", func(t *testing.T) {
+		want := "on-demand + on-label(tender:triage) + on-comment(/tender triage) + on-pr(develop)"
+		tender := base
+		tender.Manual, tender.Branch = true, "develop"
+		if got := tenderTriggerSummary(tender); got != want {
+			t.Fatalf("tenderTriggerSummary = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package tender
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DetectDefaultBranch returns the repository's default branch as seen by local
+// git: the branch origin/HEAD points at, else a local main or master branch,
+// else DefaultBranch.
+func DetectDefaultBranch(root string) string {
+	if ref, err := runGit(root, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD"); err == nil {
+		if branch := strings.TrimPrefix(strings.TrimSpace(ref), "origin/"); branch != "" && validateBranch(branch) == nil {
+			return branch
+		}
+	}
+	for _, candidate := range []string{"main", "master"} {
+		if _, err := runGit(root, "rev-parse", "--verify", "--quiet", "refs/heads/"+candidate); err == nil {
+			return candidate
+		}
+	}
+	return DefaultBranch
+}
+
+func runGit(root string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = root
+	cmd.Stderr = io.Discard
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
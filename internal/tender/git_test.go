@@ -0,0 +1,51 @@
+package tender
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// git.go tests
+
+func TestDetectDefaultBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+
+	gitIn := func(t *testing.T, dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	newRepo := func(t *testing.T, branch string) string {
+		t.Helper()
+		dir := t.TempDir()
+		gitIn(t, dir, "init", "-q", "-b", branch)
+		gitIn(t, dir, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "--allow-empty", "-m", "init")
+		return dir
+	}
+
+	t.Run("falls back to main outside a repository", func(t *testing.T) {
+		if got := DetectDefaultBranch(t.TempDir()); got != DefaultBranch {
+			t.Fatalf("DetectDefaultBranch() = %q, want %q", got, DefaultBranch)
+		}
+	})
+
+	t.Run("uses a local master branch", func(t *testing.T) {
+		if got := DetectDefaultBranch(newRepo(t, "master")); got != "master" {
+			t.Fatalf("DetectDefaultBranch() = %q, want %q", got, "master")
+		}
+	})
+
+	t.Run("prefers the branch origin/HEAD points at", func(t *testing.T) {
+		dir := newRepo(t, "main")
+		gitIn(t, dir, "update-ref", "refs/remotes/origin/develop", "HEAD")
+		gitIn(t, dir, "symbolic-ref", "refs/remotes/origin/HEAD", "refs/remotes/origin/develop")
+		if got := DetectDefaultBranch(dir); got != "develop" {
+			t.Fatalf("DetectDefaultBranch() = %q, want %q", got, "develop")
+		}
+	})
+}
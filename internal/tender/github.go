@@ -0,0 +1,52 @@
+package tender
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"tender/internal/github"
+)
+
+// githubRemoteRE reads the host and owner/repo of an SSH or HTTPS remote on
+// any host, so GitHub Enterprise Server remotes are recognised too.
+var githubRemoteRE = regexp.MustCompile(`^(?:[a-z][a-z0-9+.-]*://)?(?:[^@/]+@)?([^:/]+)(?::[0-9]+)?[:/]([^/]+)/([^/]+?)(?:\.git)?/?$`)
+
+// newGitHubClient returns a REST client for the repository at root. The API
+// base URL follows GITHUB_API_URL (set on Actions runners and GHES) so tests
+// can point it at a local server.
+func newGitHubClient(root string) (*github.Client, error) {
+	repo, err := detectGitHubRepo(root)
+	if err != nil {
+		return nil, err
+	}
+	client := github.New(repo, "")
+	client.Token = github.Token(client.BaseURL)
+	return client, nil
+}
+
+// detectGitHubRepo returns owner/repo from GITHUB_REPOSITORY or the origin
+// remote. The remote has to be on github.com or on the host of the configured
+// API (GH_HOST or GITHUB_API_URL), which the client then talks to.
+func detectGitHubRepo(root string) (string, error) {
+	if repo := strings.TrimSpace(os.Getenv("GITHUB_REPOSITORY")); repo != "" {
+		return repo, nil
+	}
+	remote, err := runGit(root, "remote", "get-url", "origin")
+	if err != nil {
+		return "", fmt.Errorf("cannot determine GitHub repository: no origin remote (set GITHUB_REPOSITORY=owner/repo)")
+	}
+	remote = strings.TrimSpace(remote)
+	m := githubRemoteRE.FindStringSubmatch(remote)
+	if m == nil {
+		return "", fmt.Errorf("origin remote %q is not a GitHub repository", remote)
+	}
+	if host, apiHost := strings.ToLower(m[1]), github.HostFromEnv(); host != apiHost {
+		if apiHost == "github.com" {
+			return "", fmt.Errorf("origin remote %q is not on github.com; for GitHub Enterprise Server set GH_HOST=%s", remote, host)
+		}
+		return "", fmt.Errorf("origin remote %q is not on the configured GitHub host %s (GH_HOST/GITHUB_API_URL)", remote, apiHost)
+	}
+	return m[2] + "/" + m[3], nil
+}
@@ -0,0 +1,224 @@
+package tender
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Guardrails limit what a tender run may deliver: the paths it may change
+// (AllowPaths), the ones it must not touch (DenyPaths) and how many files and
+// lines it may change. Globs use git's pathspec glob syntax, where `*` stays
+// within a directory and `**` crosses them.
+
+// pathGlobSeparator joins a tender's globs in TENDER_ALLOW_PATHS and
+// TENDER_DENY_PATHS.
+const pathGlobSeparator = "; "
+
+func hasGuardrails(t Tender) bool {
+	return len(normalizeGlobs(t.AllowPaths)) > 0 || len(normalizeGlobs(t.DenyPaths)) > 0 || t.MaxFiles > 0 || t.MaxLines > 0
+}
+
+// normalizeGlobs trims path globs and drops blank ones.
+func normalizeGlobs(globs []string) []string {
+	var out []string
+	for _, g := range globs {
+		if g = strings.TrimSpace(g); g != "" {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+func parsePathGlobs(raw string) []string {
+	return normalizeGlobs(strings.Split(raw, strings.TrimSpace(pathGlobSeparator)))
+}
+
+func validateGuardrails(t Tender) error {
+	for _, g := range append(normalizeGlobs(t.AllowPaths), normalizeGlobs(t.DenyPaths)...) {
+		if err := validatePathGlob(g); err != nil {
+			return err
+		}
+	}
+	if t.MaxFiles < 0 {
+		return fmt.Errorf("max-files must not be negative")
+	}
+	if t.MaxLines < 0 {
+		return fmt.Errorf("max-lines must not be negative")
+	}
+	return nil
+}
+
+func validatePathGlob(glob string) error {
+	switch {
+	case strings.ContainsAny(glob, "\r\n;"):
+		return fmt.Errorf("path glob %q cannot contain ';' or newlines", glob)
+	case strings.HasPrefix(glob, "/"), strings.HasPrefix(glob, ":"):
+		return fmt.Errorf("path glob %q must be relative to the repository root", glob)
+	}
+	return nil
+}
+
+func guardrailEnv(t Tender) []workflowScalar {
+	var env []workflowScalar
+	if allow := normalizeGlobs(t.AllowPaths); len(allow) > 0 {
+		env = append(env, quotedScalar("TENDER_ALLOW_PATHS", strings.Join(allow, pathGlobSeparator)))
+	}
+	if deny := normalizeGlobs(t.DenyPaths); len(deny) > 0 {
+		env = append(env, quotedScalar("TENDER_DENY_PATHS", strings.Join(deny, pathGlobSeparator)))
+	}
+	if t.MaxFiles > 0 {
+		env = append(env, quotedScalar("TENDER_MAX_FILES", strconv.Itoa(t.MaxFiles)))
+	}
+	if t.MaxLines > 0 {
+		env = append(env, quotedScalar("TENDER_MAX_LINES", strconv.Itoa(t.MaxLines)))
+	}
+	return env
+}
+
+// parseGuardrailEnv reads the guardrails back from the job env.
+func parseGuardrailEnv(t *Tender, env map[string]string) error {
+	t.AllowPaths = parsePathGlobs(env["TENDER_ALLOW_PATHS"])
+	t.DenyPaths = parsePathGlobs(env["TENDER_DENY_PATHS"])
+	limits := []struct {
+		key   string
+		value *int
+	}{{"TENDER_MAX_FILES", &t.MaxFiles}, {"TENDER_MAX_LINES", &t.MaxLines}}
+	for _, limit := range limits {
+		raw := strings.TrimSpace(env[limit.key])
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("%s must be a positive integer, got %q", limit.key, raw)
+		}
+		*limit.value = n
+	}
+	return validateGuardrails(*t)
+}
+
+// guardrailStep stages the run's changes and fails the job, with a job
+// summary listing every violation, before anything is committed or pushed.
+// The changes are compared with the target branch, so commits the agent made
+// itself count too.
+func guardrailStep(t Tender) workflowBlock {
+	base := "origin/" + normalizeBranch(t.Branch)
+	return workflowBlock{Key: "Check guardrails", Lines: []string{
+		"- name: Check guardrails",
+		"  shell: bash",
+		"  run: |",
+		"    set -euo pipefail",
+		"    cd \"$GITHUB_WORKSPACE\"",
+		"    git add -A",
+		"    CHANGED=\"$(git diff --cached --name-only " + base + " --)\"",
+		"    if [ -z \"$CHANGED\" ]; then",
+		"      exit 0",
+		"    fi",
+		"    globs() { tr ';' '\\n' <<< \"$1\" | sed 's/^ *//; s/ *$//' | grep -v '^$' || true; }",
+		"    PROBLEMS=()",
+		"    mapfile -t DENY < <(globs \"${TENDER_DENY_PATHS:-}\")",
+		"    if [ \"${#DENY[@]}\" -gt 0 ]; then",
+		"      SPECS=()",
+		"      for GLOB in \"${DENY[@]}\"; do SPECS+=(\":(glob)$GLOB\"); done",
+		"      while IFS= read -r FILE; do PROBLEMS+=(\"$FILE matches a denied path\"); done < <(git diff --cached --name-only " + base + " -- \"${SPECS[@]}\")",
+		"    fi",
+		"    mapfile -t ALLOW < <(globs \"${TENDER_ALLOW_PATHS:-}\")",
+		"    if [ \"${#ALLOW[@]}\" -gt 0 ]; then",
+		"      SPECS=(.)",
+		"      for GLOB in \"${ALLOW[@]}\"; do SPECS+=(\":(glob,exclude)$GLOB\"); done",
+		"      while IFS= read -r FILE; do PROBLEMS+=(\"$FILE is outside the allowed paths\"); done < <(git diff --cached --name-only " + base + " -- \"${SPECS[@]}\")",
+		"    fi",
+		"    FILES=\"$(printf '%s\\n' \"$CHANGED\" | wc -l)\"",
+		"    LINES=\"$(git diff --cached --numstat " + base + " -- | awk '{ n += $1 + $2 } END { print n + 0 }')\"",
+		"    if [ -n \"${TENDER_MAX_FILES:-}\" ] && [ \"$FILES\" -gt \"$TENDER_MAX_FILES\" ]; then",
+		"      PROBLEMS+=(\"$FILES files changed; the limit is $TENDER_MAX_FILES\")",
+		"    fi",
+		"    if [ -n \"${TENDER_MAX_LINES:-}\" ] && [ \"$LINES\" -gt \"$TENDER_MAX_LINES\" ]; then",
+		"      PROBLEMS+=(\"$LINES lines changed; the limit is $TENDER_MAX_LINES\")",
+		"    fi",
+		"    if [ \"${#PROBLEMS[@]}\" -gt 0 ]; then",
+		"      {",
+		"        echo \"### Guardrails stopped this run\"",
+		"        echo",
+		"        printf -- '- %s\\n' \"${PROBLEMS[@]}\"",
+		"        echo",
+		"        echo \"Nothing was committed or pushed.\"",
+		"      } >> \"$GITHUB_STEP_SUMMARY\"",
+		"      printf '%s\\n' \"${PROBLEMS[@]}\" >&2",
+		"      exit 1",
+		"    fi",
+	}}
+}
+
+// checkGuardrailsLocally applies t's guardrails to the changes between base
+// and head in dir the way the workflow does, returning the violations.
+func checkGuardrailsLocally(t Tender, dir, base, head string) ([]string, error) {
+	var problems []string
+	changed := func(specs ...string) ([]string, error) {
+		out, err := runGit(dir, append([]string{"diff", "--name-only", base, head, "--"}, specs...)...)
+		if err != nil {
+			return nil, err
+		}
+		var files []string
+		for _, f := range strings.Split(out, "\n") {
+			if f = strings.TrimSpace(f); f != "" {
+				files = append(files, f)
+			}
+		}
+		return files, nil
+	}
+	if deny := normalizeGlobs(t.DenyPaths); len(deny) > 0 {
+		var specs []string
+		for _, g := range deny {
+			specs = append(specs, ":(glob)"+g)
+		}
+		files, err := changed(specs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			problems = append(problems, f+" matches a denied path")
+		}
+	}
+	if allow := normalizeGlobs(t.AllowPaths); len(allow) > 0 {
+		specs := []string{"."}
+		for _, g := range allow {
+			specs = append(specs, ":(glob,exclude)"+g)
+		}
+		files, err := changed(specs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			problems = append(problems, f+" is outside the allowed paths")
+		}
+	}
+	files, err := changed()
+	if err != nil {
+		return nil, err
+	}
+	if t.MaxFiles > 0 && len(files) > t.MaxFiles {
+		problems = append(problems, fmt.Sprintf("%d files changed; the limit is %d", len(files), t.MaxFiles))
+	}
+	if t.MaxLines > 0 {
+		numstat, err := runGit(dir, "diff", "--numstat", base, head)
+		if err != nil {
+			return nil, err
+		}
+		lines := 0
+		for _, row := range strings.Split(numstat, "\n") {
+			fields := strings.Fields(row)
+			if len(fields) < 2 {
+				continue
+			}
+			added, _ := strconv.Atoi(fields[0])
+			removed, _ := strconv.Atoi(fields[1])
+			lines += added + removed
+		}
+		if lines > t.MaxLines {
+			problems = append(problems, fmt.Sprintf("%d lines changed; the limit is %d", lines, t.MaxLines))
+		}
+	}
+	return problems, nil
+}
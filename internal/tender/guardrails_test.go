@@ -0,0 +1,98 @@
+package tender
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// guardrails.go tests
+
+func TestGuardrails(t *testing.T) {
+	base := Tender{
+		Name: "docs", Agent: "Build", Manual: true,
+		AllowPaths: []string{"docs/**", "*.md"}, DenyPaths: []string{".github/**"},
+		MaxFiles: 20, MaxLines: 400,
+	}
+
+	t.Run("renders the policy and the check before delivery", func(t *testing.T) {
+		content := RenderWorkflow(base)
+		if !containsAll(content,
+			"      TENDER_ALLOW_PATHS: \"docs/**; *.md\"\n",
+			"      TENDER_DENY_PATHS: \".github/**\"\n",
+			"      TENDER_MAX_FILES: \"20\"\n",
+			"      TENDER_MAX_LINES: \"400\"\n",
+			`SPECS+=(":(glob,exclude)$GLOB")`,
+			`echo "### Guardrails stopped this run"`,
+		) {
+			t.Fatalf("rendered workflow is missing guardrail content:\n%s", content)
+		}
+		check := strings.Index(content, "- name: Check guardrails")
+		if check < strings.Index(content, "- name: Run OpenCode") || check > strings.Index(content, "- name: Commit and push main") {
+			t.Fatalf("expected the check between Run OpenCode and delivery:\n%s", content)
+		}
+		if plain := RenderWorkflow(Tender{Name: "docs", Agent: "Build", Manual: true}); strings.Contains(plain, "guardrails") {
+			t.Fatalf("expected no check without guardrails:\n%s", plain)
+		}
+	})
+
+	t.Run("round-trips through the workflow file", func(t *testing.T) {
+		parsed, err := parseTenderWorkflow(RenderWorkflow(base))
+		if err != nil {
+			t.Fatalf("parseTenderWorkflow returned error: %v", err)
+		}
+		if !reflect.DeepEqual(parsed.AllowPaths, base.AllowPaths) || !reflect.DeepEqual(parsed.DenyPaths, base.DenyPaths) ||
+			parsed.MaxFiles != base.MaxFiles || parsed.MaxLines != base.MaxLines {
+			t.Fatalf("round trip lost the guardrails: %+v", parsed)
+		}
+		broken := strings.Replace(RenderWorkflow(base), `TENDER_MAX_FILES: "20"`, `TENDER_MAX_FILES: "lots"`, 1)
+		if _, err := parseTenderWorkflow(broken); err == nil || !strings.Contains(err.Error(), "TENDER_MAX_FILES must be a positive integer") {
+			t.Fatalf("expected limit error, got %v", err)
+		}
+	})
+
+	t.Run("validates globs and limits", func(t *testing.T) {
+		cases := map[string]Tender{
+			"relative to the repository root": {Name: "docs", Agent: "Build", Manual: true, DenyPaths: []string{"/etc/**"}},
+			"cannot contain ';'":              {Name: "docs", Agent: "Build", Manual: true, AllowPaths: []string{"a;b"}},
+			"max-lines must not be negative":  {Name: "docs", Agent: "Build", Manual: true, MaxLines: -1},
+		}
+		for want, tender := range cases {
+			if err := ValidateTender(tender); err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("ValidateTender(%+v) error = %v, want %q", tender, err, want)
+			}
+		}
+	})
+
+	t.Run("reports violations of a local change", func(t *testing.T) {
+		dir := newTestRepo(t)
+		commitAt(t, dir, "", "init", map[string]string{"README.md": "hi\n"})
+		testGit(t, dir, "tag", "base")
+		commitAt(t, dir, "", "change", map[string]string{
+			"docs/guide/intro.md":      "one\ntwo\n",
+			"README.md":                "hello\n",
+			".github/workflows/ci.yml": "on: push\n",
+			"main.go":                  "package main\n",
+		})
+
+		tender := base
+		tender.MaxFiles, tender.MaxLines = 3, 4
+		got, err := checkGuardrailsLocally(tender, dir, "base", "HEAD")
+		if err != nil {
+			t.Fatalf("checkGuardrailsLocally returned error: %v", err)
+		}
+		want := []string{
+			".github/workflows/ci.yml matches a denied path",
+			".github/workflows/ci.yml is outside the allowed paths",
+			"main.go is outside the allowed paths",
+			"4 files changed; the limit is 3",
+			"6 lines changed; the limit is 4",
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("checkGuardrailsLocally = %q, want %q", got, want)
+		}
+		if got, _ := checkGuardrailsLocally(Tender{AllowPaths: []string{"**"}}, dir, "base", "HEAD"); len(got) != 0 {
+			t.Fatalf("expected no violations, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package tender
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tenderCommitSubjectRE matches the commit message tender's workflow commits
+// with and captures the tender name.
+var tenderCommitSubjectRE = regexp.MustCompile("^" + strings.Replace(regexp.QuoteMeta(commitMessageFormat), "%s", "(.+)", 1) + "$")
+
+// historyRecordSep starts each commit in the git log output HistoryOf reads.
+const historyRecordSep = "\x1e"
+
+// TenderHistory is what one tender's commits changed, from local git history.
+type TenderHistory struct {
+	Name     string          `json:"name"`
+	Commits  int             `json:"commits"`
+	Reverted int             `json:"reverted"`
+	Files    []FileChange    `json:"files"` // every file touched, by path
+	Added    int             `json:"added"`
+	Removed  int             `json:"removed"`
+	First    *time.Time      `json:"first,omitempty"`
+	Last     *time.Time      `json:"last,omitempty"`
+	Timeline []HistoryCommit `json:"timeline"` // newest first
+}
+
+// HistoryCommit is one tender commit in a TenderHistory timeline.
+type HistoryCommit struct {
+	SHA      string       `json:"sha"`
+	Date     time.Time    `json:"date"`
+	Files    []FileChange `json:"files"`
+	Added    int          `json:"added"`
+	Removed  int          `json:"removed"`
+	Reverted bool         `json:"reverted"`
+}
+
+// FileChange counts the lines added to and removed from a file; binary files
+// count as 0.
+type FileChange struct {
+	Path    string `json:"path"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+}
+
+// HistoryOf reads the commits reachable from HEAD that tender workflows made,
+// by their commit message, and sums them up per tender. With name, only that
+// tender is returned, even when it has no commits; without, every current
+// tender and every tender name found in history, by name. Removed tenders'
+// commits are included, so name does not have to be a current tender.
+func HistoryOf(root, name string) ([]TenderHistory, error) {
+	name = strings.TrimSpace(name)
+	var names []string
+	if tenders, err := LoadTenders(root); err == nil {
+		for _, t := range tenders {
+			names = append(names, t.Name)
+		}
+		if idx := findTenderIndex(tenders, name); name != "" && idx >= 0 {
+			name = tenders[idx].Name
+		}
+	}
+
+	if _, err := runGit(root, "rev-parse", "HEAD"); err != nil {
+		return nil, fmt.Errorf("cannot read history: %s is not a git repository with commits", root)
+	}
+	out, err := runGit(root, "log", "--format="+historyRecordSep+"%H%x09%cI%x09%s", "--numstat", "--no-renames",
+		"-E", "--grep="+tenderCommitSubjectRE.String(), "HEAD", "--")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read git history in %s: %w", root, err)
+	}
+	reverted, err := revertedCommits(root)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*TenderHistory{}
+	get := func(n string) *TenderHistory {
+		if h, ok := byName[n]; ok {
+			return h
+		}
+		h := &TenderHistory{Name: n, Files: []FileChange{}, Timeline: []HistoryCommit{}}
+		byName[n] = h
+		return h
+	}
+	if name != "" {
+		get(name)
+	} else {
+		for _, n := range names {
+			get(n)
+		}
+	}
+
+	for _, record := range strings.Split(out, historyRecordSep) {
+		lines := strings.Split(strings.TrimSpace(record), "\n")
+		header := strings.SplitN(lines[0], "\t", 3)
+		if len(header) != 3 {
+			continue
+		}
+		m := tenderCommitSubjectRE.FindStringSubmatch(header[2])
+		if m == nil || (name != "" && m[1] != name) {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, header[1])
+		if err != nil {
+			return nil, fmt.Errorf("commit %s has an unreadable date %q", shortCommit(header[0]), header[1])
+		}
+		c := HistoryCommit{SHA: header[0], Date: date, Files: []FileChange{}, Reverted: reverted[header[0]]}
+		for _, line := range lines[1:] {
+			fields := strings.SplitN(line, "\t", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			added, _ := strconv.Atoi(fields[0])
+			removed, _ := strconv.Atoi(fields[1])
+			c.Files = append(c.Files, FileChange{Path: fields[2], Added: added, Removed: removed})
+			c.Added += added
+			c.Removed += removed
+		}
+		h := get(m[1])
+		h.Timeline = append(h.Timeline, c)
+	}
+
+	histories := make([]TenderHistory, 0, len(byName))
+	for _, h := range byName {
+		summarizeHistory(h)
+		histories = append(histories, *h)
+	}
+	sort.Slice(histories, func(i, j int) bool { return histories[i].Name < histories[j].Name })
+	return histories, nil
+}
+
+// summarizeHistory fills in h's totals from its timeline.
+func summarizeHistory(h *TenderHistory) {
+	files := map[string]*FileChange{}
+	for i, c := range h.Timeline {
+		h.Commits++
+		if c.Reverted {
+			h.Reverted++
+		}
+		h.Added += c.Added
+		h.Removed += c.Removed
+		for _, f := range c.Files {
+			total, ok := files[f.Path]
+			if !ok {
+				total = &FileChange{Path: f.Path}
+				files[f.Path] = total
+			}
+			total.Added += f.Added
+			total.Removed += f.Removed
+		}
+		if i == 0 {
+			last := c.Date
+			h.Last = &last
+		}
+		first := c.Date
+		h.First = &first
+	}
+	for _, f := range files {
+		h.Files = append(h.Files, *f)
+	}
+	sort.Slice(h.Files, func(i, j int) bool { return h.Files[i].Path < h.Files[j].Path })
+}
+
+// PrintHistory writes HistoryOf as a table with each tender's timeline, or as
+// JSON.
+func PrintHistory(root, name string, asJSON bool, stdout io.Writer) error {
+	histories, err := HistoryOf(root, name)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(histories)
+	}
+	if len(histories) == 0 {
+		_, _ = fmt.Fprintln(stdout, "No tender commits found in local history.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(stdout, "NAME\tCOMMITS\tFILES\tADDED\tREMOVED\tFIRST\tLAST")
+	for _, h := range histories {
+		first, last := "-", "-"
+		if h.First != nil {
+			first, last = formatHistoryTime(*h.First), formatHistoryTime(*h.Last)
+		}
+		commits := strconv.Itoa(h.Commits)
+		if h.Reverted > 0 {
+			commits += fmt.Sprintf(" (%d reverted)", h.Reverted)
+		}
+		_, _ = fmt.Fprintf(stdout, "%s\t%s\t%d\t+%d\t-%d\t%s\t%s\n", h.Name, commits, len(h.Files), h.Added, h.Removed, first, last)
+	}
+	for _, h := range histories {
+		if len(h.Timeline) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(stdout, "\n%s:\n", h.Name)
+		for _, c := range h.Timeline {
+			line := fmt.Sprintf("  %s  %s  +%d -%d  %s", formatHistoryTime(c.Date), shortCommit(c.SHA), c.Added, c.Removed, historyFiles(c.Files))
+			if c.Reverted {
+				line += "  (reverted)"
+			}
+			_, _ = fmt.Fprintln(stdout, line)
+		}
+		if name != "" {
+			_, _ = fmt.Fprintln(stdout, "\nFiles touched:")
+			for _, f := range h.Files {
+				_, _ = fmt.Fprintf(stdout, "  +%d -%d\t%s\n", f.Added, f.Removed, f.Path)
+			}
+		}
+	}
+	return nil
+}
+
+func formatHistoryTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+// historyFiles names a commit's files, or counts them when there are many.
+func historyFiles(files []FileChange) string {
+	if len(files) > 3 {
+		return fmt.Sprintf("%d files", len(files))
+	}
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+	return strings.Join(paths, ", ")
+}
@@ -0,0 +1,88 @@
+package tender
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// history.go tests
+
+func TestHistory(t *testing.T) {
+	root := newTestRepo(t)
+	if _, err := SaveNewTender(root, Tender{Name: "docs", Agent: "Build", Manual: true}); err != nil {
+		t.Fatalf("SaveNewTender: %v", err)
+	}
+	if _, err := SaveNewTender(root, Tender{Name: "idle", Agent: "Build", Manual: true}); err != nil {
+		t.Fatalf("SaveNewTender: %v", err)
+	}
+	commitAt(t, root, "2026-10-01T08:00:00Z", "initial", map[string]string{"README.md": "hello\n"})
+	commitAt(t, root, "2026-10-02T09:00:00Z", "tender(docs): autonomous update", map[string]string{"README.md": "hello\nmore\n", "docs/a.md": "a\nb\n"})
+	commitAt(t, root, "2026-10-03T09:00:00Z", "manual edit", map[string]string{"src.go": "package x\n"})
+	commitAt(t, root, "2026-10-04T09:00:00Z", "tender(docs): autonomous update", map[string]string{"docs/a.md": "a\nc\n"})
+	commitAt(t, root, "2026-10-05T09:00:00Z", "tender(old-sweeper): autonomous update", map[string]string{"tmp.txt": "x\n"})
+
+	t.Run("sums up every tender's commits", func(t *testing.T) {
+		histories, err := HistoryOf(root, "")
+		if err != nil {
+			t.Fatalf("HistoryOf returned error: %v", err)
+		}
+		var names []string
+		for _, h := range histories {
+			names = append(names, h.Name)
+		}
+		if strings.Join(names, ",") != "docs,idle,old-sweeper" {
+			t.Fatalf("histories = %v", names)
+		}
+		docs := histories[0]
+		if docs.Commits != 2 || len(docs.Files) != 2 || docs.Added != 4 || docs.Removed != 1 {
+			t.Fatalf("unexpected docs totals: %+v", docs)
+		}
+		if docs.Timeline[0].Date.Day() != 4 || docs.First.Day() != 2 || docs.Last.Day() != 4 {
+			t.Fatalf("expected the timeline newest first: %+v", docs.Timeline)
+		}
+		if histories[1].Commits != 0 || histories[1].First != nil {
+			t.Fatalf("expected idle to have no commits: %+v", histories[1])
+		}
+	})
+
+	t.Run("prints a table and timeline", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := PrintHistory(root, "", false, &out); err != nil {
+			t.Fatalf("PrintHistory returned error: %v", err)
+		}
+		if !containsAll(out.String(),
+			"NAME\tCOMMITS\tFILES\tADDED\tREMOVED\tFIRST\tLAST\n",
+			"docs\t2\t2\t+4\t-1\t2026-10-02 09:00 UTC\t2026-10-04 09:00 UTC\n",
+			"idle\t0\t0\t+0\t-0\t-\t-\n",
+			"  2026-10-02 09:00 UTC  ",
+			"+3 -0  README.md, docs/a.md\n",
+		) || strings.Contains(out.String(), "Files touched:") {
+			t.Fatalf("unexpected history:\n%s", out.String())
+		}
+	})
+
+	t.Run("shows one tender with its files and reverts as JSON", func(t *testing.T) {
+		testGit(t, root, "revert", "--no-edit", "HEAD~1")
+		var out bytes.Buffer
+		if err := PrintHistory(root, "DOCS", false, &out); err != nil {
+			t.Fatalf("PrintHistory returned error: %v", err)
+		}
+		if !containsAll(out.String(), "docs\t2 (1 reverted)\t", "(reverted)\n", "Files touched:\n  +1 -0\tREADME.md\n  +3 -1\tdocs/a.md\n") || strings.Contains(out.String(), "idle") {
+			t.Fatalf("unexpected history:\n%s", out.String())
+		}
+
+		out.Reset()
+		if err := PrintHistory(root, "docs", true, &out); err != nil {
+			t.Fatalf("PrintHistory returned error: %v", err)
+		}
+		var histories []TenderHistory
+		if err := json.Unmarshal(out.Bytes(), &histories); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out.String())
+		}
+		if len(histories) != 1 || histories[0].Reverted != 1 || !histories[0].Timeline[0].Reverted || len(histories[0].Timeline[1].Files) != 2 {
+			t.Fatalf("unexpected JSON history: %+v", histories)
+		}
+	})
+}
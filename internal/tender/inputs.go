@@ -0,0 +1,284 @@
+package tender
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Dispatch input types a tender can declare.
+const (
+	InputString  = "string"
+	InputBoolean = "boolean"
+	InputChoice  = "choice"
+)
+
+// promptInputName is the workflow_dispatch input every on-demand tender has.
+const promptInputName = "prompt"
+
+// DispatchInput is a typed workflow_dispatch input a tender declares besides
+// prompt. Runs see its value as TENDER_INPUT_<NAME> and in prompts as
+// {{input.<name>}}; scheduled and push runs get the default.
+type DispatchInput struct {
+	Name    string
+	Type    string
+	Default string
+	Options []string // choice only
+}
+
+var inputNameRE = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ParseInputDeclaration parses the --input syntax of tender add/update:
+// name[:string|:boolean|:choice:opt1,opt2,...][=default]. Choices default to
+// their first option and booleans to false.
+func ParseInputDeclaration(raw string) (DispatchInput, error) {
+	decl, def, hasDefault := strings.TrimSpace(raw), "", false
+	if i := strings.IndexByte(decl, '='); i >= 0 {
+		decl, def, hasDefault = decl[:i], strings.TrimSpace(decl[i+1:]), true
+	}
+	parts := strings.SplitN(decl, ":", 3)
+	in := DispatchInput{Name: strings.TrimSpace(parts[0]), Type: InputString}
+	if len(parts) > 1 {
+		in.Type = strings.TrimSpace(parts[1])
+	}
+	if len(parts) > 2 {
+		if in.Type != InputChoice {
+			return DispatchInput{}, fmt.Errorf("input %q: only choice inputs list options", raw)
+		}
+		for _, opt := range strings.Split(parts[2], ",") {
+			if opt = strings.TrimSpace(opt); opt != "" {
+				in.Options = append(in.Options, opt)
+			}
+		}
+	}
+	switch {
+	case hasDefault:
+		in.Default = def
+	case in.Type == InputBoolean:
+		in.Default = "false"
+	case in.Type == InputChoice && len(in.Options) > 0:
+		in.Default = in.Options[0]
+	}
+	if err := validateInputs([]DispatchInput{in}); err != nil {
+		return DispatchInput{}, err
+	}
+	return in, nil
+}
+
+// String returns the input in ParseInputDeclaration syntax.
+func (in DispatchInput) String() string {
+	s := in.Name + ":" + in.Type
+	if in.Type == InputChoice {
+		s += ":" + strings.Join(in.Options, ",")
+	}
+	return s + "=" + in.Default
+}
+
+// validateInputs checks declared inputs: names usable as env suffixes and
+// placeholders, known types, and defaults their type accepts.
+func validateInputs(inputs []DispatchInput) error {
+	seen := map[string]bool{}
+	for _, in := range inputs {
+		if !inputNameRE.MatchString(in.Name) {
+			return fmt.Errorf("input name %q must start with a lowercase letter and contain only lowercase letters, digits and '_'", in.Name)
+		}
+		if in.Name == promptInputName {
+			return fmt.Errorf("input %q is built in", promptInputName)
+		}
+		if seen[in.Name] {
+			return fmt.Errorf("input %q is declared twice", in.Name)
+		}
+		seen[in.Name] = true
+		if strings.ContainsAny(in.Default, "\r\n") {
+			return fmt.Errorf("input %q: default cannot contain newlines", in.Name)
+		}
+		switch in.Type {
+		case InputString:
+		case InputBoolean:
+			if in.Default != "true" && in.Default != "false" {
+				return fmt.Errorf("input %q: boolean default must be true or false, got %q", in.Name, in.Default)
+			}
+		case InputChoice:
+			if len(in.Options) == 0 {
+				return fmt.Errorf("input %q: choice inputs need options", in.Name)
+			}
+			for _, opt := range in.Options {
+				if strings.ContainsAny(opt, "\r\n") {
+					return fmt.Errorf("input %q: options cannot contain newlines", in.Name)
+				}
+			}
+			if !containsString(in.Options, in.Default) {
+				return fmt.Errorf("input %q: default %q is not one of %s", in.Name, in.Default, strings.Join(in.Options, ", "))
+			}
+		default:
+			return fmt.Errorf("input %q: type must be %s, %s or %s, got %q", in.Name, InputString, InputBoolean, InputChoice, in.Type)
+		}
+	}
+	return nil
+}
+
+// ResolveInputValues checks key=value pairs given to tender run against the
+// tender's declared inputs and returns them as dispatch inputs.
+func ResolveInputValues(t Tender, pairs []string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, pair := range pairs {
+		i := strings.IndexByte(pair, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("input %q must be key=value", pair)
+		}
+		key, value := strings.TrimSpace(pair[:i]), pair[i+1:]
+		in, ok := findInput(t.Inputs, key)
+		if !ok {
+			return nil, fmt.Errorf("tender %q has no input %q; declared inputs: %s", t.Name, key, inputNames(t.Inputs))
+		}
+		switch in.Type {
+		case InputBoolean:
+			if value != "true" && value != "false" {
+				return nil, fmt.Errorf("input %q is a boolean: use true or false, got %q", key, value)
+			}
+		case InputChoice:
+			if !containsString(in.Options, value) {
+				return nil, fmt.Errorf("input %q must be one of %s, got %q", key, strings.Join(in.Options, ", "), value)
+			}
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func findInput(inputs []DispatchInput, name string) (DispatchInput, bool) {
+	for _, in := range inputs {
+		if in.Name == name {
+			return in, true
+		}
+	}
+	return DispatchInput{}, false
+}
+
+func inputNames(inputs []DispatchInput) string {
+	if len(inputs) == 0 {
+		return "none"
+	}
+	names := make([]string, 0, len(inputs))
+	for _, in := range inputs {
+		names = append(names, in.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// inputEnvName is the Run OpenCode step env var carrying an input's value.
+func inputEnvName(in DispatchInput) string {
+	return "TENDER_INPUT_" + strings.ToUpper(in.Name)
+}
+
+// renderInputLines are the workflow_dispatch.inputs entries of declared
+// inputs, relative to the inputs mapping.
+func renderInputLines(inputs []DispatchInput) []string {
+	var lines []string
+	for _, in := range inputs {
+		def := strconv.Quote(in.Default)
+		if in.Type == InputBoolean {
+			def = in.Default
+		}
+		lines = append(lines,
+			in.Name+":",
+			"  required: false",
+			"  default: "+def,
+			"  type: "+in.Type,
+		)
+		if in.Type == InputChoice {
+			lines = append(lines, "  options:")
+			for _, opt := range in.Options {
+				lines = append(lines, "    - "+strconv.Quote(opt))
+			}
+		}
+	}
+	return lines
+}
+
+// inputEnvLines pass declared inputs to the Run OpenCode step. Runs that were
+// not dispatched, and dispatches leaving a string empty, get the default;
+// format() keeps a dispatched false from falling through to it.
+func inputEnvLines(inputs []DispatchInput) []string {
+	var lines []string
+	for _, in := range inputs {
+		def := "'" + strings.Replace(in.Default, "'", "''", -1) + "'"
+		lines = append(lines, fmt.Sprintf("    %s: ${{ github.event_name == 'workflow_dispatch' && format('{0}', inputs.%s) || %s }}", inputEnvName(in), in.Name, def))
+	}
+	return lines
+}
+
+// workflowDispatchInput is one entry of on.workflow_dispatch.inputs.
+type workflowDispatchInput struct {
+	Type    string   `yaml:"type"`
+	Default string   `yaml:"default"`
+	Options []string `yaml:"options"`
+}
+
+// parseDispatchInputs reads the declared inputs of a workflow_dispatch
+// trigger, skipping the built-in prompt.
+func parseDispatchInputs(value *yaml.Node) ([]DispatchInput, error) {
+	if value == nil || value.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	var inputsNode *yaml.Node
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		if value.Content[i].Value == "inputs" {
+			inputsNode = value.Content[i+1]
+		}
+	}
+	if inputsNode == nil || inputsNode.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	var inputs []DispatchInput
+	for i := 0; i+1 < len(inputsNode.Content); i += 2 {
+		name := inputsNode.Content[i].Value
+		if name == promptInputName {
+			continue
+		}
+		var raw workflowDispatchInput
+		if err := inputsNode.Content[i+1].Decode(&raw); err != nil {
+			return nil, fmt.Errorf("on.workflow_dispatch.inputs.%s: %v", name, err)
+		}
+		in := DispatchInput{Name: name, Type: strings.TrimSpace(raw.Type), Default: raw.Default, Options: raw.Options}
+		if in.Type == "" {
+			in.Type = InputString
+		}
+		if in.Type == InputBoolean && in.Default == "" {
+			in.Default = "false"
+		}
+		inputs = append(inputs, in)
+	}
+	if err := validateInputs(inputs); err != nil {
+		return nil, fmt.Errorf("on.workflow_dispatch.inputs: %v", err)
+	}
+	return inputs, nil
+}
+
+// sortedInputValues lists dispatch input values by name, for stable command
+// lines.
+func sortedInputValues(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, k+"="+values[k])
+	}
+	return out
+}
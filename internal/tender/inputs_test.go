@@ -0,0 +1,136 @@
+package tender
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// inputs.go tests
+
+func TestParseInputDeclaration(t *testing.T) {
+	t.Run("parses types, options and defaults", func(t *testing.T) {
+		cases := map[string]DispatchInput{
+			"target":                        {Name: "target", Type: InputString},
+			"target=docs/":                  {Name: "target", Type: InputString, Default: "docs/"},
+			"dry_run:boolean":               {Name: "dry_run", Type: InputBoolean, Default: "false"},
+			"dry_run:boolean=true":          {Name: "dry_run", Type: InputBoolean, Default: "true"},
+			"scope:choice:docs, tests":      {Name: "scope", Type: InputChoice, Default: "docs", Options: []string{"docs", "tests"}},
+			"scope:choice:docs,tests=tests": {Name: "scope", Type: InputChoice, Default: "tests", Options: []string{"docs", "tests"}},
+		}
+		for raw, want := range cases {
+			got, err := ParseInputDeclaration(raw)
+			if err != nil {
+				t.Fatalf("ParseInputDeclaration(%q) returned error: %v", raw, err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("ParseInputDeclaration(%q) = %+v, want %+v", raw, got, want)
+			}
+		}
+	})
+
+	t.Run("rejects bad declarations", func(t *testing.T) {
+		cases := map[string]string{
+			"Target":              "must start with a lowercase letter",
+			"dry-run:boolean":     "must start with a lowercase letter",
+			"prompt":              "built in",
+			"count:number":        "type must be",
+			"dry_run:boolean=yes": "true or false",
+			"scope:choice":        "need options",
+			"scope:choice:a,b=c":  "not one of",
+			"target:string:a,b":   "only choice inputs",
+		}
+		for raw, want := range cases {
+			if _, err := ParseInputDeclaration(raw); err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("ParseInputDeclaration(%q) error = %v, want %q", raw, err, want)
+			}
+		}
+	})
+}
+
+func TestResolveInputValues(t *testing.T) {
+	tender := Tender{Name: "nightly", Inputs: []DispatchInput{
+		{Name: "dry_run", Type: InputBoolean, Default: "false"},
+		{Name: "scope", Type: InputChoice, Default: "docs", Options: []string{"docs", "tests"}},
+		{Name: "target", Type: InputString},
+	}}
+
+	t.Run("accepts values the declared inputs allow", func(t *testing.T) {
+		got, err := ResolveInputValues(tender, []string{"dry_run=true", "scope=tests", "target=a=b"})
+		if err != nil {
+			t.Fatalf("ResolveInputValues returned error: %v", err)
+		}
+		want := map[string]string{"dry_run": "true", "scope": "tests", "target": "a=b"}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("ResolveInputValues = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("rejects unknown keys and mistyped values", func(t *testing.T) {
+		cases := map[string]string{
+			"missing=1":   "has no input \"missing\"; declared inputs: dry_run, scope, target",
+			"dry_run=yes": "use true or false",
+			"scope=all":   "must be one of docs, tests",
+			"target":      "must be key=value",
+		}
+		for pair, want := range cases {
+			if _, err := ResolveInputValues(tender, []string{pair}); err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("ResolveInputValues(%q) error = %v, want %q", pair, err, want)
+			}
+		}
+	})
+}
+
+func TestDispatchInputsWorkflow(t *testing.T) {
+	inputs := []DispatchInput{
+		{Name: "dry_run", Type: InputBoolean, Default: "false"},
+		{Name: "scope", Type: InputChoice, Default: "tests", Options: []string{"docs", "tests"}},
+		{Name: "note", Type: InputString, Default: "it's"},
+	}
+	base := Tender{Name: "nightly", Agent: "Build", Manual: true, Inputs: inputs, Prompt: "Cover {{input.scope}} (dry run: {{input.dry_run}})"}
+
+	t.Run("renders typed inputs and passes them to the agent", func(t *testing.T) {
+		content := RenderWorkflow(base)
+		for _, want := range []string{
+			"      dry_run:\n        required: false\n        default: false\n        type: boolean\n",
+			"      scope:\n        required: false\n        default: \"tests\"\n        type: choice\n        options:\n          - \"docs\"\n          - \"tests\"\n",
+			"TENDER_INPUT_DRY_RUN: ${{ github.event_name == 'workflow_dispatch' && format('{0}', inputs.dry_run) || 'false' }}",
+			"TENDER_INPUT_NOTE: ${{ github.event_name == 'workflow_dispatch' && format('{0}', inputs.note) || 'it''s' }}",
+			`RUN_PROMPT="${RUN_PROMPT//\{\{input.scope\}\}/"$TENDER_INPUT_SCOPE"}"`,
+		} {
+			if !strings.Contains(content, want) {
+				t.Fatalf("rendered workflow missing %q:\n%s", want, content)
+			}
+		}
+	})
+
+	t.Run("round-trips through the workflow file", func(t *testing.T) {
+		parsed, err := parseTenderWorkflow(RenderWorkflow(base))
+		if err != nil {
+			t.Fatalf("parseTenderWorkflow returned error: %v", err)
+		}
+		if !reflect.DeepEqual(parsed.Inputs, inputs) {
+			t.Fatalf("round trip inputs = %+v, want %+v", parsed.Inputs, inputs)
+		}
+	})
+
+	t.Run("reports unsupported inputs as malformed", func(t *testing.T) {
+		broken := strings.Replace(RenderWorkflow(base), "type: boolean", "type: number", 1)
+		if _, err := parseTenderWorkflow(broken); err == nil || !strings.Contains(err.Error(), "on.workflow_dispatch.inputs") {
+			t.Fatalf("expected inputs error, got %v", err)
+		}
+	})
+
+	t.Run("validates inputs against the tender", func(t *testing.T) {
+		scheduled := base
+		scheduled.Manual, scheduled.Crons = false, []string{"0 9 * * *"}
+		if err := ValidateTender(scheduled); err == nil || !strings.Contains(err.Error(), "need manual runs") {
+			t.Fatalf("expected manual runs error, got %v", err)
+		}
+		undeclared := base
+		undeclared.Prompt = "Cover {{input.area}}"
+		if err := ValidateTender(undeclared); err == nil || !strings.Contains(err.Error(), "{{input.dry_run}}") {
+			t.Fatalf("expected unknown placeholder error listing inputs, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package tender
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalRun is the outcome of rehearsing a tender on this machine.
+type LocalRun struct {
+	Base   string // commit the worktree started from
+	Commit string // commit holding the agent's changes; empty when it changed nothing
+	Stat   string
+	Diff   string
+	// VerifyFailed reports that the tender's verify command failed on the
+	// changes, so its workflow would not have delivered them.
+	VerifyFailed bool
+	// Violations are the tender's guardrails the changes broke; its workflow
+	// would have stopped before committing them.
+	Violations []string
+	// Secrets are what the tender's secret scan found in the changes; its
+	// workflow would have stopped before committing them.
+	Secrets []SecretFinding
+}
+
+// RunTenderLocally rehearses a tender the way its workflow runs it: it checks
+// out the current HEAD in a temporary git worktree, resolves the prompt,
+// exports the TENDER_* env and OpenCode config paths, runs
+// `opencode run --agent ...`, commits the result as tender[bot] and checks it
+// against the tender's guardrails, secret scan and verify command. inputs are
+// key=value pairs for the tender's declared inputs, as for a dispatch. The diff
+// is returned instead of being pushed, and the worktree is removed afterwards.
+func RunTenderLocally(root, tenderName, prompt string, inputs []string, stdout, stderr io.Writer) (LocalRun, error) {
+	tenders, err := LoadTenders(root)
+	if err != nil {
+		return LocalRun{}, err
+	}
+	idx := findTenderIndex(tenders, tenderName)
+	if idx < 0 {
+		return LocalRun{}, fmt.Errorf("tender %q not found", tenderName)
+	}
+	t := tenders[idx]
+	values, err := ResolveInputValues(t, inputs)
+	if err != nil {
+		return LocalRun{}, err
+	}
+
+	if _, err := exec.LookPath("opencode"); err != nil {
+		return LocalRun{}, fmt.Errorf("OpenCode CLI 'opencode' is required to run a tender locally")
+	}
+	base, err := runGit(root, "rev-parse", "HEAD")
+	if err != nil {
+		return LocalRun{}, fmt.Errorf("cannot run a tender locally: %s is not a git repository with commits", root)
+	}
+	base = strings.TrimSpace(base)
+
+	parent, err := os.MkdirTemp("", "tender-local-")
+	if err != nil {
+		return LocalRun{}, err
+	}
+	defer os.RemoveAll(parent)
+	worktree := filepath.Join(parent, Slugify(t.Name))
+	if _, err := runGit(root, "worktree", "add", "--detach", worktree, base); err != nil {
+		return LocalRun{}, fmt.Errorf("git worktree add failed: %w", err)
+	}
+	defer func() {
+		_, _ = runGit(root, "worktree", "remove", "--force", worktree)
+	}()
+
+	values = localInputValues(t, values)
+	args, err := localOpenCodeArgs(t, prompt, values, worktree, time.Now())
+	if err != nil {
+		return LocalRun{}, err
+	}
+	env := append(os.Environ(), localRunEnv(t, values, worktree)...)
+	cmd := exec.Command("opencode", args...)
+	cmd.Dir = worktree
+	cmd.Env = env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return LocalRun{}, fmt.Errorf("opencode run failed: %w", err)
+	}
+
+	result := LocalRun{Base: base}
+	if _, err := runGit(worktree, "add", "-A"); err != nil {
+		return LocalRun{}, fmt.Errorf("git add failed: %w", err)
+	}
+	if _, err := runGit(worktree, "diff", "--cached", "--quiet", "--ignore-submodules"); err != nil {
+		if _, err := runGit(worktree,
+			"-c", "user.name=tender[bot]", "-c", "user.email="+tenderBotEmail,
+			"commit", "--no-verify", "-m", fmt.Sprintf(commitMessageFormat, t.Name)); err != nil {
+			return LocalRun{}, fmt.Errorf("git commit failed: %w", err)
+		}
+	}
+	head, err := runGit(worktree, "rev-parse", "HEAD")
+	if err != nil {
+		return LocalRun{}, err
+	}
+	if head = strings.TrimSpace(head); head == base {
+		return result, nil
+	}
+	result.Commit = head
+	if hasGuardrails(t) {
+		if result.Violations, err = checkGuardrailsLocally(t, worktree, base, head); err != nil {
+			return LocalRun{}, err
+		}
+	}
+	if t.SecretScan && len(result.Violations) == 0 {
+		if result.Secrets, _, err = ScanStagedDiff(worktree, base); err != nil {
+			return LocalRun{}, err
+		}
+	}
+	// As in the workflow, changes the guardrails or secret scan stop are not
+	// verified.
+	if strings.TrimSpace(t.Verify) != "" && len(result.Violations) == 0 && len(result.Secrets) == 0 {
+		result.VerifyFailed = !runVerifyLocally(t, worktree, env, stdout, stderr)
+	}
+	if result.Stat, err = runGit(worktree, "diff", "--stat", base, head); err != nil {
+		return LocalRun{}, err
+	}
+	if result.Diff, err = runGit(worktree, "diff", base, head); err != nil {
+		return LocalRun{}, err
+	}
+	return result, nil
+}
+
+// resolveRunPrompt mirrors the workflow: a dispatch prompt wins over the
+// tender's prompt file, read from the checkout in dir, or inline prompt, which
+// win over the generic fallback.
+func resolveRunPrompt(t Tender, override, dir string) (string, error) {
+	if prompt := strings.TrimSpace(override); prompt != "" {
+		return prompt, nil
+	}
+	if strings.TrimSpace(t.PromptFile) != "" {
+		prompt, err := readPromptFile(dir, t)
+		if err != nil || prompt != "" {
+			return prompt, err
+		}
+	}
+	if prompt := strings.TrimSpace(t.Prompt); prompt != "" {
+		return prompt, nil
+	}
+	return fmt.Sprintf(fallbackPromptFormat, strings.TrimSpace(t.Name)), nil
+}
+
+// localInputValues fills in the defaults of inputs a local run was not given,
+// as the workflow does for inputs left empty.
+func localInputValues(t Tender, values map[string]string) map[string]string {
+	out := make(map[string]string, len(t.Inputs))
+	for _, in := range t.Inputs {
+		out[in.Name] = in.Default
+		if v := values[in.Name]; v != "" {
+			out[in.Name] = v
+		}
+	}
+	return out
+}
+
+// expandPromptLocally substitutes prompt placeholders the way the workflow
+// does, with values from the checkout in dir: HEAD stands in for the
+// triggering commit and $USER for the actor, and event placeholders are empty
+// as in runs no event started. inputs holds every declared input's value.
+func expandPromptLocally(t Tender, prompt string, inputs map[string]string, dir string, now time.Time) string {
+	if !usesPromptTemplate(t) {
+		return prompt
+	}
+	sha, _ := runGit(dir, "rev-parse", "HEAD")
+	changed, _ := runGit(dir, "show", "--pretty=format:", "--name-only", "HEAD")
+	previous, _ := runGit(dir, "log", "-1", "--format=%H", "-F", "--grep="+fmt.Sprintf(commitMessageFormat, strings.TrimSpace(t.Name)))
+	if previous = strings.TrimSpace(previous); previous == "" {
+		previous = "none"
+	}
+	values := map[string]string{
+		"sha":             strings.TrimSpace(sha),
+		"changed_files":   strings.Join(strings.Fields(changed), " "),
+		"date":            now.UTC().Format("2006-01-02"),
+		"previous_commit": previous,
+		"actor":           os.Getenv("USER"),
+	}
+	for _, p := range tenderPlaceholders(t) {
+		prompt = strings.ReplaceAll(prompt, "{{"+p.Name+"}}", values[p.Name])
+	}
+	for _, in := range t.Inputs {
+		prompt = strings.ReplaceAll(prompt, "{{"+inputPlaceholderPrefix+in.Name+"}}", inputs[in.Name])
+	}
+	return prompt
+}
+
+func localOpenCodeArgs(t Tender, prompt string, inputs map[string]string, dir string, now time.Time) ([]string, error) {
+	resolved, err := resolveRunPrompt(t, prompt, dir)
+	if err != nil {
+		return nil, err
+	}
+	return openCodeRunArgs(strings.TrimSpace(t.Agent), strings.TrimSpace(t.Model), expandPromptLocally(t, resolved, inputs, dir, now)), nil
+}
+
+// localRunEnv is the job env of the generated workflow plus the input values
+// and OpenCode config paths its Run OpenCode step sets.
+func localRunEnv(t Tender, inputs map[string]string, worktree string) []string {
+	var env []string
+	for _, kv := range tenderEnv(t) {
+		env = append(env, kv.Key+"="+kv.Value)
+	}
+	for _, in := range t.Inputs {
+		env = append(env, inputEnvName(in)+"="+inputs[in.Name])
+	}
+	if info, err := os.Stat(filepath.Join(worktree, openCodeConfigFile)); err == nil && !info.IsDir() {
+		env = append(env, "OPENCODE_CONFIG="+filepath.Join(worktree, openCodeConfigFile))
+	}
+	if info, err := os.Stat(filepath.Join(worktree, ".opencode")); err == nil && info.IsDir() {
+		env = append(env, "OPENCODE_CONFIG_DIR="+filepath.Join(worktree, ".opencode"))
+	}
+	return env
+}
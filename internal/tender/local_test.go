@@ -0,0 +1,228 @@
+package tender
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// local.go tests
+
+func TestRunTenderLocally(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+
+	gitIn := func(t *testing.T, dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+	// fakeOpenCode records its argv and the env the workflow would set into
+	// NOTES.md, or changes nothing when TENDER_PROMPT is "noop".
+	fakeOpenCode := func(t *testing.T) {
+		t.Helper()
+		bin := t.TempDir()
+		script := `#!/bin/sh
+echo "opencode says hi"
+if [ "$TENDER_PROMPT" = "noop" ]; then exit 0; fi
+{
+  echo "args: $*"
+  echo "name: $TENDER_NAME"
+  echo "model: $TENDER_MODEL"
+  echo "config: $(basename "$OPENCODE_CONFIG")"
+} > NOTES.md
+`
+		if err := os.WriteFile(filepath.Join(bin, "opencode"), []byte(script), 0o755); err != nil {
+			t.Fatalf("write fake opencode: %v", err)
+		}
+		t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	}
+	setup := func(t *testing.T, tender Tender) string {
+		t.Helper()
+		root := t.TempDir()
+		gitIn(t, root, "init", "-q", "-b", "main")
+		if err := EnsureWorkflowDir(root); err != nil {
+			t.Fatalf("failed to create workflow dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, openCodeConfigFile), []byte("{}\n"), 0o644); err != nil {
+			t.Fatalf("write opencode.json: %v", err)
+		}
+		if _, err := SaveNewTender(root, tender); err != nil {
+			t.Fatalf("SaveNewTender: %v", err)
+		}
+		gitIn(t, root, "add", "-A")
+		gitIn(t, root, "-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-q", "-m", "init")
+		return root
+	}
+
+	t.Run("expands prompt placeholders from the checkout", func(t *testing.T) {
+		fakeOpenCode(t)
+		root := setup(t, Tender{Name: "review", Agent: "Build", Manual: true, Prompt: "Review {{changed_files}} at {{sha}} after {{previous_commit}}"})
+
+		run, err := RunTenderLocally(root, "review", "", nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("RunTenderLocally returned error: %v", err)
+		}
+		want := "+args: run --agent Build Review .github/workflows/review.yml opencode.json at " + run.Base + " after none"
+		if !strings.Contains(run.Diff, want) {
+			t.Fatalf("diff missing %q:\n%s", want, run.Diff)
+		}
+	})
+
+	t.Run("passes input values and defaults to the agent", func(t *testing.T) {
+		fakeOpenCode(t)
+		inputs := []DispatchInput{
+			{Name: "scope", Type: InputChoice, Default: "docs", Options: []string{"docs", "tests"}},
+			{Name: "dry_run", Type: InputBoolean, Default: "false"},
+		}
+		root := setup(t, Tender{Name: "review", Agent: "Build", Manual: true, Inputs: inputs, Prompt: "Cover {{input.scope}} dry={{input.dry_run}}"})
+
+		run, err := RunTenderLocally(root, "review", "", []string{"scope=tests"}, &bytes.Buffer{}, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("RunTenderLocally returned error: %v", err)
+		}
+		if !strings.Contains(run.Diff, "+args: run --agent Build Cover tests dry=false\n") {
+			t.Fatalf("unexpected diff:\n%s", run.Diff)
+		}
+		if _, err := RunTenderLocally(root, "review", "", []string{"scope=all"}, &bytes.Buffer{}, &bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "must be one of") {
+			t.Fatalf("expected invalid choice error, got %v", err)
+		}
+	})
+
+	t.Run("runs the agent in a worktree and reports the diff", func(t *testing.T) {
+		fakeOpenCode(t)
+		root := setup(t, Tender{Name: "nightly", Agent: "Build", Crons: []string{"0 9 * * *"}, Model: "openai/gpt-5"})
+
+		var stdout bytes.Buffer
+		run, err := RunTenderLocally(root, "nightly", "", nil, &stdout, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("RunTenderLocally returned error: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "opencode says hi") {
+			t.Fatalf("expected agent output to be streamed, got %q", stdout.String())
+		}
+		if run.Commit == "" || run.Base == "" || !strings.Contains(run.Stat, "NOTES.md") {
+			t.Fatalf("unexpected local run: %+v", run)
+		}
+		for _, want := range []string{
+			"+args: run --agent Build --model openai/gpt-5 Run the tender task 'nightly' for this repository.",
+			"+name: nightly",
+			"+model: openai/gpt-5",
+			"+config: opencode.json",
+		} {
+			if !strings.Contains(run.Diff, want) {
+				t.Fatalf("diff missing %q:\n%s", want, run.Diff)
+			}
+		}
+		if got := gitIn(t, root, "log", "-1", "--format=%an <%ae> %s", run.Commit); got != "tender[bot] <"+tenderBotEmail+"> tender(nightly): autonomous update\n" {
+			t.Fatalf("unexpected commit: %q", got)
+		}
+		if _, err := os.Stat(filepath.Join(root, "NOTES.md")); !os.IsNotExist(err) {
+			t.Fatalf("expected the checkout to stay untouched, stat err=%v", err)
+		}
+		if worktrees := gitIn(t, root, "worktree", "list"); strings.Count(worktrees, "\n") != 1 {
+			t.Fatalf("expected the temporary worktree to be removed:\n%s", worktrees)
+		}
+	})
+
+	t.Run("uses the prompt override", func(t *testing.T) {
+		fakeOpenCode(t)
+		root := setup(t, Tender{Name: "nightly", Agent: "Build", Manual: true, Prompt: "tidy up"})
+
+		run, err := RunTenderLocally(root, "nightly", "  fix the flaky test ", nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("RunTenderLocally returned error: %v", err)
+		}
+		if !strings.Contains(run.Diff, "+args: run --agent Build fix the flaky test\n") {
+			t.Fatalf("unexpected diff:\n%s", run.Diff)
+		}
+	})
+
+	t.Run("reports runs without changes", func(t *testing.T) {
+		fakeOpenCode(t)
+		root := setup(t, Tender{Name: "quiet", Agent: "Build", Manual: true, Prompt: "noop"})
+
+		run, err := RunTenderLocally(root, "quiet", "", nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("RunTenderLocally returned error: %v", err)
+		}
+		if run.Commit != "" || run.Diff != "" {
+			t.Fatalf("expected no changes, got %+v", run)
+		}
+	})
+
+	t.Run("runs the verify command on the changes", func(t *testing.T) {
+		fakeOpenCode(t)
+		for verify, failed := range map[string]bool{"grep -q 'name: gate' NOTES.md": false, "test ! -f NOTES.md": true} {
+			root := setup(t, Tender{Name: "gate", Agent: "Build", Manual: true, Verify: verify})
+			run, err := RunTenderLocally(root, "gate", "", nil, &bytes.Buffer{}, &bytes.Buffer{})
+			if err != nil {
+				t.Fatalf("RunTenderLocally returned error: %v", err)
+			}
+			if run.Commit == "" || run.VerifyFailed != failed {
+				t.Fatalf("verify %q: VerifyFailed = %v, want %v (%+v)", verify, run.VerifyFailed, failed, run)
+			}
+		}
+	})
+
+	t.Run("checks the guardrails before verifying", func(t *testing.T) {
+		fakeOpenCode(t)
+		root := setup(t, Tender{Name: "fenced", Agent: "Build", Manual: true, DenyPaths: []string{"*.md"}, Verify: "false"})
+		run, err := RunTenderLocally(root, "fenced", "", nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err != nil {
+			t.Fatalf("RunTenderLocally returned error: %v", err)
+		}
+		if len(run.Violations) != 1 || run.Violations[0] != "NOTES.md matches a denied path" || run.VerifyFailed {
+			t.Fatalf("expected a guardrail violation and no verify run, got %+v", run)
+		}
+	})
+
+	t.Run("returns error for unknown tender", func(t *testing.T) {
+		fakeOpenCode(t)
+		root := setup(t, Tender{Name: "nightly", Agent: "Build", Manual: true})
+		if _, err := RunTenderLocally(root, "missing", "", nil, &bytes.Buffer{}, &bytes.Buffer{}); err == nil {
+			t.Fatal("expected error for unknown tender")
+		}
+	})
+}
+
+func TestResolveRunPrompt(t *testing.T) {
+	t.Run("prefers override, then tender prompt, then fallback", func(t *testing.T) {
+		tender := Tender{Name: "nightly", Prompt: " tidy up "}
+		for override, want := range map[string]string{" now ": "now", "": "tidy up"} {
+			if got, err := resolveRunPrompt(tender, override, ""); err != nil || got != want {
+				t.Fatalf("resolveRunPrompt(%q) = %q, %v; want %q", override, got, err, want)
+			}
+		}
+		tender.Prompt = ""
+		if got, _ := resolveRunPrompt(tender, "", ""); got != "Run the tender task 'nightly' for this repository." {
+			t.Fatalf("fallback: got %q", got)
+		}
+	})
+
+	t.Run("reads the prompt file from the checkout", func(t *testing.T) {
+		dir := t.TempDir()
+		tender, err := UsePromptFile(dir, Tender{Name: "nightly", Agent: "Build"}, ".tender/prompts/nightly.md", "Tidy up.\nThen lint.")
+		if err != nil {
+			t.Fatalf("UsePromptFile returned error: %v", err)
+		}
+		if got, err := resolveRunPrompt(tender, "", dir); err != nil || got != "Tidy up.\nThen lint." {
+			t.Fatalf("resolveRunPrompt = %q, %v", got, err)
+		}
+		if got, err := resolveRunPrompt(tender, "now", dir); err != nil || got != "now" {
+			t.Fatalf("override: got %q, %v", got, err)
+		}
+		if _, err := resolveRunPrompt(tender, "", t.TempDir()); err == nil || !strings.Contains(err.Error(), "cannot read prompt file") {
+			t.Fatalf("expected missing prompt file error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package tender
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLogStep is the step `tender logs` shows when none is named.
+const DefaultLogStep = "opencode"
+
+// logStepAliases maps short --step names to generated step names.
+var logStepAliases = map[string]string{
+	"opencode": "Run OpenCode",
+	"install":  "Install OpenCode",
+	"checkout": "Run actions/checkout@v4",
+}
+
+// stepLogRE matches per-step files in a run log archive, for example
+// "tender/5_Run OpenCode.txt".
+var stepLogRE = regexp.MustCompile(`^(\d+)_(.+)\.txt$`)
+
+// logTimestampRE matches the timestamp GitHub prefixes to every log line.
+var logTimestampRE = regexp.MustCompile(`(?m)^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z `)
+
+// RunLog is the log of one step of a tender run.
+type RunLog struct {
+	RunID int64
+	Step  string
+	Text  string
+}
+
+// FetchRunLog downloads the logs of a tender run and returns one step's
+// output with timestamps and ANSI colours removed. runID 0 selects the latest
+// run; step is a generated step name, a unique part of one, or an alias such
+// as "opencode".
+func FetchRunLog(root, name string, runID int64, step string) (RunLog, error) {
+	tenders, err := LoadTenders(root)
+	if err != nil {
+		return RunLog{}, err
+	}
+	idx := findTenderIndex(tenders, name)
+	if idx < 0 {
+		return RunLog{}, fmt.Errorf("tender %q not found", name)
+	}
+	t := tenders[idx]
+
+	client, err := newGitHubClient(root)
+	if err != nil {
+		return RunLog{}, err
+	}
+	if runID == 0 {
+		runs, err := client.ListWorkflowRuns(t.WorkflowFile, 1, "")
+		if err != nil {
+			return RunLog{}, fmt.Errorf("%s: %w", t.WorkflowFile, err)
+		}
+		if len(runs) == 0 {
+			return RunLog{}, fmt.Errorf("no runs found for tender %q", t.Name)
+		}
+		runID = runs[0].ID
+	}
+
+	archive, err := client.DownloadRunLogs(runID)
+	if err != nil {
+		return RunLog{}, err
+	}
+	stepName, text, err := extractStepLog(archive, step)
+	if err != nil {
+		return RunLog{}, fmt.Errorf("run %d: %w", runID, err)
+	}
+	return RunLog{RunID: runID, Step: stepName, Text: cleanLog(text)}, nil
+}
+
+type stepLogFile struct {
+	order int
+	name  string
+	file  *zip.File
+}
+
+// extractStepLog finds the requested step in a run log archive.
+func extractStepLog(archive []byte, step string) (string, string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid log archive: %w", err)
+	}
+
+	var steps []stepLogFile
+	for _, f := range zr.File {
+		dir, base := path.Split(f.Name)
+		if dir == "" {
+			continue // whole-job logs live at the top level
+		}
+		m := stepLogRE.FindStringSubmatch(base)
+		if m == nil {
+			continue
+		}
+		order, _ := strconv.Atoi(m[1])
+		steps = append(steps, stepLogFile{order: order, name: m[2], file: f})
+	}
+	if len(steps) == 0 {
+		return "", "", fmt.Errorf("log archive has no per-step logs")
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i].order < steps[j].order })
+
+	match, err := matchLogStep(steps, step)
+	if err != nil {
+		return "", "", err
+	}
+	rc, err := match.file.Open()
+	if err != nil {
+		return "", "", err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return "", "", err
+	}
+	return match.name, string(data), nil
+}
+
+func matchLogStep(steps []stepLogFile, step string) (stepLogFile, error) {
+	want := strings.TrimSpace(step)
+	if want == "" {
+		want = DefaultLogStep
+	}
+	if alias, ok := logStepAliases[strings.ToLower(want)]; ok {
+		want = alias
+	}
+	for _, s := range steps {
+		if strings.EqualFold(s.name, want) {
+			return s, nil
+		}
+	}
+	var partial []stepLogFile
+	for _, s := range steps {
+		if strings.Contains(strings.ToLower(s.name), strings.ToLower(want)) {
+			partial = append(partial, s)
+		}
+	}
+	if len(partial) == 1 {
+		return partial[0], nil
+	}
+
+	names := make([]string, 0, len(steps))
+	for _, s := range steps {
+		names = append(names, s.name)
+	}
+	if len(partial) > 1 {
+		return stepLogFile{}, fmt.Errorf("step %q is ambiguous; steps: %s", step, strings.Join(names, ", "))
+	}
+	return stepLogFile{}, fmt.Errorf("step %q not found; steps: %s", step, strings.Join(names, ", "))
+}
+
+func cleanLog(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimPrefix(text, "\ufeff")
+	text = logTimestampRE.ReplaceAllString(text, "")
+	return ansiRE.ReplaceAllString(text, "")
+}
@@ -0,0 +1,109 @@
+package tender
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// logs.go tests
+
+func buildLogArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip create %s: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("zip write %s: %v", name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip close: %v", err)
+	}
+	return buf.String()
+}
+
+func TestFetchRunLog(t *testing.T) {
+	setup := func(t *testing.T) string {
+		t.Helper()
+		root := t.TempDir()
+		if err := EnsureWorkflowDir(root); err != nil {
+			t.Fatalf("failed to create workflow dir: %v", err)
+		}
+		if _, err := SaveNewTender(root, Tender{Name: "nightly", Agent: "Build", Manual: true}); err != nil {
+			t.Fatalf("SaveNewTender: %v", err)
+		}
+		return root
+	}
+	archive := buildLogArchive(t, map[string]string{
+		"0_tender.txt":                  "whole job log\n",
+		"tender/1_Set up job.txt":       "2026-10-16T09:00:00.1234567Z setting up\n",
+		"tender/3_Install OpenCode.txt": "2026-10-16T09:00:01.0000000Z installing\n",
+		"tender/5_Run OpenCode.txt":     "\ufeff2026-10-16T09:00:02.0000000Z \x1b[1mediting\x1b[0m files\r\n2026-10-16T09:00:03.0000000Z done\r\n",
+	})
+
+	t.Run("returns the OpenCode step of the latest run", func(t *testing.T) {
+		root := setup(t)
+		newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/workflows/nightly.yml/runs": `{"workflow_runs": [{"id": 77, "status": "completed"}]}`,
+			"/repos/acme/widgets/actions/runs/77/logs":               archive,
+		})
+
+		log, err := FetchRunLog(root, "nightly", 0, "")
+		if err != nil {
+			t.Fatalf("FetchRunLog returned error: %v", err)
+		}
+		if log.RunID != 77 || log.Step != "Run OpenCode" {
+			t.Fatalf("unexpected log metadata: %+v", log)
+		}
+		if want := "editing files\ndone\n"; log.Text != want {
+			t.Fatalf("unexpected log text: %q, want %q", log.Text, want)
+		}
+	})
+
+	t.Run("selects a run and step explicitly", func(t *testing.T) {
+		root := setup(t)
+		newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/runs/12/logs": archive,
+		})
+
+		log, err := FetchRunLog(root, "nightly", 12, "install")
+		if err != nil {
+			t.Fatalf("FetchRunLog returned error: %v", err)
+		}
+		if log.Step != "Install OpenCode" || log.Text != "installing\n" {
+			t.Fatalf("unexpected log: %+v", log)
+		}
+	})
+
+	t.Run("lists steps when the requested one is missing or ambiguous", func(t *testing.T) {
+		root := setup(t)
+		newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/runs/12/logs": archive,
+		})
+
+		_, err := FetchRunLog(root, "nightly", 12, "deploy")
+		if err == nil || !strings.Contains(err.Error(), `step "deploy" not found; steps: Set up job, Install OpenCode, Run OpenCode`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = FetchRunLog(root, "nightly", 12, "Open")
+		if err == nil || !strings.Contains(err.Error(), "ambiguous") {
+			t.Fatalf("expected ambiguous step error, got %v", err)
+		}
+	})
+
+	t.Run("reports tenders without runs", func(t *testing.T) {
+		root := setup(t)
+		newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/workflows/nightly.yml/runs": `{"workflow_runs": []}`,
+		})
+		if _, err := FetchRunLog(root, "nightly", 0, ""); err == nil || !strings.Contains(err.Error(), "no runs found") {
+			t.Fatalf("expected no runs error, got %v", err)
+		}
+	})
+}
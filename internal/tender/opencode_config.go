@@ -0,0 +1,156 @@
+package tender
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const openCodeConfigFile = "opencode.json"
+
+// providerSecretNames maps OpenCode provider IDs to the environment variable
+// (and repository secret) their API key is read from. Providers not listed
+// here use <PROVIDER>_API_KEY.
+var providerSecretNames = map[string]string{
+	"anthropic":  "ANTHROPIC_API_KEY",
+	"openai":     "OPENAI_API_KEY",
+	"google":     "GOOGLE_GENERATIVE_AI_API_KEY",
+	"openrouter": "OPENROUTER_API_KEY",
+	"groq":       "GROQ_API_KEY",
+	"mistral":    "MISTRAL_API_KEY",
+	"deepseek":   "DEEPSEEK_API_KEY",
+	"xai":        "XAI_API_KEY",
+}
+
+// OpenCodeProvider is a provider declared under "provider" in opencode.json.
+type OpenCodeProvider struct {
+	Models map[string]json.RawMessage `json:"models"`
+}
+
+type openCodeConfig struct {
+	Provider map[string]OpenCodeProvider `json:"provider"`
+}
+
+// LoadOpenCodeProviders returns the providers declared in the repository's
+// opencode.json. A missing file yields no providers.
+func LoadOpenCodeProviders(root string) (map[string]OpenCodeProvider, error) {
+	data, err := os.ReadFile(filepath.Join(root, openCodeConfigFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var cfg openCodeConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", openCodeConfigFile, err)
+	}
+	return cfg.Provider, nil
+}
+
+// ValidateModelForRepo checks a provider/model reference against the providers
+// configured in opencode.json. When the file declares no providers only the
+// reference format is checked, since OpenCode's built-in providers need no
+// configuration.
+func ValidateModelForRepo(root, model string) error {
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return nil
+	}
+	if err := validateModel(model, ""); err != nil {
+		return err
+	}
+	providers, err := LoadOpenCodeProviders(root)
+	if err != nil {
+		return err
+	}
+	if len(providers) == 0 {
+		return nil
+	}
+
+	providerID, modelID := splitModel(model)
+	provider, ok := providers[providerID]
+	if !ok {
+		names := make([]string, 0, len(providers))
+		for name := range providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("provider %q is not configured in %s (configured: %s)", providerID, openCodeConfigFile, strings.Join(names, ", "))
+	}
+	if len(provider.Models) > 0 {
+		if _, ok := provider.Models[modelID]; !ok {
+			return fmt.Errorf("model %q is not configured for provider %q in %s", modelID, providerID, openCodeConfigFile)
+		}
+	}
+	return nil
+}
+
+// validateModel accepts OpenCode's provider/model reference form. A provider,
+// when given, must be the model's prefix.
+func validateModel(model, provider string) error {
+	model, provider = strings.TrimSpace(model), strings.TrimSpace(provider)
+	if model == "" {
+		if provider != "" {
+			return fmt.Errorf("provider %q needs a model", provider)
+		}
+		return nil
+	}
+	providerID, modelID := splitModel(model)
+	if providerID == "" || modelID == "" {
+		return fmt.Errorf("model must be in provider/model form, got %q", model)
+	}
+	if strings.ContainsAny(model, " \t\r\n\"'`$\\") {
+		return fmt.Errorf("model %q contains invalid characters", model)
+	}
+	if provider != "" && provider != providerID {
+		return fmt.Errorf("provider %q does not match model %q", provider, model)
+	}
+	return nil
+}
+
+func splitModel(model string) (provider string, id string) {
+	model = strings.TrimSpace(model)
+	i := strings.Index(model, "/")
+	if i < 0 {
+		return "", ""
+	}
+	return model[:i], model[i+1:]
+}
+
+// tenderProvider returns a tender's provider, read from its model when the
+// Provider field is unset.
+func tenderProvider(t Tender) string {
+	if provider := strings.TrimSpace(t.Provider); provider != "" {
+		return provider
+	}
+	return ModelProvider(t.Model)
+}
+
+// ModelProvider returns the provider ID of a provider/model reference.
+func ModelProvider(model string) string {
+	provider, _ := splitModel(model)
+	return provider
+}
+
+// providerSecretName returns the secret tender wires into the run step for a
+// provider.
+func providerSecretName(provider string) string {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	if name, ok := providerSecretNames[provider]; ok {
+		return name
+	}
+	var b strings.Builder
+	for _, r := range strings.ToUpper(provider) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String() + "_API_KEY"
+}
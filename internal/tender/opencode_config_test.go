@@ -0,0 +1,80 @@
+package tender
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// opencode_config.go tests
+
+func TestValidateModelForRepo(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		t.Helper()
+		root := t.TempDir()
+		if err := os.WriteFile(filepath.Join(root, "opencode.json"), []byte(content), 0o644); err != nil {
+			t.Fatalf("write opencode.json: %v", err)
+		}
+		return root
+	}
+	const config = `{
+  "provider": {
+    "anthropic": {},
+    "groq": {"models": {"llama-3.1-8b-instant": {}}}
+  }
+}`
+
+	t.Run("accepts any provider when opencode.json declares none", func(t *testing.T) {
+		if err := ValidateModelForRepo(t.TempDir(), "openai/gpt-4.1-mini"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("accepts configured providers and models", func(t *testing.T) {
+		root := writeConfig(t, config)
+		for _, model := range []string{"anthropic/claude-sonnet-4", "groq/llama-3.1-8b-instant", ""} {
+			if err := ValidateModelForRepo(root, model); err != nil {
+				t.Fatalf("ValidateModelForRepo(%q) error = %v", model, err)
+			}
+		}
+	})
+
+	t.Run("rejects unknown providers and models", func(t *testing.T) {
+		root := writeConfig(t, config)
+		cases := map[string]string{
+			"openai/gpt-4.1":      `provider "openai" is not configured in opencode.json (configured: anthropic, groq)`,
+			"groq/mixtral-8x7b":   `model "mixtral-8x7b" is not configured for provider "groq"`,
+			"claude-sonnet-4":     "model must be in provider/model form",
+			"anthropic/bad model": "contains invalid characters",
+		}
+		for model, want := range cases {
+			err := ValidateModelForRepo(root, model)
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("ValidateModelForRepo(%q) error = %v, want %q", model, err, want)
+			}
+		}
+	})
+
+	t.Run("reports unreadable config", func(t *testing.T) {
+		root := writeConfig(t, "{not json")
+		if err := ValidateModelForRepo(root, "anthropic/claude-sonnet-4"); err == nil {
+			t.Fatal("expected error for invalid opencode.json")
+		}
+	})
+}
+
+func TestProviderSecretName(t *testing.T) {
+	cases := map[string]string{
+		"anthropic":    "ANTHROPIC_API_KEY",
+		"google":       "GOOGLE_GENERATIVE_AI_API_KEY",
+		"groq":         "GROQ_API_KEY",
+		"my-gateway":   "MY_GATEWAY_API_KEY",
+		" OpenRouter ": "OPENROUTER_API_KEY",
+	}
+	for provider, want := range cases {
+		if got := providerSecretName(provider); got != want {
+			t.Fatalf("providerSecretName(%q) = %q, want %q", provider, got, want)
+		}
+	}
+}
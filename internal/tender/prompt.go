@@ -0,0 +1,206 @@
+package tender
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PromptDir is where prompt files conventionally live, one per tender.
+const PromptDir = ".tender/prompts"
+
+// promptPreviewLines is how many lines of a prompt the TUI detail screen
+// shows.
+const promptPreviewLines = 3
+
+// promptPlaceholder is a run context value generated workflows substitute for
+// {{Name}} in a prompt before `opencode run`. Shell computes the value in the
+// Run OpenCode step.
+type promptPlaceholder struct {
+	Name  string
+	Help  string
+	Shell string
+}
+
+// promptPlaceholders are the placeholders prompts may use, in the order the
+// workflow expands them.
+var promptPlaceholders = []promptPlaceholder{
+	{Name: "sha", Help: "commit that triggered the run", Shell: `"$GITHUB_SHA"`},
+	{Name: "changed_files", Help: "files changed by the triggering push, or by the triggering commit", Shell: `"$CHANGED_FILES"`},
+	{Name: "date", Help: "run date, YYYY-MM-DD in UTC", Shell: `"$(date -u +%Y-%m-%d)"`},
+	{Name: "previous_commit", Help: "latest commit this tender made, or none", Shell: `"${PREVIOUS_COMMIT:-none}"`},
+	{Name: "actor", Help: "user who triggered the run", Shell: `"$GITHUB_ACTOR"`},
+}
+
+// inputPlaceholderPrefix starts the placeholder of a declared dispatch input:
+// {{input.<name>}}.
+const inputPlaceholderPrefix = "input."
+
+var placeholderRE = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+
+// validatePromptPlaceholders rejects {{...}} placeholders t's workflow would
+// leave unexpanded: event placeholders need an event trigger and input
+// placeholders a declared input.
+func validatePromptPlaceholders(prompt string, t Tender) error {
+	for _, m := range placeholderRE.FindAllStringSubmatch(prompt, -1) {
+		if !isPromptPlaceholder(m[1], t) {
+			var names []string
+			for _, p := range tenderPlaceholders(t) {
+				names = append(names, "{{"+p.Name+"}}")
+			}
+			for _, in := range t.Inputs {
+				names = append(names, "{{"+inputPlaceholderPrefix+in.Name+"}}")
+			}
+			return fmt.Errorf("unknown placeholder %s in prompt; use one of %s", m[0], strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+func isPromptPlaceholder(name string, t Tender) bool {
+	for _, p := range tenderPlaceholders(t) {
+		if p.Name == name {
+			return true
+		}
+	}
+	if strings.HasPrefix(name, inputPlaceholderPrefix) {
+		_, ok := findInput(t.Inputs, strings.TrimPrefix(name, inputPlaceholderPrefix))
+		return ok
+	}
+	return false
+}
+
+// tenderPlaceholders are the run context placeholders t's workflow expands.
+func tenderPlaceholders(t Tender) []promptPlaceholder {
+	if !hasEventTriggers(t) {
+		return promptPlaceholders
+	}
+	return append(append([]promptPlaceholder(nil), promptPlaceholders...), eventPlaceholders...)
+}
+
+// usesPromptTemplate reports whether a tender's workflow expands placeholders.
+// Prompt files may gain placeholders without the workflow being saved again,
+// so they always do.
+func usesPromptTemplate(t Tender) bool {
+	return strings.TrimSpace(t.PromptFile) != "" || placeholderRE.MatchString(t.Prompt)
+}
+
+// promptTemplateLines are the Run OpenCode step lines that compute t's
+// placeholder values and substitute them, and its inputs, into RUN_PROMPT.
+func promptTemplateLines(t Tender) []string {
+	lines := []string{
+		"    PUSH_BEFORE=\"${{ github.event.before || '' }}\"",
+		"    if [ -n \"$PUSH_BEFORE\" ] && git cat-file -e \"${PUSH_BEFORE}^{commit}\" 2>/dev/null; then",
+		"      CHANGED_FILES=\"$(git diff --name-only \"$PUSH_BEFORE\" \"$GITHUB_SHA\" | paste -sd ' ' -)\"",
+		"    else",
+		"      CHANGED_FILES=\"$(git show --pretty=format: --name-only \"$GITHUB_SHA\" | sed '/^$/d' | paste -sd ' ' -)\"",
+		"    fi",
+		"    PREVIOUS_COMMIT=\"$(git log -1 --format=%H -F --grep=\"" + fmt.Sprintf(commitMessageFormat, "${TENDER_NAME}") + "\" || true)\"",
+	}
+	if hasEventTriggers(t) {
+		lines = append(lines, eventTemplateLines()...)
+	}
+	for _, p := range tenderPlaceholders(t) {
+		lines = append(lines, "    RUN_PROMPT=\"${RUN_PROMPT//\\{\\{"+p.Name+"\\}\\}/"+p.Shell+"}\"")
+	}
+	for _, in := range t.Inputs {
+		lines = append(lines, "    RUN_PROMPT=\"${RUN_PROMPT//\\{\\{"+inputPlaceholderPrefix+in.Name+"\\}\\}/\"$"+inputEnvName(in)+"\"}\"")
+	}
+	return lines
+}
+
+// UsePromptFile points t at the prompt file rel, a path relative to the
+// repository root that the workflow reads at run time. A missing file is
+// created from text, or from t's inline prompt when text is empty; an existing
+// file is used as it is and replaces the inline prompt, so text must then be
+// empty.
+func UsePromptFile(root string, t Tender, rel, text string) (Tender, error) {
+	rel = strings.TrimSpace(rel)
+	if err := validatePromptFile(rel); err != nil {
+		return Tender{}, err
+	}
+	abs := filepath.Join(root, filepath.FromSlash(rel))
+	info, err := os.Stat(abs)
+	switch {
+	case err == nil && info.IsDir():
+		return Tender{}, fmt.Errorf("prompt file %s is a directory", rel)
+	case err == nil:
+		if strings.TrimSpace(text) != "" {
+			return Tender{}, fmt.Errorf("prompt file %s already exists; edit it instead of passing a prompt", rel)
+		}
+	case os.IsNotExist(err):
+		content := strings.TrimSpace(text)
+		if content == "" {
+			content = strings.TrimSpace(t.Prompt)
+		}
+		if content == "" {
+			return Tender{}, fmt.Errorf("prompt file %s does not exist", rel)
+		}
+		if err := validatePromptPlaceholders(content, t); err != nil {
+			return Tender{}, err
+		}
+		if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+			return Tender{}, err
+		}
+		if err := os.WriteFile(abs, []byte(content+"\n"), 0o644); err != nil {
+			return Tender{}, err
+		}
+	default:
+		return Tender{}, err
+	}
+	t.PromptFile, t.Prompt = rel, ""
+	return t, nil
+}
+
+// validatePromptFile accepts clean repository-relative paths made of
+// characters that are safe in the generated shell step.
+func validatePromptFile(rel string) error {
+	if rel == "" {
+		return nil
+	}
+	if strings.HasPrefix(rel, "/") || path.Clean(rel) != rel || rel == ".." || strings.HasPrefix(rel, "../") {
+		return fmt.Errorf("prompt file %q must be a clean path inside the repository, such as %s/nightly.md", rel, PromptDir)
+	}
+	for _, r := range rel {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && !strings.ContainsRune("._/-", r) {
+			return fmt.Errorf("prompt file %q may only contain letters, digits, '.', '_', '-' and '/'", rel)
+		}
+	}
+	return nil
+}
+
+// readPromptFile returns the trimmed prompt stored in a tender's prompt file
+// under dir, which is the repository root or a checkout of it.
+func readPromptFile(dir string, t Tender) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(strings.TrimSpace(t.PromptFile))))
+	if err != nil {
+		return "", fmt.Errorf("cannot read prompt file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// promptSummary says where a tender's prompt comes from, with the first
+// lines of the prompt as a preview.
+func promptSummary(root string, t Tender) (string, []string) {
+	text := strings.TrimSpace(t.Prompt)
+	summary := "inline"
+	if file := strings.TrimSpace(t.PromptFile); file != "" {
+		summary = file
+		content, err := readPromptFile(root, t)
+		if err != nil {
+			return file + " (missing)", nil
+		}
+		text = content
+	}
+	if text == "" {
+		return "default", []string{fmt.Sprintf(fallbackPromptFormat, strings.TrimSpace(t.Name))}
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) > promptPreviewLines {
+		lines = append(lines[:promptPreviewLines-1], fmt.Sprintf("... (%d more lines)", len(lines)-promptPreviewLines+1))
+	}
+	return summary, lines
+}
@@ -0,0 +1,144 @@
+package tender
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// prompt.go tests
+
+func TestUsePromptFile(t *testing.T) {
+	base := Tender{Name: "docs", Agent: "Build", Manual: true}
+	read := func(t *testing.T, root, rel string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatalf("read prompt file: %v", err)
+		}
+		return string(data)
+	}
+
+	t.Run("creates a missing file from the given text", func(t *testing.T) {
+		root := t.TempDir()
+		got, err := UsePromptFile(root, base, ".tender/prompts/docs.md", "  Refresh the docs.  ")
+		if err != nil {
+			t.Fatalf("UsePromptFile returned error: %v", err)
+		}
+		if got.PromptFile != ".tender/prompts/docs.md" || got.Prompt != "" {
+			t.Fatalf("unexpected tender: %+v", got)
+		}
+		if content := read(t, root, got.PromptFile); content != "Refresh the docs.\n" {
+			t.Fatalf("unexpected prompt file content %q", content)
+		}
+	})
+
+	t.Run("moves an inline prompt into a missing file", func(t *testing.T) {
+		root := t.TempDir()
+		inline := base
+		inline.Prompt = "Keep the changelog tidy."
+		got, err := UsePromptFile(root, inline, ".tender/prompts/docs.md", "")
+		if err != nil {
+			t.Fatalf("UsePromptFile returned error: %v", err)
+		}
+		if got.Prompt != "" || read(t, root, got.PromptFile) != "Keep the changelog tidy.\n" {
+			t.Fatalf("inline prompt was not moved: %+v", got)
+		}
+	})
+
+	t.Run("uses an existing file as it is", func(t *testing.T) {
+		root := t.TempDir()
+		if _, err := UsePromptFile(root, base, "prompts/docs.md", "Original."); err != nil {
+			t.Fatalf("UsePromptFile returned error: %v", err)
+		}
+		inline := base
+		inline.Prompt = "stale inline prompt"
+		got, err := UsePromptFile(root, inline, "prompts/docs.md", "")
+		if err != nil {
+			t.Fatalf("UsePromptFile returned error: %v", err)
+		}
+		if got.Prompt != "" || read(t, root, "prompts/docs.md") != "Original.\n" {
+			t.Fatalf("existing prompt file was not kept: %+v", got)
+		}
+		if _, err := UsePromptFile(root, base, "prompts/docs.md", "Replacement."); err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Fatalf("expected already exists error, got %v", err)
+		}
+	})
+
+	t.Run("rejects missing files without text and paths outside the repository", func(t *testing.T) {
+		root := t.TempDir()
+		if _, err := UsePromptFile(root, base, ".tender/prompts/docs.md", ""); err == nil || !strings.Contains(err.Error(), "does not exist") {
+			t.Fatalf("expected does not exist error, got %v", err)
+		}
+		for _, rel := range []string{"/etc/passwd", "../docs.md", "prompts/../../docs.md", "./docs.md", "my prompt.md"} {
+			if _, err := UsePromptFile(root, base, rel, "text"); err == nil {
+				t.Fatalf("expected %q to be rejected", rel)
+			}
+		}
+	})
+}
+
+func TestPromptSummary(t *testing.T) {
+	root := t.TempDir()
+
+	t.Run("previews the first lines of a prompt file", func(t *testing.T) {
+		tender, err := UsePromptFile(root, Tender{Name: "docs", Agent: "Build"}, ".tender/prompts/docs.md", "one\ntwo\nthree\nfour")
+		if err != nil {
+			t.Fatalf("UsePromptFile returned error: %v", err)
+		}
+		source, preview := promptSummary(root, tender)
+		if source != ".tender/prompts/docs.md" || strings.Join(preview, "|") != "one|two|... (2 more lines)" {
+			t.Fatalf("promptSummary = %q, %q", source, preview)
+		}
+	})
+
+	t.Run("describes inline, default and missing prompts", func(t *testing.T) {
+		if source, preview := promptSummary(root, Tender{Name: "lint", Prompt: "Fix lint."}); source != "inline" || strings.Join(preview, "|") != "Fix lint." {
+			t.Fatalf("inline prompt = %q, %q", source, preview)
+		}
+		if source, preview := promptSummary(root, Tender{Name: "lint"}); source != "default" || strings.Join(preview, "|") != "Run the tender task 'lint' for this repository." {
+			t.Fatalf("default prompt = %q, %q", source, preview)
+		}
+		if source, preview := promptSummary(root, Tender{Name: "lint", PromptFile: "gone.md"}); source != "gone.md (missing)" || preview != nil {
+			t.Fatalf("missing prompt file = %q, %q", source, preview)
+		}
+	})
+}
+
+func TestValidatePromptPlaceholders(t *testing.T) {
+	t.Run("accepts known placeholders", func(t *testing.T) {
+		prompt := "Review {{changed_files}} from {{sha}} on {{date}} for {{actor}}; last run {{previous_commit}}. Braces { } stay."
+		if err := validatePromptPlaceholders(prompt, Tender{}); err != nil {
+			t.Fatalf("validatePromptPlaceholders returned error: %v", err)
+		}
+	})
+
+	t.Run("rejects unknown placeholders", func(t *testing.T) {
+		for _, prompt := range []string{"{{branch}}", "{{ sha }}", "at ${{ github.sha }}"} {
+			if err := validatePromptPlaceholders(prompt, Tender{}); err == nil || !strings.Contains(err.Error(), "unknown placeholder") {
+				t.Fatalf("validatePromptPlaceholders(%q) error = %v", prompt, err)
+			}
+		}
+	})
+
+	t.Run("checks prompt files when saving", func(t *testing.T) {
+		root := t.TempDir()
+		if _, err := UsePromptFile(root, Tender{Name: "docs", Agent: "Build"}, "docs.md", "{{commit}}"); err == nil || !strings.Contains(err.Error(), "{{commit}}") {
+			t.Fatalf("expected UsePromptFile to reject the placeholder, got %v", err)
+		}
+		tender, err := UsePromptFile(root, Tender{Name: "docs", Agent: "Build", Manual: true}, "docs.md", "Changed: {{changed_files}}")
+		if err != nil {
+			t.Fatalf("UsePromptFile returned error: %v", err)
+		}
+		if _, err := SaveNewTender(root, tender); err != nil {
+			t.Fatalf("SaveNewTender returned error: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, "docs.md"), []byte("{{commit}}\n"), 0o644); err != nil {
+			t.Fatalf("write prompt file: %v", err)
+		}
+		if err := SaveTender(root, tender); err == nil || !strings.Contains(err.Error(), "docs.md: unknown placeholder {{commit}}") {
+			t.Fatalf("expected SaveTender to reject the prompt file, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,290 @@
+package tender
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// TenderCommit is a commit a tender run made, found by its commit message.
+type TenderCommit struct {
+	SHA     string
+	Date    time.Time // committer date, when the run pushed it
+	Subject string
+}
+
+// RevertOptions picks which of a tender's commits PlanRevert selects. At most
+// one of them may be set; none means the latest commit.
+type RevertOptions struct {
+	Last  int       // the newest Last commits
+	Since time.Time // commits made at or after Since
+	RunID int64     // commits made during this GitHub Actions run
+}
+
+// RevertConflict is a commit that does not revert cleanly on top of HEAD.
+type RevertConflict struct {
+	Commit TenderCommit
+	Files  []string
+}
+
+func (c *RevertConflict) Error() string {
+	return fmt.Sprintf("reverting %s (%s) conflicts in %s", shortCommit(c.Commit.SHA), c.Commit.Date.UTC().Format("2006-01-02 15:04"), strings.Join(c.Files, ", "))
+}
+
+// RevertPlan is what ApplyRevert does: the commits to revert, newest
+// first, and the combined change reverting them makes.
+type RevertPlan struct {
+	Name    string
+	Head    string
+	Commits []TenderCommit
+	Stat    string
+	Diff    string
+}
+
+// revertedCommitRE finds the commits earlier reverts undid, from the trailer
+// `git revert` writes.
+var revertedCommitRE = regexp.MustCompile(`This reverts commit ([0-9a-f]{40})`)
+
+// tenderCommits lists the commits reachable from HEAD whose subject is the
+// message tender's workflow commits with for name, newest first. Commits that
+// were already reverted are left out.
+func tenderCommits(root, name string) ([]TenderCommit, error) {
+	subject := fmt.Sprintf(commitMessageFormat, strings.TrimSpace(name))
+	out, err := runGit(root, "log", "--format=%H%x09%cI%x09%s", "-F", "--grep="+subject, "HEAD", "--")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read git history in %s: %w", root, err)
+	}
+	reverted, err := revertedCommits(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []TenderCommit
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 || fields[2] != subject || reverted[fields[0]] {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("commit %s has an unreadable date %q", shortCommit(fields[0]), fields[1])
+		}
+		commits = append(commits, TenderCommit{SHA: fields[0], Date: date, Subject: fields[2]})
+	}
+	return commits, nil
+}
+
+// revertedCommits returns the commits reachable from HEAD that a later commit
+// reverted.
+func revertedCommits(root string) (map[string]bool, error) {
+	bodies, err := runGit(root, "log", "--format=%B", "-F", "--grep=This reverts commit", "HEAD", "--")
+	if err != nil {
+		return nil, fmt.Errorf("cannot read git history in %s: %w", root, err)
+	}
+	reverted := map[string]bool{}
+	for _, m := range revertedCommitRE.FindAllStringSubmatch(bodies, -1) {
+		reverted[m[1]] = true
+	}
+	return reverted, nil
+}
+
+// selectRevertCommits applies opts to a tender's commits, newest first.
+// runStart and runEnd bound opts.RunID.
+func selectRevertCommits(commits []TenderCommit, opts RevertOptions, runStart, runEnd time.Time) []TenderCommit {
+	var selected []TenderCommit
+	switch {
+	case opts.RunID != 0:
+		for _, c := range commits {
+			if !c.Date.Before(runStart.Truncate(time.Second)) && !c.Date.After(runEnd) {
+				selected = append(selected, c)
+			}
+		}
+	case !opts.Since.IsZero():
+		for _, c := range commits {
+			if !c.Date.Before(opts.Since) {
+				selected = append(selected, c)
+			}
+		}
+	default:
+		last := opts.Last
+		if last <= 0 {
+			last = 1
+		}
+		if last > len(commits) {
+			last = len(commits)
+		}
+		selected = commits[:last]
+	}
+	return selected
+}
+
+// PlanRevert finds the commits of tender name that opts selects and works out
+// their combined revert in a temporary worktree of HEAD, so a conflict is
+// found before the repository is touched. Conflicts are returned as a
+// *RevertConflict. name does not have to be a current tender, so commits of
+// removed tenders can still be reverted.
+func PlanRevert(root, name string, opts RevertOptions) (RevertPlan, error) {
+	set := 0
+	for _, on := range []bool{opts.Last != 0, !opts.Since.IsZero(), opts.RunID != 0} {
+		if on {
+			set++
+		}
+	}
+	if set > 1 {
+		return RevertPlan{}, fmt.Errorf("use only one of --last, --since and --run")
+	}
+	if opts.Last < 0 {
+		return RevertPlan{}, fmt.Errorf("--last must be a positive number")
+	}
+	name = strings.TrimSpace(name)
+	viaPR := false
+	if tenders, err := LoadTenders(root); err == nil {
+		if idx := findTenderIndex(tenders, name); idx >= 0 {
+			name = tenders[idx].Name
+			viaPR = normalizeDelivery(tenders[idx].Delivery) == DeliveryPR
+		}
+	}
+
+	head, err := runGit(root, "rev-parse", "HEAD")
+	if err != nil {
+		return RevertPlan{}, fmt.Errorf("cannot revert: %s is not a git repository with commits", root)
+	}
+	if err := requireCleanTree(root); err != nil {
+		return RevertPlan{}, err
+	}
+	commits, err := tenderCommits(root, name)
+	if err != nil {
+		return RevertPlan{}, err
+	}
+	var runStart, runEnd time.Time
+	if opts.RunID != 0 {
+		client, err := newGitHubClient(root)
+		if err != nil {
+			return RevertPlan{}, err
+		}
+		run, err := client.GetRun(opts.RunID)
+		if err != nil {
+			return RevertPlan{}, fmt.Errorf("run %d: %w", opts.RunID, err)
+		}
+		runStart, runEnd = runStartedAt(run), run.UpdatedAt
+	}
+	plan := RevertPlan{Name: name, Head: strings.TrimSpace(head), Commits: selectRevertCommits(commits, opts, runStart, runEnd)}
+	if len(plan.Commits) == 0 {
+		switch {
+		case opts.RunID != 0 && viaPR:
+			// The run only pushed a pull request branch. Its commits reach the
+			// target branch when the PR is merged: a rebase merge gives them a
+			// new date and a squash merge a new message.
+			return RevertPlan{}, fmt.Errorf("no %q commits in local history were made during run %d; %s delivers through pull requests, and rebase or squash merges change the date or message of its commits: use --since or --last, or git revert the squashed commit", fmt.Sprintf(commitMessageFormat, name), opts.RunID, name)
+		case opts.RunID != 0:
+			return RevertPlan{}, fmt.Errorf("no %q commits in local history were made during run %d; pull the target branch first", fmt.Sprintf(commitMessageFormat, name), opts.RunID)
+		case !opts.Since.IsZero():
+			return RevertPlan{}, fmt.Errorf("no %q commits in local history since %s", fmt.Sprintf(commitMessageFormat, name), opts.Since.Format("2006-01-02 15:04"))
+		}
+		return RevertPlan{}, fmt.Errorf("no %q commits to revert in local history", fmt.Sprintf(commitMessageFormat, name))
+	}
+
+	parent, err := os.MkdirTemp("", "tender-revert-")
+	if err != nil {
+		return RevertPlan{}, err
+	}
+	defer os.RemoveAll(parent)
+	worktree := filepath.Join(parent, Slugify(name))
+	if _, err := runGit(root, "worktree", "add", "--detach", worktree, plan.Head); err != nil {
+		return RevertPlan{}, fmt.Errorf("git worktree add failed: %w", err)
+	}
+	defer func() {
+		_, _ = runGit(root, "worktree", "remove", "--force", worktree)
+	}()
+	for _, c := range plan.Commits {
+		if _, err := runGit(worktree, "revert", "--no-commit", c.SHA); err != nil {
+			return RevertPlan{}, &RevertConflict{Commit: c, Files: conflictedFiles(worktree)}
+		}
+	}
+	if plan.Stat, err = runGit(worktree, "diff", "--cached", "--stat", plan.Head); err != nil {
+		return RevertPlan{}, err
+	}
+	if plan.Diff, err = runGit(worktree, "diff", "--cached", plan.Head); err != nil {
+		return RevertPlan{}, err
+	}
+	return plan, nil
+}
+
+// ApplyRevert creates one revert commit per planned commit on the current
+// branch, newest first, and returns them. Nothing is pushed. It refuses to run
+// with uncommitted changes or when HEAD moved since the plan was made, and
+// leaves the branch as it was when a revert conflicts.
+func ApplyRevert(root string, plan RevertPlan) ([]string, error) {
+	head, err := runGit(root, "rev-parse", "HEAD")
+	if err != nil || strings.TrimSpace(head) != plan.Head {
+		return nil, fmt.Errorf("HEAD moved since the revert was planned; run tender revert again")
+	}
+	if err := requireCleanTree(root); err != nil {
+		return nil, err
+	}
+	args := []string{"revert", "--no-edit"}
+	for _, c := range plan.Commits {
+		args = append(args, c.SHA)
+	}
+	if _, err := runGit(root, args...); err != nil {
+		conflict := &RevertConflict{Files: conflictedFiles(root)}
+		if sha, err := runGit(root, "rev-parse", "REVERT_HEAD"); err == nil {
+			conflict.Commit = TenderCommit{SHA: strings.TrimSpace(sha)}
+			for _, c := range plan.Commits {
+				if c.SHA == conflict.Commit.SHA {
+					conflict.Commit = c
+				}
+			}
+		}
+		_, _ = runGit(root, "revert", "--abort")
+		if now, _ := runGit(root, "rev-parse", "HEAD"); strings.TrimSpace(now) != plan.Head {
+			_, _ = runGit(root, "reset", "--hard", plan.Head)
+		}
+		if len(conflict.Files) == 0 {
+			return nil, fmt.Errorf("git revert failed: %w", err)
+		}
+		return nil, conflict
+	}
+	out, err := runGit(root, "rev-list", "--reverse", plan.Head+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// requireCleanTree refuses to revert over uncommitted changes to tracked
+// files, which a failed revert would have to discard.
+func requireCleanTree(root string) error {
+	if status, err := runGit(root, "status", "--porcelain", "--untracked-files=no"); err != nil || strings.TrimSpace(status) != "" {
+		return fmt.Errorf("cannot revert with uncommitted changes; commit or stash them first")
+	}
+	return nil
+}
+
+func conflictedFiles(dir string) []string {
+	out, _ := runGit(dir, "diff", "--name-only", "--diff-filter=U")
+	return strings.Fields(out)
+}
+
+func shortCommit(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+// ParseRevertSince reads a --since value: a date (YYYY-MM-DD, local time) or
+// an RFC 3339 timestamp.
+func ParseRevertSince(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if t, err := time.ParseInLocation("2006-01-02", raw, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("--since must be a date (YYYY-MM-DD) or an RFC 3339 time, got %q", raw)
+}
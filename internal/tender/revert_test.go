@@ -0,0 +1,196 @@
+package tender
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// revert.go tests
+
+func TestRevertTender(t *testing.T) {
+	// setup makes a repository with one commit.
+	setup := func(t *testing.T) string {
+		t.Helper()
+		dir := newTestRepo(t)
+		commitAt(t, dir, "2026-10-01T08:00:00Z", "initial", map[string]string{"README.md": "hello\n", "guide.md": "guide\n"})
+		return dir
+	}
+	read := func(t *testing.T, dir, rel string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	t.Run("reverts the latest commit by default", func(t *testing.T) {
+		dir := setup(t)
+		first := commitAt(t, dir, "2026-10-02T09:00:00Z", "tender(docs): autonomous update", map[string]string{"README.md": "hello docs\n"})
+		commitAt(t, dir, "2026-10-02T10:00:00Z", "tender(docs-extra): autonomous update", map[string]string{"extra.md": "x\n"})
+		latest := commitAt(t, dir, "2026-10-03T09:00:00Z", "tender(docs): autonomous update", map[string]string{"guide.md": "guide docs\n"})
+
+		plan, err := PlanRevert(dir, "docs", RevertOptions{})
+		if err != nil {
+			t.Fatalf("PlanRevert returned error: %v", err)
+		}
+		if len(plan.Commits) != 1 || plan.Commits[0].SHA != latest {
+			t.Fatalf("expected only %s, got %+v", latest, plan.Commits)
+		}
+		if !strings.Contains(plan.Diff, "-guide docs\n+guide\n") || strings.Contains(plan.Diff, "README.md") {
+			t.Fatalf("unexpected combined diff:\n%s", plan.Diff)
+		}
+		reverts, err := ApplyRevert(dir, plan)
+		if err != nil || len(reverts) != 1 {
+			t.Fatalf("ApplyRevert = %v, %v", reverts, err)
+		}
+		if got := read(t, dir, "guide.md"); got != "guide\n" {
+			t.Fatalf("guide.md = %q", got)
+		}
+
+		// The reverted commit is skipped the next time.
+		plan, err = PlanRevert(dir, "docs", RevertOptions{})
+		if err != nil || len(plan.Commits) != 1 || plan.Commits[0].SHA != first {
+			t.Fatalf("expected %s next, got %+v, %v", first, plan.Commits, err)
+		}
+	})
+
+	t.Run("selects commits with --last and --since", func(t *testing.T) {
+		dir := setup(t)
+		commitAt(t, dir, "2026-10-02T09:00:00Z", "tender(docs): autonomous update", map[string]string{"README.md": "hello docs\n"})
+		commitAt(t, dir, "2026-10-03T09:00:00Z", "manual edit", map[string]string{"other.md": "human\n"})
+		commitAt(t, dir, "2026-10-04T09:00:00Z", "tender(docs): autonomous update", map[string]string{"guide.md": "guide docs\n"})
+
+		plan, err := PlanRevert(dir, "docs", RevertOptions{Last: 5})
+		if err != nil || len(plan.Commits) != 2 {
+			t.Fatalf("expected both commits, got %+v, %v", plan.Commits, err)
+		}
+		if !strings.Contains(plan.Stat, "README.md") || !strings.Contains(plan.Stat, "guide.md") || strings.Contains(plan.Stat, "other.md") {
+			t.Fatalf("unexpected combined stat:\n%s", plan.Stat)
+		}
+		since, err := ParseRevertSince("2026-10-03T00:00:00Z")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if plan, err = PlanRevert(dir, "docs", RevertOptions{Since: since}); err != nil || len(plan.Commits) != 1 {
+			t.Fatalf("expected one commit since %s, got %+v, %v", since, plan.Commits, err)
+		}
+		if _, err := ApplyRevert(dir, plan); err != nil {
+			t.Fatalf("ApplyRevert returned error: %v", err)
+		}
+		if read(t, dir, "README.md") != "hello docs\n" || read(t, dir, "guide.md") != "guide\n" {
+			t.Fatalf("expected only the newest commit reverted")
+		}
+		if _, err := PlanRevert(dir, "docs", RevertOptions{Last: 1, Since: since}); err == nil || !strings.Contains(err.Error(), "only one of") {
+			t.Fatalf("expected option error, got %v", err)
+		}
+		if _, err := PlanRevert(dir, "nightly", RevertOptions{}); err == nil || !strings.Contains(err.Error(), "no \"tender(nightly): autonomous update\" commits") {
+			t.Fatalf("expected no commits error, got %v", err)
+		}
+	})
+
+	t.Run("selects the commits made during a run", func(t *testing.T) {
+		dir := setup(t)
+		commitAt(t, dir, "2026-10-02T09:00:00Z", "tender(docs): autonomous update", map[string]string{"README.md": "hello docs\n"})
+		during := commitAt(t, dir, "2026-10-03T09:04:00Z", "tender(docs): autonomous update", map[string]string{"guide.md": "guide docs\n"})
+		newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/runs/42": `{"id": 42, "status": "completed", "run_started_at": "2026-10-03T09:00:00Z", "updated_at": "2026-10-03T09:05:00Z"}`,
+		})
+
+		plan, err := PlanRevert(dir, "docs", RevertOptions{RunID: 42})
+		if err != nil || len(plan.Commits) != 1 || plan.Commits[0].SHA != during {
+			t.Fatalf("expected %s, got %+v, %v", during, plan.Commits, err)
+		}
+		if _, err := PlanRevert(dir, "docs", RevertOptions{RunID: 7}); err == nil || !strings.Contains(err.Error(), "run 7") {
+			t.Fatalf("expected unknown run error, got %v", err)
+		}
+	})
+
+	t.Run("selects by the run window", func(t *testing.T) {
+		at := func(raw string) time.Time {
+			t.Helper()
+			ts, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return ts
+		}
+		commits := []TenderCommit{
+			{SHA: "after", Date: at("2026-10-03T09:06:00Z")},
+			{SHA: "end", Date: at("2026-10-03T09:05:00Z")},
+			{SHA: "during", Date: at("2026-10-03T09:04:00Z")},
+			{SHA: "start", Date: at("2026-10-03T09:00:00Z")},
+			{SHA: "before", Date: at("2026-10-03T08:59:59Z")},
+		}
+		// Commit dates have whole seconds, so the start is truncated.
+		got := selectRevertCommits(commits, RevertOptions{RunID: 42}, at("2026-10-03T09:00:00.500Z"), at("2026-10-03T09:05:00Z"))
+		var shas []string
+		for _, c := range got {
+			shas = append(shas, c.SHA)
+		}
+		if strings.Join(shas, ",") != "end,during,start" {
+			t.Fatalf("selected %v", shas)
+		}
+		if got := selectRevertCommits(commits, RevertOptions{RunID: 42}, at("2026-10-04T00:00:00Z"), at("2026-10-04T01:00:00Z")); len(got) != 0 {
+			t.Fatalf("expected no commits outside the run, got %+v", got)
+		}
+	})
+
+	t.Run("explains runs of pull request tenders", func(t *testing.T) {
+		dir := setup(t)
+		if _, err := SaveNewTender(dir, Tender{Name: "docs", Agent: "Build", Manual: true, Delivery: DeliveryPR}); err != nil {
+			t.Fatalf("SaveNewTender: %v", err)
+		}
+		commitAt(t, dir, "2026-10-03T10:30:00Z", "tender(docs): autonomous update", map[string]string{"README.md": "hello docs\n"})
+		newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/runs/42": `{"id": 42, "status": "completed", "run_started_at": "2026-10-03T09:00:00Z", "updated_at": "2026-10-03T09:05:00Z"}`,
+		})
+
+		_, err := PlanRevert(dir, "docs", RevertOptions{RunID: 42})
+		if err == nil || !containsAll(err.Error(), "during run 42", "docs delivers through pull requests", "use --since or --last") {
+			t.Fatalf("expected a pull request explanation, got %v", err)
+		}
+	})
+
+	t.Run("reports conflicts without touching the branch", func(t *testing.T) {
+		dir := setup(t)
+		tenderCommit := commitAt(t, dir, "2026-10-02T09:00:00Z", "tender(docs): autonomous update", map[string]string{"README.md": "hello docs\n"})
+		head := commitAt(t, dir, "2026-10-03T09:00:00Z", "manual edit", map[string]string{"README.md": "hello docs, edited\n"})
+
+		_, err := PlanRevert(dir, "docs", RevertOptions{})
+		var conflict *RevertConflict
+		if !errors.As(err, &conflict) {
+			t.Fatalf("expected a conflict, got %v", err)
+		}
+		if conflict.Commit.SHA != tenderCommit || strings.Join(conflict.Files, ",") != "README.md" {
+			t.Fatalf("unexpected conflict: %+v", conflict)
+		}
+		if !strings.Contains(conflict.Error(), "conflicts in README.md") {
+			t.Fatalf("unexpected conflict report: %v", conflict)
+		}
+		if testGit(t, dir, "rev-parse", "HEAD") != head || read(t, dir, "README.md") != "hello docs, edited\n" {
+			t.Fatalf("expected the branch to be untouched")
+		}
+	})
+
+	t.Run("refuses uncommitted changes and a moved HEAD", func(t *testing.T) {
+		dir := setup(t)
+		commitAt(t, dir, "2026-10-02T09:00:00Z", "tender(docs): autonomous update", map[string]string{"README.md": "hello docs\n"})
+		plan, err := PlanRevert(dir, "docs", RevertOptions{})
+		if err != nil {
+			t.Fatalf("PlanRevert returned error: %v", err)
+		}
+		writeTestFiles(t, dir, map[string]string{"guide.md": "wip\n"})
+		if _, err := ApplyRevert(dir, plan); err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+			t.Fatalf("expected dirty tree error, got %v", err)
+		}
+		commitAt(t, dir, "2026-10-03T09:00:00Z", "manual edit", nil)
+		if _, err := ApplyRevert(dir, plan); err == nil || !strings.Contains(err.Error(), "HEAD moved") {
+			t.Fatalf("expected moved HEAD error, got %v", err)
+		}
+	})
+}
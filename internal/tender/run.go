@@ -5,9 +5,16 @@ import (
 	"io"
 	"os/exec"
 	"strings"
+
+	"tender/internal/github"
 )
 
-func DispatchTenderNow(root string, tenderName string, prompt string, stdout io.Writer, stderr io.Writer) error {
+// DispatchTenderNow triggers a workflow_dispatch run of a tender through the
+// GitHub REST API on the repository's default branch. inputs are key=value
+// pairs checked against the tender's declared inputs before anything is sent.
+// Without API credentials it falls back to `gh workflow run` when the GitHub
+// CLI is installed.
+func DispatchTenderNow(root string, tenderName string, prompt string, inputs []string, stdout io.Writer, stderr io.Writer) error {
 	tenders, err := LoadTenders(root)
 	if err != nil {
 		return err
@@ -20,12 +27,23 @@ func DispatchTenderNow(root string, tenderName string, prompt string, stdout io.
 	if !t.Manual {
 		return fmt.Errorf("tender %q does not allow on-demand runs; enable workflow_dispatch to use 'tender run'", tenderName)
 	}
+	values, err := ResolveInputValues(t, inputs)
+	if err != nil {
+		return err
+	}
 
-	if _, err := exec.LookPath("gh"); err != nil {
-		return fmt.Errorf("GitHub CLI 'gh' is required to run a tender now")
+	client, err := newGitHubClient(root)
+	if err == nil && client.Token == "" {
+		err = fmt.Errorf("no GitHub credentials found")
+	}
+	if err == nil {
+		return dispatchWorkflow(client, t, prompt, values)
+	}
+	if _, lookErr := exec.LookPath("gh"); lookErr != nil {
+		return fmt.Errorf("cannot run a tender now: %v; set GH_TOKEN or GITHUB_TOKEN, or install the GitHub CLI 'gh'", err)
 	}
 
-	args := buildGHWorkflowRunArgs(t, prompt)
+	args := buildGHWorkflowRunArgs(t, prompt, values)
 	cmd := exec.Command("gh", args...)
 	cmd.Dir = root
 	cmd.Stdout = stdout
@@ -36,10 +54,38 @@ func DispatchTenderNow(root string, tenderName string, prompt string, stdout io.
 	return nil
 }
 
-func buildGHWorkflowRunArgs(t Tender, prompt string) []string {
+func dispatchWorkflow(client *github.Client, t Tender, prompt string, values map[string]string) error {
+	ref, err := client.DefaultBranch()
+	if err != nil {
+		return fmt.Errorf("workflow dispatch failed: %w", err)
+	}
+	if err := client.DispatchWorkflow(t.WorkflowFile, ref, dispatchInputs(prompt, values)); err != nil {
+		return fmt.Errorf("workflow dispatch failed: %w", err)
+	}
+	return nil
+}
+
+func dispatchInputs(prompt string, values map[string]string) map[string]string {
+	if strings.TrimSpace(prompt) == "" && len(values) == 0 {
+		return nil
+	}
+	inputs := make(map[string]string, len(values)+1)
+	for k, v := range values {
+		inputs[k] = v
+	}
+	if strings.TrimSpace(prompt) != "" {
+		inputs[promptInputName] = strings.TrimSpace(prompt)
+	}
+	return inputs
+}
+
+func buildGHWorkflowRunArgs(t Tender, prompt string, values map[string]string) []string {
 	args := []string{"workflow", "run", t.WorkflowFile}
 	if strings.TrimSpace(prompt) != "" {
 		args = append(args, "-f", "prompt="+strings.TrimSpace(prompt))
 	}
+	for _, kv := range sortedInputValues(values) {
+		args = append(args, "-f", kv)
+	}
 	return args
 }
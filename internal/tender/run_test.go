@@ -2,6 +2,10 @@ package tender
 
 import (
 	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -15,7 +19,7 @@ import (
 func TestBuildGHWorkflowRunArgs(t *testing.T) {
 	t.Run("builds args without prompt", func(t *testing.T) {
 		tender := Tender{WorkflowFile: "nightly.yml"}
-		got := buildGHWorkflowRunArgs(tender, "")
+		got := buildGHWorkflowRunArgs(tender, "", nil)
 		want := []string{"workflow", "run", "nightly.yml"}
 		if !reflect.DeepEqual(got, want) {
 			t.Fatalf("unexpected args: got=%v want=%v", got, want)
@@ -24,7 +28,7 @@ func TestBuildGHWorkflowRunArgs(t *testing.T) {
 
 	t.Run("builds args with prompt", func(t *testing.T) {
 		tender := Tender{WorkflowFile: "nightly.yml"}
-		got := buildGHWorkflowRunArgs(tender, "Fix tests")
+		got := buildGHWorkflowRunArgs(tender, "Fix tests", nil)
 		want := []string{"workflow", "run", "nightly.yml", "-f", "prompt=Fix tests"}
 		if !reflect.DeepEqual(got, want) {
 			t.Fatalf("unexpected args: got=%v want=%v", got, want)
@@ -33,7 +37,7 @@ func TestBuildGHWorkflowRunArgs(t *testing.T) {
 
 	t.Run("trims whitespace from prompt", func(t *testing.T) {
 		tender := Tender{WorkflowFile: "test.yml"}
-		got := buildGHWorkflowRunArgs(tender, "  spaced prompt  ")
+		got := buildGHWorkflowRunArgs(tender, "  spaced prompt  ", nil)
 		want := []string{"workflow", "run", "test.yml", "-f", "prompt=spaced prompt"}
 		if !reflect.DeepEqual(got, want) {
 			t.Fatalf("unexpected args: got=%v want=%v", got, want)
@@ -42,12 +46,21 @@ func TestBuildGHWorkflowRunArgs(t *testing.T) {
 
 	t.Run("ignores empty prompt after trimming", func(t *testing.T) {
 		tender := Tender{WorkflowFile: "test.yml"}
-		got := buildGHWorkflowRunArgs(tender, "   ")
+		got := buildGHWorkflowRunArgs(tender, "   ", nil)
 		want := []string{"workflow", "run", "test.yml"}
 		if !reflect.DeepEqual(got, want) {
 			t.Fatalf("unexpected args: got=%v want=%v", got, want)
 		}
 	})
+
+	t.Run("passes inputs sorted by name", func(t *testing.T) {
+		tender := Tender{WorkflowFile: "test.yml"}
+		got := buildGHWorkflowRunArgs(tender, "Fix tests", map[string]string{"scope": "docs", "dry_run": "true"})
+		want := []string{"workflow", "run", "test.yml", "-f", "prompt=Fix tests", "-f", "dry_run=true", "-f", "scope=docs"}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("unexpected args: got=%v want=%v", got, want)
+		}
+	})
 }
 
 // DispatchTenderNow tests
@@ -76,7 +89,7 @@ func TestDispatchTenderNow(t *testing.T) {
 		}
 
 		var stdout, stderr bytes.Buffer
-		err := DispatchTenderNow(root, "test-tender", "test prompt", &stdout, &stderr)
+		err := DispatchTenderNow(root, "test-tender", "test prompt", nil, &stdout, &stderr)
 
 		// We expect this to fail in test environment since we don't have a real GitHub repo
 		// But we can verify the error is about gh workflow dispatch, not earlier validation
@@ -95,7 +108,7 @@ func TestDispatchTenderNow(t *testing.T) {
 		root := t.TempDir()
 		var stdout, stderr bytes.Buffer
 
-		err := DispatchTenderNow(root, "non-existent", "prompt", &stdout, &stderr)
+		err := DispatchTenderNow(root, "non-existent", "prompt", nil, &stdout, &stderr)
 		if err == nil {
 			t.Fatal("expected error for non-existent tender")
 		}
@@ -117,7 +130,7 @@ func TestDispatchTenderNow(t *testing.T) {
 			Name:         "schedule-only",
 			Agent:        "Build",
 			Manual:       false,
-			Cron:         "0 9 * * *",
+			Crons:        []string{"0 9 * * *"},
 			WorkflowFile: "schedule-only.yml",
 		}
 		if err := SaveTender(root, tender); err != nil {
@@ -125,7 +138,7 @@ func TestDispatchTenderNow(t *testing.T) {
 		}
 
 		var stdout, stderr bytes.Buffer
-		err := DispatchTenderNow(root, "schedule-only", "prompt", &stdout, &stderr)
+		err := DispatchTenderNow(root, "schedule-only", "prompt", nil, &stdout, &stderr)
 		if err == nil {
 			t.Fatal("expected error for schedule-only tender")
 		}
@@ -154,7 +167,7 @@ func TestDispatchTenderNow(t *testing.T) {
 		}
 
 		var stdout, stderr bytes.Buffer
-		err := DispatchTenderNow(root, "push-only", "prompt", &stdout, &stderr)
+		err := DispatchTenderNow(root, "push-only", "prompt", nil, &stdout, &stderr)
 		if err == nil {
 			t.Fatal("expected error for push-only tender")
 		}
@@ -165,10 +178,7 @@ func TestDispatchTenderNow(t *testing.T) {
 		}
 	})
 
-	t.Run("returns error when gh CLI is not available", func(t *testing.T) {
-		// This test is hard to do reliably since gh might be in system paths
-		// We'll test the validation logic by mocking the exec.LookPath call
-		// through a test that creates an environment where gh would fail
+	t.Run("returns error without credentials or gh CLI", func(t *testing.T) {
 		root := t.TempDir()
 		if err := EnsureWorkflowDir(root); err != nil {
 			t.Fatalf("failed to create workflow dir: %v", err)
@@ -189,19 +199,100 @@ func TestDispatchTenderNow(t *testing.T) {
 		if _, err := exec.LookPath("gh"); err == nil {
 			t.Skip("gh CLI is available, cannot test unavailable case")
 		}
+		t.Setenv("GITHUB_REPOSITORY", "acme/widgets")
+		t.Setenv("GH_TOKEN", "")
+		t.Setenv("GITHUB_TOKEN", "")
+		t.Setenv("GH_CONFIG_DIR", t.TempDir())
 
 		var stdout, stderr bytes.Buffer
-		err := DispatchTenderNow(root, "test-tender", "prompt", &stdout, &stderr)
+		err := DispatchTenderNow(root, "test-tender", "prompt", nil, &stdout, &stderr)
 		if err == nil {
-			t.Fatal("expected error when gh CLI is not available")
+			t.Fatal("expected error without credentials or gh CLI")
 		}
 
-		expected := "GitHub CLI 'gh' is required to run a tender now"
+		expected := "cannot run a tender now: no GitHub credentials found; set GH_TOKEN or GITHUB_TOKEN, or install the GitHub CLI 'gh'"
 		if err.Error() != expected {
 			t.Fatalf("expected error %q, got %q", expected, err.Error())
 		}
 	})
 
+	t.Run("dispatches through the REST API", func(t *testing.T) {
+		root := t.TempDir()
+		if err := EnsureWorkflowDir(root); err != nil {
+			t.Fatalf("failed to create workflow dir: %v", err)
+		}
+		if _, err := SaveNewTender(root, Tender{Name: "nightly", Agent: "Build", Manual: true}); err != nil {
+			t.Fatalf("SaveNewTender: %v", err)
+		}
+		var body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/widgets":
+				fmt.Fprint(w, `{"default_branch": "trunk"}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/widgets/actions/workflows/nightly.yml/dispatches":
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+		t.Setenv("GITHUB_API_URL", server.URL)
+		t.Setenv("GITHUB_REPOSITORY", "acme/widgets")
+		t.Setenv("GH_TOKEN", "test-token")
+
+		var stdout, stderr bytes.Buffer
+		if err := DispatchTenderNow(root, "nightly", "  Fix tests ", nil, &stdout, &stderr); err != nil {
+			t.Fatalf("DispatchTenderNow returned error: %v", err)
+		}
+		want := `{"inputs":{"prompt":"Fix tests"},"ref":"trunk"}`
+		if body != want {
+			t.Fatalf("unexpected dispatch body: %s, want %s", body, want)
+		}
+	})
+
+	t.Run("sends declared inputs and rejects undeclared ones", func(t *testing.T) {
+		root := t.TempDir()
+		if err := EnsureWorkflowDir(root); err != nil {
+			t.Fatalf("failed to create workflow dir: %v", err)
+		}
+		inputs := []DispatchInput{{Name: "dry_run", Type: InputBoolean, Default: "false"}}
+		if _, err := SaveNewTender(root, Tender{Name: "nightly", Agent: "Build", Manual: true, Inputs: inputs}); err != nil {
+			t.Fatalf("SaveNewTender: %v", err)
+		}
+		var body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/widgets":
+				fmt.Fprint(w, `{"default_branch": "main"}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/widgets/actions/workflows/nightly.yml/dispatches":
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+		t.Setenv("GITHUB_API_URL", server.URL)
+		t.Setenv("GITHUB_REPOSITORY", "acme/widgets")
+		t.Setenv("GH_TOKEN", "test-token")
+
+		var stdout, stderr bytes.Buffer
+		err := DispatchTenderNow(root, "nightly", "", []string{"scope=docs"}, &stdout, &stderr)
+		if err == nil || !strings.Contains(err.Error(), `has no input "scope"`) || body != "" {
+			t.Fatalf("expected undeclared input to be rejected before dispatching, got err=%v body=%q", err, body)
+		}
+		if err := DispatchTenderNow(root, "nightly", "", []string{"dry_run=true"}, &stdout, &stderr); err != nil {
+			t.Fatalf("DispatchTenderNow returned error: %v", err)
+		}
+		want := `{"inputs":{"dry_run":"true"},"ref":"main"}`
+		if body != want {
+			t.Fatalf("unexpected dispatch body: %s, want %s", body, want)
+		}
+	})
+
 	t.Run("propagates LoadTenders errors", func(t *testing.T) {
 		// Create a directory and then make it unreadable
 		root := t.TempDir()
@@ -217,7 +308,7 @@ func TestDispatchTenderNow(t *testing.T) {
 		defer os.Chmod(workflowDir, 0o755)
 
 		var stdout, stderr bytes.Buffer
-		err := DispatchTenderNow(root, "any", "prompt", &stdout, &stderr)
+		err := DispatchTenderNow(root, "any", "prompt", nil, &stdout, &stderr)
 		if err == nil {
 			t.Fatal("expected error from LoadTenders")
 		}
@@ -0,0 +1,360 @@
+package tender
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"tender/internal/cron"
+)
+
+// scheduleWindow is how far ahead tender schedule lays out runs: one week
+// covers every weekday a schedule can pick.
+const scheduleWindow = 7 * 24 * time.Hour
+
+// staggerStep is the granularity, in minutes, of the start times stagger
+// tries.
+const staggerStep = 5
+
+// scheduledRunsPerDay is how many start times the timeline lists for a tender
+// on one day before it only gives the count and range.
+const scheduledRunsPerDay = 4
+
+// scheduledRun is one fire of a tender's schedule. A run may hold its
+// concurrency group until End, when its job timeout runs out.
+type scheduledRun struct {
+	Tender     string
+	Group      string
+	Start, End time.Time
+}
+
+// scheduleOverlap counts the runs of Tender that start while a run of Blocker
+// in the same concurrency group may still be going.
+type scheduleOverlap struct {
+	Tender, Blocker string
+	Group           string
+	Count           int
+	LongestWait     time.Duration
+}
+
+// StaggerChange is a new start minute stagger picked for a tender, with its
+// schedules as written before and after.
+type StaggerChange struct {
+	Tender   Tender
+	From, To []string
+}
+
+// PrintSchedule lays out the runs of every scheduled tender over the week
+// starting today (UTC) and reports runs that start while another tender of the
+// same concurrency group may still be running. It assumes the worst case: every
+// run lasts its full timeout.
+func PrintSchedule(root string, now time.Time, stdout io.Writer) error {
+	tenders, err := LoadTenders(root)
+	if err != nil {
+		return err
+	}
+	from := now.UTC().Truncate(24 * time.Hour)
+	runs, err := scheduledRuns(tenders, from, from.Add(scheduleWindow))
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		_, _ = fmt.Fprintln(stdout, "No scheduled tenders.")
+		return nil
+	}
+
+	timeouts := map[string]int{}
+	for _, t := range tenders {
+		timeouts[t.Name] = normalizeTimeoutMinutes(t.TimeoutMinutes)
+	}
+	waits := runWaits(runs)
+	_, _ = fmt.Fprintln(stdout, "DAY\tTENDER\tRUNS (UTC)\tTIMEOUT\tGROUP\tWAITS FOR")
+	for start := 0; start < len(runs); {
+		day := runs[start].Start.Format("Mon 2006-01-02")
+		end := start
+		for end < len(runs) && runs[end].Start.Format("Mon 2006-01-02") == day {
+			end++
+		}
+		var names []string
+		byTender := map[string][]scheduledRun{}
+		for _, run := range runs[start:end] {
+			if _, ok := byTender[run.Tender]; !ok {
+				names = append(names, run.Tender)
+			}
+			byTender[run.Tender] = append(byTender[run.Tender], run)
+		}
+		for _, name := range names {
+			dayRuns := byTender[name]
+			blockers := map[string]bool{}
+			var waitsFor []string
+			for _, run := range dayRuns {
+				for _, blocker := range waits[run] {
+					if !blockers[blocker] {
+						blockers[blocker] = true
+						waitsFor = append(waitsFor, blocker)
+					}
+				}
+			}
+			waitSummary := "-"
+			if len(waitsFor) > 0 {
+				waitSummary = strings.Join(waitsFor, ", ")
+			}
+			_, _ = fmt.Fprintf(stdout, "%s\t%s\t%s\t%d min\t%s\t%s\n", day, name, runTimes(dayRuns), timeouts[name], dayRuns[0].Group, waitSummary)
+		}
+		start = end
+	}
+
+	overlaps := findOverlaps(runs)
+	_, _ = fmt.Fprintln(stdout)
+	if len(overlaps) == 0 {
+		_, _ = fmt.Fprintln(stdout, "No overlapping runs.")
+		return nil
+	}
+	_, _ = fmt.Fprintln(stdout, "Overlapping runs:")
+	for _, o := range overlaps {
+		_, _ = fmt.Fprintf(stdout, "  %s starts while %s may still run (%s): %d time(s) this week, waiting up to %d min\n",
+			o.Tender, o.Blocker, o.Group, o.Count, int(o.LongestWait/time.Minute))
+	}
+	_, _ = fmt.Fprintln(stdout)
+	_, _ = fmt.Fprintln(stdout, "Run `tender schedule --stagger` to see staggered start minutes, or `tender schedule --apply` to write them.")
+	return nil
+}
+
+// PrintStagger prints the start minutes StaggerSchedules picks and, when
+// apply is set, writes them to the tender workflows.
+func PrintStagger(root string, now time.Time, apply bool, stdout io.Writer) error {
+	tenders, err := LoadTenders(root)
+	if err != nil {
+		return err
+	}
+	changes, err := StaggerSchedules(tenders, now)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		_, _ = fmt.Fprintln(stdout, "No staggering needed.")
+		return nil
+	}
+	for _, c := range changes {
+		_, _ = fmt.Fprintf(stdout, "%s: %s -> %s\n", c.Tender.Name, strings.Join(c.From, localCronSeparator), strings.Join(c.To, localCronSeparator))
+	}
+	if !apply {
+		_, _ = fmt.Fprintln(stdout)
+		_, _ = fmt.Fprintln(stdout, "Run `tender schedule --apply` to write these schedules.")
+		return nil
+	}
+	for _, c := range changes {
+		if err := UpdateTender(root, c.Tender.Name, c.Tender); err != nil {
+			return fmt.Errorf("%s: %w", c.Tender.Name, err)
+		}
+	}
+	_, _ = fmt.Fprintf(stdout, "Updated %d tender(s).\n", len(changes))
+	return nil
+}
+
+// StaggerSchedules moves the start minute of tenders whose runs overlap others
+// in their concurrency group. Tenders are placed in order; each keeps the
+// minute, tried in steps of staggerStep within its hour, that overlaps the
+// tenders placed before it least, preferring its current one. Only schedules
+// with a single start minute are moved. It returns the tenders that changed.
+func StaggerSchedules(tenders []Tender, now time.Time) ([]StaggerChange, error) {
+	from := now.UTC().Truncate(24 * time.Hour)
+	to := from.Add(scheduleWindow)
+	var placed []scheduledRun
+	var changes []StaggerChange
+	for _, t := range tenders {
+		written := writtenCrons(t)
+		if len(written) == 0 {
+			continue
+		}
+		best, err := scheduledRuns([]Tender{t}, from, to)
+		if err != nil {
+			return nil, err
+		}
+		bestScore := overlapTotal(placed, best)
+		var bestTender Tender
+		var bestCrons []string
+		for delta := staggerStep; delta < 60 && bestScore > 0; delta += staggerStep {
+			shifted, ok := shiftCronMinutes(written, delta)
+			if !ok {
+				break
+			}
+			candidate, err := SetSchedule(t, shifted, t.Timezone, now)
+			if err != nil || ValidateTender(candidate) != nil {
+				continue
+			}
+			runs, err := scheduledRuns([]Tender{candidate}, from, to)
+			if err != nil {
+				return nil, err
+			}
+			if score := overlapTotal(placed, runs); score < bestScore {
+				best, bestScore, bestTender, bestCrons = runs, score, candidate, shifted
+			}
+		}
+		placed = append(placed, best...)
+		if bestCrons != nil {
+			changes = append(changes, StaggerChange{Tender: bestTender, From: written, To: bestCrons})
+		}
+	}
+	return changes, nil
+}
+
+// scheduledRuns lists the runs of tenders starting in [from, to), ordered by
+// start time and tender name.
+func scheduledRuns(tenders []Tender, from, to time.Time) ([]scheduledRun, error) {
+	fires := map[string][]time.Time{}
+	for _, expr := range SortedCrons(tenders) {
+		schedule, err := cron.Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		for at := schedule.Next(from.Add(-time.Second)); !at.IsZero() && at.Before(to); at = schedule.Next(at) {
+			fires[expr] = append(fires[expr], at)
+		}
+	}
+	var runs []scheduledRun
+	for _, t := range tenders {
+		timeout := time.Duration(normalizeTimeoutMinutes(t.TimeoutMinutes)) * time.Minute
+		seen := map[time.Time]bool{}
+		for _, expr := range normalizeCrons(t.Crons) {
+			for _, at := range fires[expr] {
+				// Two schedules of one tender firing together start one run.
+				if seen[at] {
+					continue
+				}
+				seen[at] = true
+				runs = append(runs, scheduledRun{Tender: t.Name, Group: concurrencyGroupName(t), Start: at, End: at.Add(timeout)})
+			}
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].Start.Equal(runs[j].Start) {
+			return runs[i].Start.Before(runs[j].Start)
+		}
+		return runs[i].Tender < runs[j].Tender
+	})
+	return runs, nil
+}
+
+// eachWait calls fn for every run that starts while a run of another tender
+// in its concurrency group, started no later, may still be going. runs must be
+// sorted by start time.
+func eachWait(runs []scheduledRun, fn func(prev, run scheduledRun)) {
+	var longest time.Duration
+	for _, run := range runs {
+		if d := run.End.Sub(run.Start); d > longest {
+			longest = d
+		}
+	}
+	first := 0
+	for j, run := range runs {
+		for !runs[first].Start.Add(longest).After(run.Start) {
+			first++
+		}
+		for i := first; i < j; i++ {
+			prev := runs[i]
+			if prev.Group == run.Group && prev.Tender != run.Tender && run.Start.Before(prev.End) {
+				fn(prev, run)
+			}
+		}
+	}
+}
+
+// runWaits maps each run to the tenders it may queue behind.
+func runWaits(runs []scheduledRun) map[scheduledRun][]string {
+	waits := map[scheduledRun][]string{}
+	eachWait(runs, func(prev, run scheduledRun) {
+		waits[run] = append(waits[run], prev.Tender)
+	})
+	return waits
+}
+
+// findOverlaps groups the waits of runs by tender pair, in the order they
+// first happen.
+func findOverlaps(runs []scheduledRun) []scheduleOverlap {
+	var out []scheduleOverlap
+	index := map[string]int{}
+	eachWait(runs, func(prev, run scheduledRun) {
+		key := run.Group + "\x00" + run.Tender + "\x00" + prev.Tender
+		k, ok := index[key]
+		if !ok {
+			k = len(out)
+			index[key] = k
+			out = append(out, scheduleOverlap{Tender: run.Tender, Blocker: prev.Tender, Group: run.Group})
+		}
+		out[k].Count++
+		if wait := prev.End.Sub(run.Start); wait > out[k].LongestWait {
+			out[k].LongestWait = wait
+		}
+	})
+	return out
+}
+
+// overlapTotal is how long runs of other tenders in the same concurrency
+// group overlap, summed over every pair of runs from a and b.
+func overlapTotal(a, b []scheduledRun) time.Duration {
+	var total time.Duration
+	for _, x := range a {
+		for _, y := range b {
+			if x.Group != y.Group || x.Tender == y.Tender {
+				continue
+			}
+			start, end := x.Start, x.End
+			if y.Start.After(start) {
+				start = y.Start
+			}
+			if y.End.Before(end) {
+				end = y.End
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+	}
+	return total
+}
+
+// runTimes lists the start times of one tender's runs on a day, or their
+// count and range when there are many.
+func runTimes(runs []scheduledRun) string {
+	if len(runs) > scheduledRunsPerDay {
+		return fmt.Sprintf("%d runs %s-%s", len(runs), runs[0].Start.Format("15:04"), runs[len(runs)-1].Start.Format("15:04"))
+	}
+	times := make([]string, 0, len(runs))
+	for _, run := range runs {
+		times = append(times, run.Start.Format("15:04"))
+	}
+	return strings.Join(times, ", ")
+}
+
+// writtenCrons returns a tender's schedules as its author wrote them: in its
+// timezone when it has one.
+func writtenCrons(t Tender) []string {
+	if strings.TrimSpace(t.Timezone) != "" {
+		return normalizeCrons(t.LocalCrons)
+	}
+	return normalizeCrons(t.Crons)
+}
+
+// shiftCronMinutes moves the start minute of every expression by delta,
+// wrapping within the hour. It reports false unless each minute field is a
+// single number.
+func shiftCronMinutes(exprs []string, delta int) ([]string, bool) {
+	out := make([]string, 0, len(exprs))
+	for _, expr := range exprs {
+		fields := strings.Fields(expr)
+		if len(fields) != 5 {
+			return nil, false
+		}
+		minute, err := strconv.Atoi(fields[0])
+		if err != nil || minute < 0 || minute > 59 {
+			return nil, false
+		}
+		fields[0] = strconv.Itoa((minute + delta) % 60)
+		out = append(out, strings.Join(fields, " "))
+	}
+	return out, true
+}
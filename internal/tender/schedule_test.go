@@ -0,0 +1,173 @@
+package tender
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// schedule.go tests
+
+func TestPrintSchedule(t *testing.T) {
+	// A Saturday; the week runs to Friday 2026-10-23.
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	seed := func(t *testing.T, tenders ...Tender) string {
+		t.Helper()
+		root := t.TempDir()
+		for _, tender := range tenders {
+			if _, err := SaveNewTender(root, tender); err != nil {
+				t.Fatalf("SaveNewTender returned error: %v", err)
+			}
+		}
+		return root
+	}
+
+	t.Run("flags runs that wait on another tender of the same group", func(t *testing.T) {
+		root := seed(t,
+			Tender{Name: "nightly", Agent: "Build", Crons: []string{"0 9 * * *"}, TimeoutMinutes: 30},
+			Tender{Name: "standup", Agent: "Build", Crons: []string{"0 9 * * 1-5"}, TimeoutMinutes: 60},
+			Tender{Name: "docs", Agent: "Build", Crons: []string{"0 9 * * *"}, Branch: "develop"},
+		)
+		var out bytes.Buffer
+		if err := PrintSchedule(root, now, &out); err != nil {
+			t.Fatalf("PrintSchedule returned error: %v", err)
+		}
+		for _, want := range []string{
+			"DAY\tTENDER\tRUNS (UTC)\tTIMEOUT\tGROUP\tWAITS FOR\n",
+			"Sat 2026-10-17\tnightly\t09:00\t30 min\ttender-main\t-\n",
+			"Sat 2026-10-17\tdocs\t09:00\t30 min\ttender-develop\t-\n",
+			"Mon 2026-10-19\tstandup\t09:00\t60 min\ttender-main\tnightly\n",
+			"  standup starts while nightly may still run (tender-main): 5 time(s) this week, waiting up to 30 min\n",
+		} {
+			if !strings.Contains(out.String(), want) {
+				t.Fatalf("expected %q in output:\n%s", want, out.String())
+			}
+		}
+		if strings.Contains(out.String(), "docs starts while") {
+			t.Fatalf("tenders on other branches must not overlap:\n%s", out.String())
+		}
+	})
+
+	t.Run("summarises frequent runs and reports a clean week", func(t *testing.T) {
+		root := seed(t, Tender{Name: "poller", Agent: "Build", Crons: []string{"0 * * * *"}, TimeoutMinutes: 15})
+		var out bytes.Buffer
+		if err := PrintSchedule(root, now, &out); err != nil {
+			t.Fatalf("PrintSchedule returned error: %v", err)
+		}
+		if !strings.Contains(out.String(), "Sat 2026-10-17\tpoller\t24 runs 00:00-23:00\t15 min") || !strings.Contains(out.String(), "No overlapping runs.") {
+			t.Fatalf("unexpected output:\n%s", out.String())
+		}
+	})
+
+	t.Run("reports when nothing is scheduled", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := PrintSchedule(seed(t, Tender{Name: "manual", Agent: "Build", Manual: true}), now, &out); err != nil {
+			t.Fatalf("PrintSchedule returned error: %v", err)
+		}
+		if out.String() != "No scheduled tenders.\n" {
+			t.Fatalf("unexpected output %q", out.String())
+		}
+	})
+}
+
+func TestStaggerSchedules(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	t.Run("moves a colliding tender past the other's timeout", func(t *testing.T) {
+		changes, err := StaggerSchedules([]Tender{
+			{Name: "nightly", Agent: "Build", Crons: []string{"0 9 * * *"}, TimeoutMinutes: 30},
+			{Name: "standup", Agent: "Build", Crons: []string{"0 9 * * 1-5"}, TimeoutMinutes: 60},
+		}, now)
+		if err != nil {
+			t.Fatalf("StaggerSchedules returned error: %v", err)
+		}
+		if len(changes) != 1 || changes[0].Tender.Name != "standup" || strings.Join(changes[0].To, "; ") != "30 9 * * 1-5" {
+			t.Fatalf("unexpected changes: %+v", changes)
+		}
+	})
+
+	t.Run("shrinks waits it cannot remove", func(t *testing.T) {
+		changes, err := StaggerSchedules([]Tender{
+			{Name: "a", Agent: "Build", Crons: []string{"0 9 * * *"}, TimeoutMinutes: 60},
+			{Name: "b", Agent: "Build", Crons: []string{"0 9 * * *"}, TimeoutMinutes: 60},
+		}, now)
+		if err != nil {
+			t.Fatalf("StaggerSchedules returned error: %v", err)
+		}
+		if len(changes) != 1 || strings.Join(changes[0].To, "; ") != "55 9 * * *" {
+			t.Fatalf("unexpected changes: %+v", changes)
+		}
+	})
+
+	t.Run("leaves schedules without a single start minute alone", func(t *testing.T) {
+		changes, err := StaggerSchedules([]Tender{
+			{Name: "a", Agent: "Build", Crons: []string{"0 9 * * *"}},
+			{Name: "b", Agent: "Build", Crons: []string{"0,30 9 * * *"}},
+		}, now)
+		if err != nil || len(changes) != 0 {
+			t.Fatalf("StaggerSchedules = %+v, %v", changes, err)
+		}
+	})
+
+	t.Run("keeps a timezone tender's local schedule", func(t *testing.T) {
+		if _, err := LoadTimezone("Asia/Tokyo"); err != nil {
+			t.Skipf("time zone database unavailable: %v", err)
+		}
+		zoned, err := SetSchedule(Tender{Name: "standup", Agent: "Build", TimeoutMinutes: 20}, []string{"0 9 * * 1-5"}, "Asia/Tokyo", now)
+		if err != nil {
+			t.Fatalf("SetSchedule returned error: %v", err)
+		}
+		changes, err := StaggerSchedules([]Tender{{Name: "sync", Agent: "Build", Crons: []string{"0 0 * * *"}, TimeoutMinutes: 20}, zoned}, now)
+		if err != nil {
+			t.Fatalf("StaggerSchedules returned error: %v", err)
+		}
+		if len(changes) != 1 {
+			t.Fatalf("unexpected changes: %+v", changes)
+		}
+		got := changes[0].Tender
+		if strings.Join(got.LocalCrons, "; ") != "20 9 * * 1-5" || strings.Join(got.Crons, "; ") != "20 0 * * 1-5" || got.Timezone != "Asia/Tokyo" {
+			t.Fatalf("unexpected staggered tender: %+v", got)
+		}
+	})
+}
+
+func TestPrintStagger(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	root := t.TempDir()
+	for _, tender := range []Tender{
+		{Name: "nightly", Agent: "Build", Crons: []string{"0 9 * * *"}, TimeoutMinutes: 30},
+		{Name: "standup", Agent: "Build", Crons: []string{"0 9 * * 1-5"}, TimeoutMinutes: 60},
+	} {
+		if _, err := SaveNewTender(root, tender); err != nil {
+			t.Fatalf("SaveNewTender returned error: %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := PrintStagger(root, now, false, &out); err != nil {
+		t.Fatalf("PrintStagger returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "standup: 0 9 * * 1-5 -> 30 9 * * 1-5\n") {
+		t.Fatalf("unexpected suggestion:\n%s", out.String())
+	}
+	tenders, err := LoadTenders(root)
+	if err != nil {
+		t.Fatalf("LoadTenders returned error: %v", err)
+	}
+	if strings.Join(tenders[1].Crons, "; ") != "0 9 * * 1-5" {
+		t.Fatalf("suggesting must not write the workflow, got %q", tenders[1].Crons)
+	}
+
+	out.Reset()
+	if err := PrintStagger(root, now, true, &out); err != nil {
+		t.Fatalf("PrintStagger returned error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Updated 1 tender(s).") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	out.Reset()
+	if err := PrintStagger(root, now, false, &out); err != nil || out.String() != "No staggering needed.\n" {
+		t.Fatalf("expected a staggered repository, got %v:\n%s", err, out.String())
+	}
+}
@@ -0,0 +1,297 @@
+package tender
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// secretScanIgnoreFile lists findings `tender scan-diff` should not report,
+// one per line: a path glob, `rule:<id>` or `sha256:<fingerprint>`. It is read
+// from the base revision, so the changes being scanned cannot widen it.
+const secretScanIgnoreFile = ".tender/secret-scan-ignore"
+
+// secretRule is a built-in pattern for credentials in added lines. Group is the
+// submatch holding the secret; 0 means the whole match.
+type secretRule struct {
+	ID    string
+	RE    *regexp.Regexp
+	Group int
+}
+
+var secretRules = []secretRule{
+	{ID: "aws-access-key", RE: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{ID: "github-token", RE: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{ID: "slack-token", RE: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{ID: "private-key", RE: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY-----`)},
+	{ID: "anthropic-api-key", RE: regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{20,}`)},
+	{ID: "openai-api-key", RE: regexp.MustCompile(`\bsk-(?:proj-)?[A-Za-z0-9]{20,}`)},
+	{ID: "google-api-key", RE: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{ID: "stripe-live-key", RE: regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{16,}`)},
+	{ID: "generic-secret", Group: 1, RE: regexp.MustCompile(`(?i)[a-z0-9_.-]*(?:api[_-]?key|secret|token|password|passwd)[a-z0-9_.-]*["']?\s*(?::=|[:=])\s*["']([^"'\s$<{]{12,})["']`)},
+}
+
+// dotenvRule reports added lines in .env files, whatever they contain.
+const dotenvRule = "dotenv-file"
+
+// secretScanStep stages the run's changes and scans them with the
+// `tender scan-diff` installStep pinned, against the target branch, failing the
+// job with the redacted report as its summary before anything is committed or
+// pushed.
+func secretScanStep(t Tender) workflowBlock {
+	return workflowBlock{Key: "Scan for secrets", Lines: []string{
+		"- name: Scan for secrets",
+		"  shell: bash",
+		"  run: |",
+		"    set -euo pipefail",
+		"    cd \"$GITHUB_WORKSPACE\"",
+		"    git add -A",
+		"    REPORT=\"$RUNNER_TEMP/secret-scan.txt\"",
+		"    if ! tender scan-diff --base origin/" + normalizeBranch(t.Branch) + " > \"$REPORT\" 2>&1; then",
+		"      {",
+		"        echo \"### Secret scan stopped this run\"",
+		"        echo",
+		"        echo '```'",
+		"        cat \"$REPORT\"",
+		"        echo '```'",
+		"        echo",
+		"        echo \"Nothing was committed or pushed.\"",
+		"      } >> \"$GITHUB_STEP_SUMMARY\"",
+		"      cat \"$REPORT\" >&2",
+		"      exit 1",
+		"    fi",
+		"    cat \"$REPORT\"",
+	}}
+}
+
+// SecretFinding is one possible credential in the scanned changes. The secret
+// itself is never kept: Redacted shows its first characters and Fingerprint
+// identifies it for the ignore file.
+type SecretFinding struct {
+	File        string
+	Line        int
+	Rule        string
+	Redacted    string
+	Fingerprint string
+}
+
+func (f SecretFinding) String() string {
+	if f.Rule == dotenvRule {
+		return fmt.Sprintf("%s:%d: %s (sha256:%s)", f.File, f.Line, f.Rule, f.Fingerprint)
+	}
+	return fmt.Sprintf("%s:%d: %s %s (sha256:%s)", f.File, f.Line, f.Rule, f.Redacted, f.Fingerprint)
+}
+
+// secretIgnore is a parsed secretScanIgnoreFile.
+type secretIgnore struct {
+	paths        []string
+	rules        map[string]bool
+	fingerprints []string
+}
+
+func parseSecretIgnore(content string) secretIgnore {
+	ignore := secretIgnore{rules: map[string]bool{}}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "rule:"):
+			ignore.rules[strings.TrimSpace(strings.TrimPrefix(line, "rule:"))] = true
+		case strings.HasPrefix(line, "sha256:"):
+			if fp := strings.TrimSpace(strings.TrimPrefix(line, "sha256:")); fp != "" {
+				ignore.fingerprints = append(ignore.fingerprints, fp)
+			}
+		default:
+			ignore.paths = append(ignore.paths, line)
+		}
+	}
+	return ignore
+}
+
+func (ig secretIgnore) skips(f SecretFinding) bool {
+	if ig.rules[f.Rule] {
+		return true
+	}
+	for _, fp := range ig.fingerprints {
+		if strings.HasPrefix(f.Fingerprint, fp) {
+			return true
+		}
+	}
+	for _, p := range ig.paths {
+		if matchIgnorePath(p, f.File) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchIgnorePath matches a file against an ignore glob: `dir/` and `dir/**`
+// cover everything below dir, and globs without a slash match the base name
+// in any directory.
+func matchIgnorePath(pattern, file string) bool {
+	if dir := strings.TrimSuffix(strings.TrimSuffix(pattern, "**"), "/"); dir != pattern && dir != "" {
+		return strings.HasPrefix(file, dir+"/")
+	}
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(file))
+		return ok
+	}
+	ok, _ := path.Match(pattern, file)
+	return ok
+}
+
+func isDotenvFile(file string) bool {
+	base := path.Base(file)
+	if base != ".env" && !strings.HasPrefix(base, ".env.") {
+		return false
+	}
+	switch strings.TrimPrefix(base, ".env.") {
+	case "example", "sample", "template", "dist":
+		return false
+	}
+	return true
+}
+
+// redactSecret keeps the first four characters of a secret, enough to
+// recognise the kind of key without making it usable.
+func redactSecret(secret string) string {
+	if len(secret) <= 4 {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:4] + strings.Repeat("*", min(len(secret)-4, 8))
+}
+
+func secretFingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// scanDiff checks the added lines of a unified diff against the built-in
+// rules. It returns the findings ignore does not skip and the number of files
+// with added lines.
+func scanDiff(diff string, ignore secretIgnore) ([]SecretFinding, int) {
+	var findings []SecretFinding
+	files := map[string]bool{}
+	file, line, inHunk := "", 0, false
+	for _, raw := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(raw, "diff --git "):
+			file, inHunk = "", false
+			continue
+		case !inHunk && strings.HasPrefix(raw, "+++ "):
+			file = diffPath(strings.TrimPrefix(raw, "+++ "))
+			continue
+		case strings.HasPrefix(raw, "@@ "):
+			line, inHunk = hunkStart(raw), true
+			continue
+		case !inHunk || file == "":
+			continue
+		case strings.HasPrefix(raw, "+"):
+		case strings.HasPrefix(raw, "-"), strings.HasPrefix(raw, "\\"):
+			continue
+		default:
+			line++
+			continue
+		}
+
+		added := strings.TrimPrefix(raw, "+")
+		files[file] = true
+		var found []SecretFinding
+		if isDotenvFile(file) {
+			found = append(found, SecretFinding{File: file, Line: line, Rule: dotenvRule, Fingerprint: secretFingerprint(added)})
+		}
+		for _, rule := range secretRules {
+			for _, m := range rule.RE.FindAllStringSubmatch(added, -1) {
+				secret := m[rule.Group]
+				if !hasFingerprint(found, secretFingerprint(secret)) {
+					found = append(found, SecretFinding{File: file, Line: line, Rule: rule.ID, Redacted: redactSecret(secret), Fingerprint: secretFingerprint(secret)})
+				}
+			}
+		}
+		for _, f := range found {
+			if !ignore.skips(f) {
+				findings = append(findings, f)
+			}
+		}
+		line++
+	}
+	return findings, len(files)
+}
+
+// hasFingerprint reports whether a secret was already found, so a key that
+// several rules match is reported once, by the most specific rule.
+func hasFingerprint(findings []SecretFinding, fingerprint string) bool {
+	for _, f := range findings {
+		if f.Fingerprint == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
+// diffPath returns the file of a `+++` header, or "" for deleted files.
+func diffPath(header string) string {
+	header = strings.TrimSpace(header)
+	if strings.HasPrefix(header, `"`) {
+		if unquoted, err := strconv.Unquote(header); err == nil {
+			header = unquoted
+		}
+	}
+	if header == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(header, "b/")
+}
+
+// hunkStart returns the first new-file line of a `@@ -a,b +c,d @@` header.
+func hunkStart(header string) int {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") {
+		return 0
+	}
+	start := strings.SplitN(strings.TrimPrefix(fields[2], "+"), ",", 2)[0]
+	n, _ := strconv.Atoi(start)
+	return n
+}
+
+// ScanStagedDiff scans the changes staged in root against base (HEAD when
+// empty), including commits made since base, with the ignore file as it is in
+// base. It returns the findings and the number of files with added lines.
+func ScanStagedDiff(root, base string) ([]SecretFinding, int, error) {
+	if strings.TrimSpace(base) == "" {
+		base = "HEAD"
+	}
+	if _, err := runGit(root, "rev-parse", "--verify", "--quiet", base+"^{commit}"); err != nil {
+		return nil, 0, fmt.Errorf("cannot scan: %q is not a commit in %s", base, root)
+	}
+	diff, err := runGit(root, "diff", "--cached", "-U0", "--no-color", "--no-ext-diff", "--no-renames", base, "--")
+	if err != nil {
+		return nil, 0, fmt.Errorf("git diff failed: %w", err)
+	}
+	ignoreFile, _ := runGit(root, "show", base+":"+secretScanIgnoreFile)
+	findings, files := scanDiff(diff, parseSecretIgnore(ignoreFile))
+	return findings, files, nil
+}
+
+// PrintScanDiff runs ScanStagedDiff and prints a redacted report. It returns
+// the number of findings.
+func PrintScanDiff(root, base string, stdout io.Writer) (int, error) {
+	findings, files, err := ScanStagedDiff(root, base)
+	if err != nil {
+		return 0, err
+	}
+	if len(findings) == 0 {
+		_, _ = fmt.Fprintf(stdout, "No secrets found in %d changed file(s).\n", files)
+		return 0, nil
+	}
+	for _, f := range findings {
+		_, _ = fmt.Fprintln(stdout, f)
+	}
+	_, _ = fmt.Fprintf(stdout, "%d possible secret(s) found. Remove them, or list the file, rule:<id> or sha256:<fingerprint> in %s.\n", len(findings), secretScanIgnoreFile)
+	return len(findings), nil
+}
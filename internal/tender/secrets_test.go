@@ -0,0 +1,150 @@
+package tender
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// secrets.go tests
+
+// Fake keys are split so the test source itself does not look like a leak.
+var (
+	fakeAWSKey    = "AKIA" + "ABCDEFGHIJKLMNOP"
+	fakeGitHubKey = "ghp_" + strings.Repeat("a1B2", 9)
+)
+
+func TestSecretScan(t *testing.T) {
+	t.Run("renders the scan before delivery", func(t *testing.T) {
+		content := RenderWorkflow(Tender{Name: "docs", Agent: "Build", Manual: true, SecretScan: true, Branch: "develop"})
+		if !containsAll(content,
+			"      TENDER_SECRET_SCAN: \"true\"\n",
+			"    npm install --global @susu-eng/tender@"+Version+"\n",
+			"    if ! tender scan-diff --base origin/develop > \"$REPORT\" 2>&1; then\n",
+			`echo "### Secret scan stopped this run"`,
+		) {
+			t.Fatalf("rendered workflow is missing the secret scan:\n%s", content)
+		}
+		scan := strings.Index(content, "- name: Scan for secrets")
+		if scan < strings.Index(content, "- name: Run OpenCode") || scan > strings.Index(content, "- name: Commit and push develop") {
+			t.Fatalf("expected the scan between Run OpenCode and delivery:\n%s", content)
+		}
+		if strings.Contains(content, "npx") || strings.Contains(content, "@latest") {
+			t.Fatalf("expected the scan to use the pinned install, not npx:\n%s", content)
+		}
+		if plain := RenderWorkflow(Tender{Name: "docs", Agent: "Build", Manual: true}); strings.Contains(plain, "scan-diff") || strings.Contains(plain, "@susu-eng/tender") {
+			t.Fatalf("expected no scan unless enabled:\n%s", plain)
+		}
+	})
+
+	t.Run("round-trips and patches through the workflow file", func(t *testing.T) {
+		on := Tender{Name: "docs", Agent: "Build", Manual: true, SecretScan: true}
+		parsed, err := parseTenderWorkflow(RenderWorkflow(on))
+		if err != nil {
+			t.Fatalf("parseTenderWorkflow returned error: %v", err)
+		}
+		if !parsed.SecretScan {
+			t.Fatalf("round trip lost the secret scan: %+v", parsed)
+		}
+		off := on
+		off.SecretScan = false
+		got, err := PatchWorkflow(RenderWorkflow(on), off)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if strings.Contains(got, "TENDER_SECRET_SCAN") || strings.Contains(got, "Scan for secrets") || strings.Contains(got, "@susu-eng/tender") {
+			t.Fatalf("expected the scan to be removed:\n%s", got)
+		}
+	})
+
+	t.Run("finds and redacts secrets in added lines", func(t *testing.T) {
+		diff := strings.Join([]string{
+			"diff --git a/config.go b/config.go",
+			"--- a/config.go",
+			"+++ b/config.go",
+			"@@ -3,0 +4,3 @@",
+			"+const region = \"us-east-1\"",
+			"+const key = \"" + fakeAWSKey + "\"",
+			"+password := \"correct-horse-battery\"",
+			"@@ -20 +22 @@",
+			"-old := os.Getenv(\"TOKEN\")",
+			"+token = \"" + fakeGitHubKey + "\"",
+			"diff --git a/.env b/.env",
+			"new file mode 100644",
+			"--- /dev/null",
+			"+++ b/.env",
+			"@@ -0,0 +1 @@",
+			"+DEBUG=1",
+			"diff --git a/.env.example b/.env.example",
+			"--- /dev/null",
+			"+++ b/.env.example",
+			"@@ -0,0 +1 @@",
+			"+API_KEY=",
+		}, "\n")
+		findings, files := scanDiff(diff, parseSecretIgnore(""))
+		var got []string
+		for _, f := range findings {
+			got = append(got, f.File+":"+strconv.Itoa(f.Line)+" "+f.Rule+" "+f.Redacted)
+		}
+		want := []string{
+			"config.go:5 aws-access-key AKIA********",
+			"config.go:6 generic-secret corr********",
+			"config.go:22 github-token ghp_********",
+			".env:1 dotenv-file ",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("findings =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+		if files != 3 {
+			t.Fatalf("files = %d, want 3", files)
+		}
+		for _, f := range findings {
+			if strings.Contains(f.String(), fakeAWSKey) || strings.Contains(f.String(), fakeGitHubKey) {
+				t.Fatalf("finding leaks the secret: %s", f)
+			}
+		}
+	})
+
+	t.Run("skips ignored paths, rules and fingerprints", func(t *testing.T) {
+		diff := "diff --git a/testdata/keys.txt b/testdata/keys.txt\n+++ b/testdata/keys.txt\n@@ -0,0 +1,2 @@\n+" + fakeAWSKey + "\n+" + fakeGitHubKey + "\n" +
+			"diff --git a/app.go b/app.go\n+++ b/app.go\n@@ -0,0 +1,2 @@\n+" + fakeAWSKey + "\n+" + fakeGitHubKey + "\n"
+		ignore := parseSecretIgnore("# fixtures\ntestdata/\nrule:github-token\nsha256:" + secretFingerprint(fakeAWSKey)[:8] + "\n")
+		if findings, _ := scanDiff(diff, ignore); len(findings) != 0 {
+			t.Fatalf("expected every finding to be ignored, got %v", findings)
+		}
+		if findings, _ := scanDiff(diff, parseSecretIgnore("*.txt\n")); len(findings) != 2 || findings[0].File != "app.go" {
+			t.Fatalf("expected only app.go findings, got %v", findings)
+		}
+	})
+
+	t.Run("scans staged changes with the base revision's ignore file", func(t *testing.T) {
+		dir := newTestRepo(t)
+		commitAt(t, dir, "", "base", map[string]string{secretScanIgnoreFile: "fixtures/\n"})
+
+		writeTestFiles(t, dir, map[string]string{"fixtures/key.txt": fakeAWSKey + "\n"})
+		testGit(t, dir, "add", "-A")
+		var out bytes.Buffer
+		if n, err := PrintScanDiff(dir, "", &out); err != nil || n != 0 || !strings.Contains(out.String(), "No secrets found in 1 changed file(s).") {
+			t.Fatalf("PrintScanDiff = %d, %v\n%s", n, err, out.String())
+		}
+
+		writeTestFiles(t, dir, map[string]string{
+			"app.go":             "package app\n\nconst key = \"" + fakeAWSKey + "\"\n",
+			secretScanIgnoreFile: "fixtures/\napp.go\n",
+		})
+		testGit(t, dir, "add", "-A")
+		out.Reset()
+		n, err := PrintScanDiff(dir, "HEAD", &out)
+		if err != nil || n != 1 {
+			t.Fatalf("PrintScanDiff = %d, %v\n%s", n, err, out.String())
+		}
+		if !strings.Contains(out.String(), "app.go:3: aws-access-key AKIA********") || strings.Contains(out.String(), fakeAWSKey) {
+			t.Fatalf("unexpected report:\n%s", out.String())
+		}
+
+		if _, err := PrintScanDiff(dir, "no-such-rev", &out); err == nil || !strings.Contains(err.Error(), "is not a commit") {
+			t.Fatalf("expected base error, got %v", err)
+		}
+	})
+}
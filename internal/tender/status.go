@@ -0,0 +1,148 @@
+package tender
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"tender/internal/github"
+)
+
+const statusRecentRuns = 10
+
+// PrintStatus reports recent GitHub Actions runs: the latest run of every
+// tender, or the recent runs of one tender when name is set.
+func PrintStatus(root, name string, stdout io.Writer) error {
+	tenders, err := LoadTenders(root)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		idx := findTenderIndex(tenders, name)
+		if idx < 0 {
+			return fmt.Errorf("tender %q not found", name)
+		}
+		tenders = tenders[idx : idx+1]
+	}
+	if len(tenders) == 0 {
+		_, _ = fmt.Fprintln(stdout, "No managed tender workflows found.")
+		return nil
+	}
+
+	client, err := newGitHubClient(root)
+	if err != nil {
+		return err
+	}
+
+	if name != "" {
+		t := tenders[0]
+		runs, err := client.ListWorkflowRuns(t.WorkflowFile, statusRecentRuns, "")
+		if err != nil {
+			return fmt.Errorf("%s: %w", t.WorkflowFile, err)
+		}
+		if len(runs) == 0 {
+			_, _ = fmt.Fprintf(stdout, "No runs found for tender %q.\n", t.Name)
+			return nil
+		}
+		_, _ = fmt.Fprintln(stdout, "RUN\tSTARTED\tRESULT\tDURATION\tEVENT\tPUSHED")
+		for _, run := range runs {
+			_, _ = fmt.Fprintf(stdout, "%d\t%s\t%s\t%s\t%s\t%s\n", run.ID, formatRunTime(run), runResult(run), runDuration(run), run.Event, runDelivered(client, t, run))
+		}
+		return nil
+	}
+
+	var failed []MalformedTender
+	_, _ = fmt.Fprintln(stdout, "NAME\tLAST RUN\tRESULT\tDURATION\tEVENT\tPUSHED")
+	for _, t := range tenders {
+		runs, err := client.ListWorkflowRuns(t.WorkflowFile, 1, "")
+		if err != nil {
+			failed = append(failed, MalformedTender{WorkflowFile: t.WorkflowFile, Err: err})
+			_, _ = fmt.Fprintf(stdout, "%s\tunknown\t-\t-\t-\t-\n", t.Name)
+			continue
+		}
+		if len(runs) == 0 {
+			_, _ = fmt.Fprintf(stdout, "%s\tnever\t-\t-\t-\t-\n", t.Name)
+			continue
+		}
+		run := runs[0]
+		_, _ = fmt.Fprintf(stdout, "%s\t%s\t%s\t%s\t%s\t%s\n", t.Name, formatRunTime(run), runResult(run), runDuration(run), run.Event, runDelivered(client, t, run))
+	}
+	if len(failed) > 0 {
+		_, _ = fmt.Fprintln(stdout)
+		_, _ = fmt.Fprintln(stdout, "Could not fetch runs:")
+		for _, f := range failed {
+			_, _ = fmt.Fprintf(stdout, "  %s: %v\n", f.WorkflowFile, f.Err)
+		}
+	}
+	return nil
+}
+
+func runStartedAt(run github.WorkflowRun) time.Time {
+	if !run.RunStartedAt.IsZero() {
+		return run.RunStartedAt
+	}
+	return run.CreatedAt
+}
+
+func formatRunTime(run github.WorkflowRun) string {
+	return runStartedAt(run).UTC().Format("2006-01-02 15:04 UTC")
+}
+
+func runResult(run github.WorkflowRun) string {
+	if run.Status != "completed" {
+		return run.Status
+	}
+	if run.Conclusion == "" {
+		return "unknown"
+	}
+	return run.Conclusion
+}
+
+func runDuration(run github.WorkflowRun) string {
+	if run.Status != "completed" {
+		return "-"
+	}
+	d := run.UpdatedAt.Sub(runStartedAt(run))
+	if d < 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
+
+// runDelivered reports whether a finished run landed changes: a commit by
+// tender's bot on the target branch during the run, or the run's pull request.
+func runDelivered(client *github.Client, t Tender, run github.WorkflowRun) string {
+	if run.Status != "completed" {
+		return "-"
+	}
+	if normalizeDelivery(t.Delivery) == DeliveryPR {
+		pr, err := client.FindPullRequest(tenderRunBranch(t, run.ID))
+		if err != nil {
+			return "?"
+		}
+		if pr == nil {
+			return "no"
+		}
+		return "PR #" + strconv.Itoa(pr.Number)
+	}
+	n, err := client.CountCommits(normalizeBranch(t.Branch), url.Values{
+		"author": {tenderBotEmail},
+		"since":  {runStartedAt(run).UTC().Format(time.RFC3339)},
+		"until":  {run.UpdatedAt.UTC().Format(time.RFC3339)},
+	})
+	if err != nil {
+		return "?"
+	}
+	if n > 0 {
+		return "yes"
+	}
+	return "no"
+}
+
+// tenderRunBranch is the branch a pull-request tender pushes for one run; it
+// matches the BRANCH the generated workflow computes.
+func tenderRunBranch(t Tender, runID int64) string {
+	return "tender/" + Slugify(t.Name) + "/" + strconv.FormatInt(runID, 10)
+}
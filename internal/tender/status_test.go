@@ -0,0 +1,211 @@
+package tender
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"tender/internal/github"
+)
+
+// status.go tests
+
+// fakeGitHubAPI serves canned JSON for the REST paths tender calls and records
+// the query strings it received.
+type fakeGitHubAPI struct {
+	routes  map[string]string
+	queries map[string][]string
+}
+
+func newFakeGitHubAPI(t *testing.T, routes map[string]string) *fakeGitHubAPI {
+	t.Helper()
+	api := &fakeGitHubAPI{routes: routes, queries: map[string][]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			http.Error(w, "bad credentials", http.StatusUnauthorized)
+			return
+		}
+		api.queries[r.URL.Path] = append(api.queries[r.URL.Path], r.URL.RawQuery)
+		body, ok := api.routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("GITHUB_API_URL", server.URL)
+	t.Setenv("GITHUB_REPOSITORY", "acme/widgets")
+	t.Setenv("GH_TOKEN", "test-token")
+	return api
+}
+
+func TestPrintStatus(t *testing.T) {
+	setup := func(t *testing.T) string {
+		t.Helper()
+		root := t.TempDir()
+		if err := EnsureWorkflowDir(root); err != nil {
+			t.Fatalf("failed to create workflow dir: %v", err)
+		}
+		for _, tender := range []Tender{
+			{Name: "nightly", Agent: "Build", Manual: true},
+			{Name: "reviewed", Agent: "Build", Manual: true, Delivery: DeliveryPR},
+			{Name: "fresh", Agent: "Build", Manual: true},
+		} {
+			if _, err := SaveNewTender(root, tender); err != nil {
+				t.Fatalf("SaveNewTender: %v", err)
+			}
+		}
+		return root
+	}
+	const nightlyRuns = `{"workflow_runs": [
+  {"id": 101, "event": "schedule", "status": "completed", "conclusion": "success",
+   "run_started_at": "2026-10-16T09:00:05Z", "created_at": "2026-10-16T09:00:00Z", "updated_at": "2026-10-16T09:04:17Z"},
+  {"id": 100, "event": "workflow_dispatch", "status": "completed", "conclusion": "failure",
+   "run_started_at": "2026-10-15T09:00:00Z", "created_at": "2026-10-15T09:00:00Z", "updated_at": "2026-10-15T09:01:00Z"}
+]}`
+
+	t.Run("shows the latest run of every tender", func(t *testing.T) {
+		root := setup(t)
+		api := newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/workflows/nightly.yml/runs":  nightlyRuns,
+			"/repos/acme/widgets/actions/workflows/reviewed.yml/runs": `{"workflow_runs": [{"id": 7, "event": "workflow_dispatch", "status": "in_progress", "run_started_at": "2026-10-17T08:00:00Z", "updated_at": "2026-10-17T08:01:00Z"}]}`,
+			"/repos/acme/widgets/actions/workflows/fresh.yml/runs":    `{"workflow_runs": []}`,
+			"/repos/acme/widgets/commits":                             `[{"sha": "abc"}]`,
+		})
+
+		var out bytes.Buffer
+		if err := PrintStatus(root, "", &out); err != nil {
+			t.Fatalf("PrintStatus returned error: %v", err)
+		}
+		want := strings.Join([]string{
+			"NAME\tLAST RUN\tRESULT\tDURATION\tEVENT\tPUSHED",
+			"fresh\tnever\t-\t-\t-\t-",
+			"nightly\t2026-10-16 09:00 UTC\tsuccess\t4m12s\tschedule\tyes",
+			"reviewed\t2026-10-17 08:00 UTC\tin_progress\t-\tworkflow_dispatch\t-",
+			"",
+		}, "\n")
+		if out.String() != want {
+			t.Fatalf("unexpected status output:\n%s\nwant:\n%s", out.String(), want)
+		}
+		queries := api.queries["/repos/acme/widgets/commits"]
+		if len(queries) != 1 || !strings.Contains(queries[0], "author=tender%5Bbot%5D%40users.noreply.github.com") || !strings.Contains(queries[0], "sha=main") {
+			t.Fatalf("unexpected commit queries: %v", queries)
+		}
+	})
+
+	t.Run("lists recent runs for one tender", func(t *testing.T) {
+		root := setup(t)
+		newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/workflows/reviewed.yml/runs": nightlyRuns,
+			"/repos/acme/widgets/pulls":                               `[{"number": 42, "state": "open"}]`,
+		})
+
+		var out bytes.Buffer
+		if err := PrintStatus(root, "reviewed", &out); err != nil {
+			t.Fatalf("PrintStatus returned error: %v", err)
+		}
+		want := strings.Join([]string{
+			"RUN\tSTARTED\tRESULT\tDURATION\tEVENT\tPUSHED",
+			"101\t2026-10-16 09:00 UTC\tsuccess\t4m12s\tschedule\tPR #42",
+			"100\t2026-10-15 09:00 UTC\tfailure\t1m0s\tworkflow_dispatch\tPR #42",
+			"",
+		}, "\n")
+		if out.String() != want {
+			t.Fatalf("unexpected status output:\n%s\nwant:\n%s", out.String(), want)
+		}
+	})
+
+	t.Run("reports workflows it could not fetch", func(t *testing.T) {
+		root := setup(t)
+		newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/workflows/nightly.yml/runs":  `{"workflow_runs": []}`,
+			"/repos/acme/widgets/actions/workflows/reviewed.yml/runs": `{"workflow_runs": []}`,
+		})
+
+		var out bytes.Buffer
+		if err := PrintStatus(root, "", &out); err != nil {
+			t.Fatalf("PrintStatus returned error: %v", err)
+		}
+		if !strings.Contains(out.String(), "fresh\tunknown\t-\t-\t-\t-") ||
+			!strings.Contains(out.String(), "Could not fetch runs:\n  fresh.yml: GitHub API") {
+			t.Fatalf("expected fetch failure to be reported:\n%s", out.String())
+		}
+	})
+
+	t.Run("returns error for unknown tender", func(t *testing.T) {
+		root := setup(t)
+		if err := PrintStatus(root, "missing", &bytes.Buffer{}); err == nil {
+			t.Fatal("expected error for unknown tender")
+		}
+	})
+}
+
+func TestDetectGitHubRepo(t *testing.T) {
+	t.Run("parses origin remotes", func(t *testing.T) {
+		cases := map[string]string{
+			"git@github.com:acme/widgets.git":      "acme/widgets",
+			"https://github.com/acme/widgets":      "acme/widgets",
+			"https://github.com/acme/widgets.git/": "acme/widgets",
+			"ssh://git@github.com/acme/widgets.js": "acme/widgets.js",
+		}
+		for remote, want := range cases {
+			m := githubRemoteRE.FindStringSubmatch(remote)
+			if m == nil || m[1] != "github.com" || m[2]+"/"+m[3] != want {
+				t.Fatalf("remote %q parsed as %v, want %q", remote, m, want)
+			}
+		}
+	})
+
+	t.Run("reads the origin remote of a repository", func(t *testing.T) {
+		t.Setenv("GITHUB_REPOSITORY", "")
+		dir := newTestRepo(t)
+		if _, err := detectGitHubRepo(dir); err == nil || !strings.Contains(err.Error(), "no origin remote") {
+			t.Fatalf("expected a missing remote error, got %v", err)
+		}
+		testGit(t, dir, "remote", "add", "origin", "git@github.com:acme/widgets.git")
+		if got, err := detectGitHubRepo(dir); err != nil || got != "acme/widgets" {
+			t.Fatalf("detectGitHubRepo() = %q, %v", got, err)
+		}
+	})
+
+	t.Run("accepts GitHub Enterprise Server remotes on the configured host", func(t *testing.T) {
+		t.Setenv("GITHUB_REPOSITORY", "")
+		t.Setenv("GITHUB_API_URL", "")
+		t.Setenv("GH_HOST", "")
+		dir := newTestRepo(t)
+		testGit(t, dir, "remote", "add", "origin", "git@ghe.example.com:org/repo.git")
+
+		if _, err := detectGitHubRepo(dir); err == nil || !strings.Contains(err.Error(), "set GH_HOST=ghe.example.com") {
+			t.Fatalf("expected a GH_HOST hint for an unconfigured host, got %v", err)
+		}
+		t.Setenv("GH_HOST", "ghe.example.com")
+		if got, err := detectGitHubRepo(dir); err != nil || got != "org/repo" {
+			t.Fatalf("detectGitHubRepo() = %q, %v", got, err)
+		}
+		if base := github.BaseURLFromEnv(); base != "https://ghe.example.com/api/v3" {
+			t.Fatalf("BaseURLFromEnv() = %q", base)
+		}
+		t.Setenv("GH_HOST", "")
+		t.Setenv("GITHUB_API_URL", "https://ghe.example.com/api/v3")
+		if got, err := detectGitHubRepo(dir); err != nil || got != "org/repo" {
+			t.Fatalf("detectGitHubRepo() = %q, %v", got, err)
+		}
+		t.Setenv("GITHUB_API_URL", "https://other.example.com/api/v3")
+		if _, err := detectGitHubRepo(dir); err == nil || !strings.Contains(err.Error(), "configured GitHub host other.example.com") {
+			t.Fatalf("expected a host mismatch error, got %v", err)
+		}
+	})
+
+	t.Run("prefers GITHUB_REPOSITORY", func(t *testing.T) {
+		t.Setenv("GITHUB_REPOSITORY", "acme/other")
+		got, err := detectGitHubRepo(t.TempDir())
+		if err != nil || got != "acme/other" {
+			t.Fatalf("detectGitHubRepo() = %q, %v", got, err)
+		}
+	})
+}
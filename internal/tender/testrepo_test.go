@@ -0,0 +1,70 @@
+package tender
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Git fixtures shared by tests that need a real repository.
+
+// newTestRepo returns an empty git repository with a committer identity,
+// skipping the test when git is not installed.
+func newTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found in PATH")
+	}
+	dir := t.TempDir()
+	testGit(t, dir, "init", "-q")
+	testGit(t, dir, "config", "user.name", "t")
+	testGit(t, dir, "config", "user.email", "t@example.com")
+	return dir
+}
+
+// testGit runs git in dir and returns its trimmed output.
+func testGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	return runTestGit(t, dir, nil, args...)
+}
+
+func runTestGit(t *testing.T, dir string, env []string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// writeTestFiles writes files by their path relative to dir.
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, rel)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, rel), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// commitAt writes files, stages every change in dir and commits it with
+// message, dated date (RFC 3339, or now when empty). It returns the commit.
+func commitAt(t *testing.T, dir, date, message string, files map[string]string) string {
+	t.Helper()
+	writeTestFiles(t, dir, files)
+	testGit(t, dir, "add", "-A")
+	var env []string
+	if date != "" {
+		env = []string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date}
+	}
+	runTestGit(t, dir, env, "commit", "-q", "--allow-empty", "-m", message)
+	return testGit(t, dir, "rev-parse", "HEAD")
+}
@@ -0,0 +1,148 @@
+package tender
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"tender/internal/cron"
+)
+
+// LoadTimezone resolves an IANA zone name such as "Europe/Berlin". "Local" is
+// rejected because it means a different zone on every machine.
+func LoadTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "Local") {
+		return nil, fmt.Errorf("timezone must be an IANA name such as Europe/Berlin, got %q", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", name)
+	}
+	return loc, nil
+}
+
+// isUTCZone reports whether a timezone setting means plain UTC scheduling.
+func isUTCZone(name string) bool {
+	name = strings.TrimSpace(name)
+	return name == "" || name == "UTC" || name == "Etc/UTC"
+}
+
+// localCronSeparator joins a tender's local crons in TENDER_LOCAL_CRON.
+const localCronSeparator = "; "
+
+// SetSchedule sets t's schedules to exprs read as wall-clock time in timezone.
+// Empty or UTC timezones store exprs as they are; any other zone keeps them as
+// LocalCrons and stores the UTC crons matching the offset the zone has at now.
+// No exprs clears the schedule.
+func SetSchedule(t Tender, exprs []string, timezone string, now time.Time) (Tender, error) {
+	exprs = normalizeCrons(exprs)
+	if len(exprs) == 0 || isUTCZone(timezone) {
+		t.Crons, t.LocalCrons, t.Timezone = exprs, nil, ""
+		return t, nil
+	}
+	loc, err := LoadTimezone(timezone)
+	if err != nil {
+		return Tender{}, err
+	}
+	utc, err := schedulesInUTC(exprs, loc, now)
+	if err != nil {
+		return Tender{}, err
+	}
+	t.Crons, t.LocalCrons, t.Timezone = utc, exprs, loc.String()
+	return t, nil
+}
+
+// schedulesInUTC converts local crons to the UTC crons a tender stores. One
+// local cron needs several when its times fall on different UTC days.
+func schedulesInUTC(exprs []string, loc *time.Location, now time.Time) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	for _, expr := range exprs {
+		if err := cron.Validate(expr); err != nil {
+			return nil, err
+		}
+		crons, err := cron.ToUTC(expr, loc, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range crons {
+			if !seen[c] {
+				seen[c] = true
+				out = append(out, c)
+			}
+		}
+	}
+	return out, nil
+}
+
+// parseLocalCrons splits a TENDER_LOCAL_CRON value.
+func parseLocalCrons(raw string) []string {
+	return normalizeCrons(strings.Split(raw, strings.TrimSpace(localCronSeparator)))
+}
+
+// validateTimezone checks the timezone fields of a tender: a zone needs the
+// local crons it was converted from, and local crons need their zone.
+func validateTimezone(t Tender) error {
+	local := normalizeCrons(t.LocalCrons)
+	if strings.TrimSpace(t.Timezone) == "" {
+		if len(local) > 0 {
+			return fmt.Errorf("local cron %q requires a timezone", local[0])
+		}
+		return nil
+	}
+	if _, err := LoadTimezone(t.Timezone); err != nil {
+		return err
+	}
+	if len(local) == 0 || len(normalizeCrons(t.Crons)) == 0 {
+		return fmt.Errorf("timezone %s requires a schedule", strings.TrimSpace(t.Timezone))
+	}
+	return validateCrons(local)
+}
+
+// scheduleDrift compares the stored UTC crons of a timezone tender with the
+// ones its local crons convert to at now. It returns the UTC crons that would
+// keep the local times, or nil when the stored ones still do.
+func scheduleDrift(t Tender, now time.Time) ([]string, error) {
+	loc, err := LoadTimezone(t.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	want, err := schedulesInUTC(normalizeCrons(t.LocalCrons), loc, now)
+	if err != nil {
+		return nil, err
+	}
+	have := make([]string, 0, len(t.Crons))
+	for _, c := range normalizeCrons(t.Crons) {
+		schedule, err := cron.Parse(c)
+		if err != nil {
+			return nil, err
+		}
+		have = append(have, schedule.String())
+	}
+	if sameCronSet(want, have) {
+		return nil, nil
+	}
+	return want, nil
+}
+
+func sameCronSet(a, b []string) bool {
+	a = append([]string(nil), a...)
+	b = append([]string(nil), b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return strings.Join(a, "\n") == strings.Join(b, "\n")
+}
+
+// nextOffsetChange returns the first hour within window after now at which
+// loc's UTC offset differs from the one at now, or the zero time.
+func nextOffsetChange(loc *time.Location, now time.Time, window time.Duration) time.Time {
+	_, offset := now.In(loc).Zone()
+	for at := now.Add(time.Hour); !at.After(now.Add(window)); at = at.Add(time.Hour) {
+		if _, o := at.In(loc).Zone(); o != offset {
+			return at.In(loc)
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,166 @@
+package tender
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// timezone.go tests
+
+func TestSetSchedule(t *testing.T) {
+	if _, err := LoadTimezone("Europe/Berlin"); err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	summer := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	winter := time.Date(2026, 12, 1, 12, 0, 0, 0, time.UTC)
+	base := Tender{Name: "nightly", Agent: "Build", Manual: true}
+
+	t.Run("converts a local schedule to UTC for the current offset", func(t *testing.T) {
+		for at, want := range map[time.Time]string{summer: "0 7 * * 1-5", winter: "0 8 * * 1-5"} {
+			got, err := SetSchedule(base, []string{"0 9 * * 1-5"}, "Europe/Berlin", at)
+			if err != nil {
+				t.Fatalf("SetSchedule returned error: %v", err)
+			}
+			if strings.Join(got.Crons, "; ") != want || strings.Join(got.LocalCrons, "; ") != "0 9 * * 1-5" || got.Timezone != "Europe/Berlin" {
+				t.Fatalf("SetSchedule at %s = %+v, want cron %q", at.Format("Jan"), got, want)
+			}
+		}
+	})
+
+	t.Run("UTC and empty zones store the cron as is", func(t *testing.T) {
+		zoned, err := SetSchedule(base, []string{"0 9 * * *"}, "Europe/Berlin", winter)
+		if err != nil {
+			t.Fatalf("SetSchedule returned error: %v", err)
+		}
+		for _, zone := range []string{"", "UTC"} {
+			got, err := SetSchedule(zoned, []string{"0 9 * * *"}, zone, winter)
+			if err != nil {
+				t.Fatalf("SetSchedule returned error: %v", err)
+			}
+			if strings.Join(got.Crons, "; ") != "0 9 * * *" || len(got.LocalCrons) != 0 || got.Timezone != "" {
+				t.Fatalf("SetSchedule(%q) = %+v", zone, got)
+			}
+		}
+		cleared, err := SetSchedule(zoned, nil, "Europe/Berlin", winter)
+		if err != nil || len(cleared.Crons) != 0 || cleared.Timezone != "" {
+			t.Fatalf("clearing the schedule = %+v, %v", cleared, err)
+		}
+	})
+
+	t.Run("rejects unknown zones and unconvertible schedules", func(t *testing.T) {
+		cases := map[string]string{
+			"Mars/Olympus": "unknown timezone",
+			"Local":        "IANA name",
+		}
+		for zone, want := range cases {
+			if _, err := SetSchedule(base, []string{"0 9 * * *"}, zone, winter); err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("SetSchedule(%q) error = %v, want %q", zone, err, want)
+			}
+		}
+		if _, err := SetSchedule(base, []string{"0 0 1 * *"}, "Europe/Berlin", winter); err == nil || !strings.Contains(err.Error(), "crosses midnight") {
+			t.Fatalf("expected crosses midnight error, got %v", err)
+		}
+	})
+
+	t.Run("converts several local schedules into their UTC union", func(t *testing.T) {
+		got, err := SetSchedule(base, []string{"0 0,12 * * 1-5", "0 12 * * 1-5", "0 10 * * 0"}, "Europe/Berlin", winter)
+		if err != nil {
+			t.Fatalf("SetSchedule returned error: %v", err)
+		}
+		want := "0 23 * * 0-4; 0 11 * * 1-5; 0 9 * * 0"
+		if strings.Join(got.Crons, "; ") != want || len(got.LocalCrons) != 3 {
+			t.Fatalf("SetSchedule = %+v, want crons %q", got, want)
+		}
+	})
+}
+
+func TestTimezoneWorkflowRoundTrip(t *testing.T) {
+	if _, err := LoadTimezone("America/New_York"); err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	at := time.Date(2026, 12, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("records the source timezone in the workflow", func(t *testing.T) {
+		tender, err := SetSchedule(Tender{Name: "standup", Agent: "Build", Manual: true}, []string{"30 8 * * 1-5"}, "America/New_York", at)
+		if err != nil {
+			t.Fatalf("SetSchedule returned error: %v", err)
+		}
+		content := RenderWorkflow(tender)
+		for _, want := range []string{`- cron: "30 13 * * 1-5"`, `TENDER_TIMEZONE: "America/New_York"`, `TENDER_LOCAL_CRON: "30 8 * * 1-5"`} {
+			if !strings.Contains(content, want) {
+				t.Fatalf("rendered workflow missing %q:\n%s", want, content)
+			}
+		}
+		parsed, err := parseTenderWorkflow(content)
+		if err != nil {
+			t.Fatalf("parseTenderWorkflow returned error: %v", err)
+		}
+		if strings.Join(parsed.Crons, "; ") != strings.Join(tender.Crons, "; ") || strings.Join(parsed.LocalCrons, "; ") != strings.Join(tender.LocalCrons, "; ") || parsed.Timezone != tender.Timezone {
+			t.Fatalf("round trip lost the timezone schedule: %+v", parsed)
+		}
+	})
+
+	t.Run("drops the timezone keys when switching back to UTC", func(t *testing.T) {
+		root := t.TempDir()
+		zoned, err := SetSchedule(Tender{Name: "standup", Agent: "Build", Manual: true}, []string{"30 8 * * 1-5"}, "America/New_York", at)
+		if err != nil {
+			t.Fatalf("SetSchedule returned error: %v", err)
+		}
+		saved, err := SaveNewTender(root, zoned)
+		if err != nil {
+			t.Fatalf("SaveNewTender returned error: %v", err)
+		}
+		utc, err := SetSchedule(saved, []string{"30 8 * * 1-5"}, "UTC", at)
+		if err != nil {
+			t.Fatalf("SetSchedule returned error: %v", err)
+		}
+		if err := UpdateTender(root, "standup", utc); err != nil {
+			t.Fatalf("UpdateTender returned error: %v", err)
+		}
+		tenders, err := LoadTenders(root)
+		if err != nil {
+			t.Fatalf("LoadTenders returned error: %v", err)
+		}
+		if got := tenders[0]; strings.Join(got.Crons, "; ") != "30 8 * * 1-5" || got.Timezone != "" || len(got.LocalCrons) != 0 {
+			t.Fatalf("expected a plain UTC schedule, got %+v", got)
+		}
+	})
+
+	t.Run("reports broken timezone keys as malformed", func(t *testing.T) {
+		tender, err := SetSchedule(Tender{Name: "standup", Agent: "Build", Manual: true}, []string{"30 8 * * 1-5"}, "America/New_York", at)
+		if err != nil {
+			t.Fatalf("SetSchedule returned error: %v", err)
+		}
+		content := RenderWorkflow(tender)
+		cases := map[string]string{
+			strings.Replace(content, `"America/New_York"`, `"Nowhere/Special"`, 1): "TENDER_TIMEZONE",
+			strings.Replace(content, `"30 8 * * 1-5"`, `"30 8 * *"`, 1):            "TENDER_LOCAL_CRON",
+		}
+		for broken, want := range cases {
+			if _, err := parseTenderWorkflow(broken); err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("expected %s error, got %v", want, err)
+			}
+		}
+	})
+}
+
+func TestValidateTenderTimezone(t *testing.T) {
+	t.Run("requires the timezone and local cron together", func(t *testing.T) {
+		base := Tender{Name: "nightly", Agent: "Build", Manual: true, Crons: []string{"0 8 * * *"}}
+		cases := []struct {
+			local, zone, want string
+		}{
+			{local: "0 9 * * *", zone: "", want: "requires a timezone"},
+			{local: "", zone: "Europe/Berlin", want: "requires a schedule"},
+			{local: "* 9 * * *", zone: "Europe/Berlin", want: "at most every 5 minutes"},
+		}
+		for _, tc := range cases {
+			tender := base
+			tender.LocalCrons, tender.Timezone = []string{tc.local}, tc.zone
+			if err := ValidateTender(tender); err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("ValidateTender(%q, %q) error = %v, want %q", tc.local, tc.zone, err, tc.want)
+			}
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package tender
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestTriggerSummary(t *testing.T) {
 	t.Run("generates summaries for different trigger configurations", func(t *testing.T) {
@@ -17,11 +20,14 @@ func TestTriggerSummary(t *testing.T) {
 			{name: "weekly", cron: "45 6 * * 1,3", manual: false, push: false, want: "weekly Mon,Wed at 06:45 UTC"},
 			{name: "push only", cron: "", manual: false, push: true, want: "on-push(main)"},
 			{name: "push plus on-demand", cron: "", manual: true, push: true, want: "on-push(main) + on-demand"},
+			{name: "stepped hours on weekdays", cron: "0 */6 * * 1-5", manual: true, push: false, want: "every 6 hours on weekdays + on-demand"},
+			{name: "monthly", cron: "30 2 1 * *", manual: false, push: false, want: "at 02:30 UTC on day 1 of the month"},
+			{name: "minute steps", cron: "*/30 8-18 * * *", manual: false, push: false, want: "every 30 minutes during hours 8-18 UTC"},
 		}
 
 		for _, tc := range cases {
 			t.Run(tc.name, func(t *testing.T) {
-				got := TriggerSummary(tc.cron, tc.manual, tc.push)
+				got := TriggerSummary([]string{tc.cron}, tc.manual, tc.push)
 				if got != tc.want {
 					t.Fatalf("unexpected trigger summary: got=%q want=%q", got, tc.want)
 				}
@@ -29,9 +35,34 @@ func TestTriggerSummary(t *testing.T) {
 		}
 	})
 
+	t.Run("names the tender's target branch", func(t *testing.T) {
+		got := tenderTriggerSummary(Tender{Push: true, Manual: true, Branch: "develop"})
+		if want := "on-push(develop) + on-demand"; got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("lists every schedule", func(t *testing.T) {
+		got := TriggerSummary([]string{"0 9 * * 1-5", "0 3 * * 0"}, true, false)
+		if want := "at 09:00 UTC on weekdays + weekly Sun at 03:00 UTC + on-demand"; got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	})
+
+	t.Run("describes timezone schedules as written", func(t *testing.T) {
+		got := tenderTriggerSummary(Tender{Crons: []string{"0 7 * * 1-5"}, LocalCrons: []string{"0 9 * * 1-5"}, Timezone: "Europe/Berlin", Manual: true})
+		if want := "at 09:00 Europe/Berlin on weekdays + on-demand"; got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+		got = tenderTriggerSummary(Tender{Crons: []string{"0 6 1 * *"}, LocalCrons: []string{"0 8 1 * *"}, Timezone: "Europe/Berlin"})
+		if want := "at 08:00 Europe/Berlin on day 1 of the month"; got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	})
+
 	t.Run("handles edge cases", func(t *testing.T) {
 		t.Run("no triggers configured", func(t *testing.T) {
-			got := TriggerSummary("", false, false)
+			got := TriggerSummary([]string{""}, false, false)
 			want := "none"
 			if got != want {
 				t.Fatalf("expected %q, got %q", want, got)
@@ -39,7 +70,7 @@ func TestTriggerSummary(t *testing.T) {
 		})
 
 		t.Run("invalid cron format", func(t *testing.T) {
-			got := TriggerSummary("invalid", false, false)
+			got := TriggerSummary([]string{"invalid"}, false, false)
 			want := "scheduled"
 			if got != want {
 				t.Fatalf("expected %q, got %q", want, got)
@@ -47,7 +78,7 @@ func TestTriggerSummary(t *testing.T) {
 		})
 
 		t.Run("complex weekly schedule", func(t *testing.T) {
-			got := TriggerSummary("0 12 * * 0,1,2,3,4,5,6", false, false)
+			got := TriggerSummary([]string{"0 12 * * 0,1,2,3,4,5,6"}, false, false)
 			want := "weekly Sun,Mon,Tue,Wed,Thu,Fri,Sat at 12:00 UTC"
 			if got != want {
 				t.Fatalf("expected %q, got %q", want, got)
@@ -55,7 +86,7 @@ func TestTriggerSummary(t *testing.T) {
 		})
 
 		t.Run("midnight hourly", func(t *testing.T) {
-			got := TriggerSummary("0 * * * *", false, false)
+			got := TriggerSummary([]string{"0 * * * *"}, false, false)
 			want := "every hour at :00 UTC"
 			if got != want {
 				t.Fatalf("expected %q, got %q", want, got)
@@ -63,7 +94,7 @@ func TestTriggerSummary(t *testing.T) {
 		})
 
 		t.Run("single day weekly", func(t *testing.T) {
-			got := TriggerSummary("30 14 * * 5", false, false)
+			got := TriggerSummary([]string{"30 14 * * 5"}, false, false)
 			want := "weekly Fri at 14:30 UTC"
 			if got != want {
 				t.Fatalf("expected %q, got %q", want, got)
@@ -71,7 +102,7 @@ func TestTriggerSummary(t *testing.T) {
 		})
 
 		t.Run("manual with empty cron", func(t *testing.T) {
-			got := TriggerSummary("", true, false)
+			got := TriggerSummary([]string{""}, true, false)
 			want := "on-demand"
 			if got != want {
 				t.Fatalf("expected %q, got %q", want, got)
@@ -79,7 +110,7 @@ func TestTriggerSummary(t *testing.T) {
 		})
 
 		t.Run("manual with valid cron", func(t *testing.T) {
-			got := TriggerSummary("15 10 * * *", true, false)
+			got := TriggerSummary([]string{"15 10 * * *"}, true, false)
 			want := "daily at 10:15 UTC + on-demand"
 			if got != want {
 				t.Fatalf("expected %q, got %q", want, got)
@@ -87,3 +118,39 @@ func TestTriggerSummary(t *testing.T) {
 		})
 	})
 }
+
+func TestNextRunSummary(t *testing.T) {
+	t.Run("shows the next fire time of the schedule", func(t *testing.T) {
+		now := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+		cases := map[string]string{
+			"":           "-",
+			"0 9 * * 1":  "Mon 2026-10-19 09:00 UTC",
+			"*/20 * * *": "invalid cron",
+			"0 0 31 4 *": "never",
+		}
+		for cron, want := range cases {
+			if got := nextRunSummary(Tender{Crons: []string{cron}, Manual: true}, now); got != want {
+				t.Fatalf("nextRunSummary(%q) = %q, want %q", cron, got, want)
+			}
+		}
+	})
+
+	t.Run("picks the earliest of several schedules", func(t *testing.T) {
+		now := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+		got := nextRunSummary(Tender{Crons: []string{"0 9 * * 1-5", "0 3 * * 0"}}, now)
+		if want := "Sun 2026-10-18 03:00 UTC"; got != want {
+			t.Fatalf("nextRunSummary = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("shows the next run in the tender's timezone", func(t *testing.T) {
+		if _, err := LoadTimezone("Europe/Berlin"); err != nil {
+			t.Skipf("time zone database unavailable: %v", err)
+		}
+		now := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+		got := nextRunSummary(Tender{Crons: []string{"0 7 * * 1-5"}, LocalCrons: []string{"0 9 * * 1-5"}, Timezone: "Europe/Berlin"}, now)
+		if want := "Mon 2026-10-19 09:00 CEST"; got != want {
+			t.Fatalf("nextRunSummary = %q, want %q", got, want)
+		}
+	})
+}
@@ -1,21 +1,60 @@
 package tender
 
-import "sort"
+import (
+	"sort"
+	"strings"
+)
 
 const WorkflowDir = ".github/workflows"
 const DefaultTimeoutMinutes = 30
 
+// DefaultBranch is the target branch assumed when a tender does not name one.
+const DefaultBranch = "main"
+
+// Delivery modes control how a tender run lands its changes.
+const (
+	DeliveryPush = "push"
+	DeliveryPR   = "pr"
+)
+
 type Tender struct {
 	Name           string
 	Agent          string
 	Prompt         string
-	Cron           string
+	PromptFile     string   // repository-relative file the workflow reads the prompt from
+	Crons          []string // UTC, as GitHub Actions runs them
+	LocalCrons     []string // Crons as written in Timezone, when one is set
+	Timezone       string   // IANA zone name; empty means UTC
 	Manual         bool
+	Inputs         []DispatchInput // workflow_dispatch inputs besides prompt
+	IssueLabel     bool            // runs when an issue is labelled tender:<name>
+	PRComment      bool            // runs on a `/tender <name>` pull request comment
+	PullRequest    bool            // runs when a pull request is opened against Branch
+	After          string          // upstream tender whose completed runs start this one
+	AfterWhen      string          // which upstream runs count; empty means AfterCompleted
 	Push           bool
+	Verify         string   // command that must pass before changes are delivered
+	VerifyFailure  string   // what happens to changes that fail Verify; empty means VerifyDiscard
+	AllowPaths     []string // path globs a run may change; empty allows any
+	DenyPaths      []string // path globs a run must not change
+	MaxFiles       int      // most files a run may change; 0 means no limit
+	MaxLines       int      // most lines a run may add and remove; 0 means no limit
+	SecretScan     bool     // scan the changes for secrets before delivering them
 	TimeoutMinutes int
+	Delivery       string
+	Branch         string
+	Model          string
+	Provider       string
 	WorkflowFile   string
 }
 
+// MalformedTender is a workflow file that claims to be a tender but could not
+// be loaded as one.
+type MalformedTender struct {
+	WorkflowFile string
+	Err          error
+}
+
 func normalizeTimeoutMinutes(timeoutMinutes int) int {
 	if timeoutMinutes > 0 {
 		return timeoutMinutes
@@ -23,6 +62,31 @@ func normalizeTimeoutMinutes(timeoutMinutes int) int {
 	return DefaultTimeoutMinutes
 }
 
+func normalizeDelivery(delivery string) string {
+	if strings.TrimSpace(delivery) == "" {
+		return DeliveryPush
+	}
+	return strings.ToLower(strings.TrimSpace(delivery))
+}
+
+// normalizeCrons trims schedule entries and drops blank ones.
+func normalizeCrons(crons []string) []string {
+	var out []string
+	for _, c := range crons {
+		if c = strings.TrimSpace(c); c != "" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func normalizeBranch(branch string) string {
+	if b := strings.TrimSpace(branch); b != "" {
+		return b
+	}
+	return DefaultBranch
+}
+
 func SortTenders(tenders []Tender) {
 	sort.Slice(tenders, func(i, j int) bool {
 		if tenders[i].Name == tenders[j].Name {
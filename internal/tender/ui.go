@@ -69,7 +69,7 @@ func RunInteractive(root string, stdin io.Reader, stdout io.Writer) error {
 
 		switch strings.TrimSpace(action) {
 		case "1":
-			base := Tender{Manual: true, Push: false}
+			base := Tender{Manual: true, Push: false, Branch: DetectDefaultBranch(root)}
 			t, ok, err := inputTender(r, stdout, root, base, true, tty)
 			if err != nil {
 				if errors.Is(err, errQuitRequested) {
@@ -140,7 +140,7 @@ func drawHome(w io.Writer, tenders []Tender, offset int, tty *os.File) {
 		idx := offset + i
 		if idx >= 0 && idx < len(tenders) {
 			t := tenders[idx]
-			fmt.Fprintf(w, "  %s  %-20s %-30s\n", numberChip(key), t.Name, paintTrigger(TriggerSummary(t.Cron, t.Manual, t.Push), t.Cron, t.Manual, t.Push))
+			fmt.Fprintf(w, "  %s  %-20s %-30s\n", numberChip(key), t.Name, paintTrigger(tenderTriggerSummary(t), len(t.Crons) > 0, t.Manual, t.Push || hasEventTriggers(t) || t.After != ""))
 			continue
 		}
 		fmt.Fprintln(w)
@@ -260,8 +260,20 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 	}
 	draft.Agent = agent
 
+	branchDefault := normalizeBranch(base.Branch)
+	branchScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "", true)
+	branchInput, err := promptText(r, branchScreen, fmt.Sprintf("Target branch (default: %s): ", branchDefault))
+	if err != nil {
+		return Tender{}, false, err
+	}
+	branch := strings.TrimSpace(branchInput)
+	if branch == "" {
+		branch = branchDefault
+	}
+	draft.Branch = branch
+
 	pushScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "", true)
-	push, err := promptBinaryChoice(r, pushScreen, tty, "Run on every push to main?", base.Push, false)
+	push, err := promptBinaryChoice(r, pushScreen, tty, fmt.Sprintf("Run on every push to %s?", branch), base.Push, false)
 	if err != nil {
 		if errors.Is(err, errQuitRequested) {
 			return base, false, nil
@@ -270,6 +282,63 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 	}
 	draft.Push = push
 
+	deliveryDefault := 0
+	if normalizeDelivery(base.Delivery) == DeliveryPR {
+		deliveryDefault = 1
+	}
+	deliveryScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "", true)
+	deliveryIndex, err := selectNumberedOption(r, deliveryScreen, tty, "How should changes land?", []string{"Push to main", "Open a pull request"}, deliveryDefault, true)
+	if err != nil {
+		if errors.Is(err, errQuitRequested) {
+			return base, false, nil
+		}
+		return Tender{}, false, err
+	}
+	delivery := DeliveryPush
+	if deliveryIndex == 1 {
+		delivery = DeliveryPR
+	}
+	draft.Delivery = delivery
+
+	verifyPrompt := "Verify command (optional): "
+	if current := strings.TrimSpace(base.Verify); current != "" {
+		verifyPrompt = fmt.Sprintf("Verify command (default: %s, - to remove): ", current)
+	}
+	verifyScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "Runs before delivery; a failing command stops the changes from landing.", true)
+	verifyInput, err := promptText(r, verifyScreen, verifyPrompt)
+	if err != nil {
+		return Tender{}, false, err
+	}
+	verify := strings.TrimSpace(base.Verify)
+	switch verifyInput = strings.TrimSpace(verifyInput); verifyInput {
+	case "":
+	case "-":
+		verify = ""
+	default:
+		verify = verifyInput
+	}
+	draft.Verify = verify
+
+	verifyFailure := ""
+	if verify != "" {
+		failureDefault := 0
+		if normalizeVerifyFailure(base.VerifyFailure) == VerifyBranch {
+			failureDefault = 1
+		}
+		failureScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "", true)
+		failureIndex, err := selectNumberedOption(r, failureScreen, tty, "When verification fails", []string{"Discard the changes", "Push them to a review branch"}, failureDefault, true)
+		if err != nil {
+			if errors.Is(err, errQuitRequested) {
+				return base, false, nil
+			}
+			return Tender{}, false, err
+		}
+		if failureIndex == 1 {
+			verifyFailure = VerifyBranch
+		}
+	}
+	draft.VerifyFailure = verifyFailure
+
 	timeoutDefault := normalizeTimeoutMinutes(base.TimeoutMinutes)
 	timeoutScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "", true)
 	timeoutMinutes, err := promptTimeoutMinutes(r, timeoutScreen, timeoutDefault)
@@ -281,7 +350,13 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 	}
 	draft.TimeoutMinutes = timeoutMinutes
 
-	hasScheduleDefault := isNew || strings.TrimSpace(base.Cron) != ""
+	crons := normalizeCrons(base.Crons)
+	timezone := "UTC"
+	if strings.TrimSpace(base.Timezone) != "" {
+		crons = normalizeCrons(base.LocalCrons)
+		timezone = strings.TrimSpace(base.Timezone)
+	}
+	hasScheduleDefault := isNew || len(crons) > 0
 	scheduleToggleScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "", true)
 	hasSchedule, err := promptBinaryChoice(r, scheduleToggleScreen, tty, "Enable recurring schedule?", hasScheduleDefault, false)
 	if err != nil {
@@ -291,15 +366,41 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 		return Tender{}, false, err
 	}
 
-	cron := strings.TrimSpace(base.Cron)
 	if hasSchedule {
-		defaults, hasDefaults := scheduleDefaultsFromCron(cron)
-		notice := ""
-		if cron != "" && !hasDefaults {
-			notice = "Existing schedule is unsupported in presets; choose a new one."
+		timezoneScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "", true)
+		timezoneInput, err := promptText(r, timezoneScreen, fmt.Sprintf("Timezone (default: %s): ", timezone))
+		if err != nil {
+			return Tender{}, false, err
+		}
+		if tz := strings.TrimSpace(timezoneInput); tz != "" {
+			timezone = tz
+		}
+		if !isUTCZone(timezone) {
+			if _, err := LoadTimezone(timezone); err != nil {
+				if err := acknowledgeTenderForm(r, w, tty, root, isNew, draft, "", err.Error(), true); err != nil {
+					if errors.Is(err, errQuitRequested) {
+						return base, false, nil
+					}
+					return Tender{}, false, err
+				}
+				return base, false, nil
+			}
 		}
 
+		// Schedules the presets cannot express, such as several crons, can be
+		// kept as they are.
+		defaults, hasDefaults := scheduleDefaults{}, false
+		if len(crons) == 1 {
+			defaults, hasDefaults = scheduleDefaultsFromCron(crons[0])
+		}
+		modes := []string{"Hourly", "Daily", "Weekly"}
+		notice := ""
 		defaultMode := 1
+		if len(crons) > 0 && !hasDefaults {
+			notice = "Current schedule: " + triggerSummary(crons, timezone, false, false, branch, nil)
+			modes = append(modes, "Keep current schedule")
+			defaultMode = 3
+		}
 		if hasDefaults {
 			switch defaults.Mode {
 			case "hourly":
@@ -312,7 +413,7 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 		}
 
 		scheduleModeScreen := drawTenderFormScreen(w, tty, root, isNew, draft, notice, true)
-		modeIndex, err := selectNumberedOption(r, scheduleModeScreen, tty, "Schedule", []string{"Hourly", "Daily", "Weekly"}, defaultMode, true)
+		modeIndex, err := selectNumberedOption(r, scheduleModeScreen, tty, "Schedule", modes, defaultMode, true)
 		if err != nil {
 			if errors.Is(err, errQuitRequested) {
 				return base, false, nil
@@ -345,7 +446,7 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 				}
 				return base, false, nil
 			}
-			cron = built
+			crons = []string{built}
 
 		case 1:
 			timeDefault := 2
@@ -353,7 +454,7 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 				timeDefault = defaultTimePresetIndex(defaults.Hour, defaults.Minute)
 			}
 			dailyTimeScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "", true)
-			timeIndex, err := selectNumberedOption(r, dailyTimeScreen, tty, "Daily time (UTC)", timePresetLabels(), timeDefault, true)
+			timeIndex, err := selectNumberedOption(r, dailyTimeScreen, tty, fmt.Sprintf("Daily time (%s)", timezone), timePresetLabels(), timeDefault, true)
 			if err != nil {
 				if errors.Is(err, errQuitRequested) {
 					return base, false, nil
@@ -371,7 +472,7 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 				}
 				return base, false, nil
 			}
-			cron = built
+			crons = []string{built}
 
 		case 2:
 			dayDefault := 0
@@ -392,7 +493,7 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 				timeDefault = defaultTimePresetIndex(defaults.Hour, defaults.Minute)
 			}
 			weeklyTimeScreen := drawTenderFormScreen(w, tty, root, isNew, draft, "", true)
-			timeIndex, err := selectNumberedOption(r, weeklyTimeScreen, tty, "Weekly time (UTC)", timePresetLabels(), timeDefault, true)
+			timeIndex, err := selectNumberedOption(r, weeklyTimeScreen, tty, fmt.Sprintf("Weekly time (%s)", timezone), timePresetLabels(), timeDefault, true)
 			if err != nil {
 				if errors.Is(err, errQuitRequested) {
 					return base, false, nil
@@ -412,24 +513,43 @@ func inputTender(r *bufio.Reader, w io.Writer, root string, base Tender, isNew b
 				}
 				return base, false, nil
 			}
-			cron = built
+			crons = []string{built}
 		}
 	} else {
-		cron = ""
+		crons = nil
 	}
 
-	result := Tender{
+	result, err := SetSchedule(Tender{
 		Name:           name,
 		Agent:          strings.TrimSpace(agent),
 		Prompt:         strings.TrimSpace(base.Prompt),
-		Cron:           strings.TrimSpace(cron),
+		PromptFile:     base.PromptFile,
 		Manual:         true,
+		Inputs:         base.Inputs,
+		IssueLabel:     base.IssueLabel,
+		PRComment:      base.PRComment,
+		PullRequest:    base.PullRequest,
+		After:          base.After,
+		AfterWhen:      base.AfterWhen,
 		Push:           push,
+		Verify:         verify,
+		VerifyFailure:  verifyFailure,
+		AllowPaths:     base.AllowPaths,
+		DenyPaths:      base.DenyPaths,
+		MaxFiles:       base.MaxFiles,
+		MaxLines:       base.MaxLines,
+		SecretScan:     base.SecretScan,
+		Delivery:       delivery,
+		Branch:         branch,
+		Model:          base.Model,
+		Provider:       base.Provider,
 		TimeoutMinutes: timeoutMinutes,
 		WorkflowFile:   base.WorkflowFile,
+	}, crons, timezone, time.Now())
+	if err == nil {
+		err = ValidateTender(result)
 	}
-
-	if err := ValidateTender(result); err != nil {
+	if err != nil {
 		if err := acknowledgeTenderForm(r, w, tty, root, isNew, draft, "", err.Error(), true); err != nil {
 			if errors.Is(err, errQuitRequested) {
 				return base, false, nil
@@ -665,7 +785,7 @@ func selectTender(r *bufio.Reader, w io.Writer, tty *os.File, tenders []Tender,
 	fmt.Fprintf(w, "%s%s Tender%s\n", colorLabel(cCyan), action, cReset)
 	rule(w, '.')
 	for i, t := range tenders {
-		fmt.Fprintf(w, "  %s %-20s %-30s %s\n", numberChip(i+1), t.Name, TriggerSummary(t.Cron, t.Manual, t.Push), t.WorkflowFile)
+		fmt.Fprintf(w, "  %s %-20s %-30s %s\n", numberChip(i+1), t.Name, tenderTriggerSummary(t), t.WorkflowFile)
 	}
 	rule(w, '.')
 
@@ -713,13 +833,23 @@ func runTenderMenu(r *bufio.Reader, w io.Writer, root string, tty *os.File, name
 			return nil
 		}
 		selected := tenders[idx]
-		sw := beginScreen(w, tty, 21+rootTenderSlots())
+		promptSource, promptPreview := promptSummary(root, selected)
+		sw := beginScreen(w, tty, 23+len(promptPreview)+rootTenderSlots())
 		drawHero(sw)
 		fmt.Fprintln(sw)
 		fmt.Fprintf(sw, "%sTender%s %s%s%s\n", colorLabel(cPink), cReset, cBold, selected.Name, cReset)
 		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Agent:", cReset, selected.Agent)
-		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Trigger:", cReset, paintTrigger(TriggerSummary(selected.Cron, selected.Manual, selected.Push), selected.Cron, selected.Manual, selected.Push))
+		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Model:", cReset, modelSummary(selected.Model))
+		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Prompt:", cReset, promptSource)
+		for _, line := range promptPreview {
+			fmt.Fprintf(sw, "%-9s %s%s%s\n", "", cDim, clipText(line, panelWidth-14), cReset)
+		}
+		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Trigger:", cReset, paintTrigger(tenderTriggerSummary(selected), len(selected.Crons) > 0, selected.Manual, selected.Push || hasEventTriggers(selected) || selected.After != ""))
+		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Next run:", cReset, nextRunSummary(selected, time.Now()))
 		fmt.Fprintf(sw, "%s%-9s%s %d min\n", cDim, "Timeout:", cReset, normalizeTimeoutMinutes(selected.TimeoutMinutes))
+		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Branch:", cReset, normalizeBranch(selected.Branch))
+		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Delivery:", cReset, deliverySummary(selected))
+		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Verify:", cReset, verifySummary(selected))
 		fmt.Fprintf(sw, "%s%-9s%s %s\n", cDim, "Workflow:", cReset, selected.WorkflowFile)
 		fmt.Fprintln(sw)
 		rule(sw, '.')
@@ -1166,15 +1296,38 @@ func paintBand(w io.Writer, bg string, fg string, text string) {
 	fmt.Fprintf(w, "%s%s%s%s%s\n", bg, fg, cBold, text, cReset)
 }
 
-func paintTrigger(summary, cron string, manual bool, push bool) string {
+// clipText shortens s to at most width runes, marking the cut with "...".
+func clipText(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+func modelSummary(model string) string {
+	if strings.TrimSpace(model) == "" {
+		return "agent default"
+	}
+	return strings.TrimSpace(model)
+}
+
+func deliverySummary(t Tender) string {
+	if normalizeDelivery(t.Delivery) == DeliveryPR {
+		return "pull request"
+	}
+	return "push to " + normalizeBranch(t.Branch)
+}
+
+func paintTrigger(summary string, scheduled bool, manual bool, push bool) string {
 	switch {
-	case cron != "" && manual && push:
+	case scheduled && manual && push:
 		return cCyan + summary + cReset
-	case cron != "" && manual:
+	case scheduled && manual:
 		return cCyan + summary + cReset
-	case cron != "" && push:
+	case scheduled && push:
 		return cPink + summary + cReset
-	case cron != "":
+	case scheduled:
 		return cMagenta + summary + cReset
 	case push && manual:
 		return cBlue + summary + cReset
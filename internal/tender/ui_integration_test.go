@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestRunInteractive(t *testing.T) {
@@ -82,7 +83,10 @@ EOF
 			"1", // create
 			"q", // name
 			"",  // agent (default TendTests)
+			"",  // branch (default main)
 			"",  // push (default no)
+			"",  // delivery (default push)
+			"",  // verify command (none)
 			"",  // timeout (default 30)
 			"2", // recurring schedule: no
 			"q", // exit dashboard
@@ -164,9 +168,13 @@ EOF
 			"1",          // create
 			"new-tender", // name
 			"",           // agent (default: TendTests)
+			"",           // branch (default main)
 			"",           // push (default: no)
+			"",           // delivery (default push)
+			"",           // verify command (none)
 			"",           // timeout (default: 30)
 			"",           // recurring schedule (default: yes)
+			"",           // timezone (default: UTC)
 			"",           // schedule mode (default: daily)
 			"",           // daily time (default: 09:00 UTC)
 			"q",          // exit
@@ -206,7 +214,10 @@ EOF
 			"1",          // create
 			"new-tender", // name
 			"",           // agent (default TendTests)
+			"",           // branch (default main)
 			"2",          // push: no
+			"",           // delivery (default push)
+			"",           // verify command (none)
 			"",           // timeout (default: 30)
 			"2",          // recurring schedule: no
 			"q",          // exit
@@ -261,7 +272,10 @@ EOF
 			"2", // edit
 			"",  // name (default existing)
 			"",  // agent (default TendTests)
+			"",  // branch (default main)
 			"",  // push (default no)
+			"",  // delivery (default push)
+			"",  // verify command (none)
 			"",  // timeout (default existing)
 			"",  // recurring schedule (default no)
 			"1", // back
@@ -283,6 +297,135 @@ EOF
 		}
 	})
 
+	t.Run("edit flow keeps the timezone schedule and model", func(t *testing.T) {
+		if _, err := LoadTimezone("Europe/Berlin"); err != nil {
+			t.Skipf("time zone database unavailable: %v", err)
+		}
+		root := t.TempDir()
+		if err := EnsureWorkflowDir(root); err != nil {
+			t.Fatalf("failed to create workflow dir: %v", err)
+		}
+		seed, err := SetSchedule(Tender{
+			Name:   "berlin",
+			Agent:  "TendTests",
+			Manual: true,
+			Model:  "anthropic/claude-sonnet-4",
+		}, []string{"0 9 * * *"}, "Europe/Berlin", time.Now())
+		if err != nil {
+			t.Fatalf("SetSchedule returned error: %v", err)
+		}
+		if _, err := SaveNewTender(root, seed); err != nil {
+			t.Fatalf("failed to seed test tender: %v", err)
+		}
+
+		binDir := t.TempDir()
+		writeFakeOpenCode(t, binDir, `#!/bin/sh
+cat <<'EOF'
+NAME MODE
+TendTests primary
+EOF
+`)
+		t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+		stdin := strings.NewReader(strings.Join([]string{
+			"2", // open first tender
+			"2", // edit
+			"",  // name (default berlin)
+			"",  // agent (default TendTests)
+			"",  // branch (default main)
+			"",  // push (default no)
+			"",  // delivery (default push)
+			"",  // verify command (none)
+			"",  // timeout (default 30)
+			"",  // recurring schedule (default yes)
+			"",  // timezone (default Europe/Berlin)
+			"",  // schedule mode (default daily)
+			"",  // daily time (default 09:00)
+			"1", // back
+			"q", // exit
+		}, "\n") + "\n")
+		var stdout bytes.Buffer
+
+		if err := RunInteractive(root, stdin, &stdout); err != nil {
+			t.Fatalf("RunInteractive returned error: %v", err)
+		}
+
+		clean := ansiRE.ReplaceAllString(stdout.String(), "")
+		for _, snippet := range []string{"Timezone (default: Europe/Berlin):", "Daily time (Europe/Berlin)", "daily at 09:00 Europe/Berlin"} {
+			if !strings.Contains(clean, snippet) {
+				t.Fatalf("expected %q in output:\n%s", snippet, clean)
+			}
+		}
+		tenders, err := LoadTenders(root)
+		if err != nil {
+			t.Fatalf("LoadTenders returned error: %v", err)
+		}
+		got := tenders[0]
+		if got.Timezone != "Europe/Berlin" || strings.Join(got.LocalCrons, "; ") != "0 9 * * *" || strings.Join(got.Crons, "; ") != strings.Join(seed.Crons, "; ") {
+			t.Fatalf("timezone schedule was not kept: %+v", got)
+		}
+		if got.Model != "anthropic/claude-sonnet-4" {
+			t.Fatalf("edit dropped the model: %+v", got)
+		}
+	})
+
+	t.Run("detail screen previews the prompt file and edit keeps it", func(t *testing.T) {
+		root := t.TempDir()
+		if err := EnsureWorkflowDir(root); err != nil {
+			t.Fatalf("failed to create workflow dir: %v", err)
+		}
+		seed, err := UsePromptFile(root, Tender{Name: "docs", Agent: "TendTests", Manual: true}, ".tender/prompts/docs.md", "Refresh the README.\nKeep it short.\nLink the changelog.\nFix typos.")
+		if err != nil {
+			t.Fatalf("UsePromptFile returned error: %v", err)
+		}
+		if _, err := SaveNewTender(root, seed); err != nil {
+			t.Fatalf("failed to seed test tender: %v", err)
+		}
+
+		binDir := t.TempDir()
+		writeFakeOpenCode(t, binDir, `#!/bin/sh
+cat <<'EOF'
+NAME MODE
+TendTests primary
+EOF
+`)
+		t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+		stdin := strings.NewReader(strings.Join([]string{
+			"2", // open first tender
+			"2", // edit
+			"",  // name (default docs)
+			"",  // agent (default TendTests)
+			"",  // branch (default main)
+			"",  // push (default no)
+			"",  // delivery (default push)
+			"",  // verify command (none)
+			"",  // timeout (default 30)
+			"",  // recurring schedule (default no)
+			"1", // back
+			"q", // exit
+		}, "\n") + "\n")
+		var stdout bytes.Buffer
+
+		if err := RunInteractive(root, stdin, &stdout); err != nil {
+			t.Fatalf("RunInteractive returned error: %v", err)
+		}
+
+		clean := ansiRE.ReplaceAllString(stdout.String(), "")
+		for _, snippet := range []string{"Prompt:   .tender/prompts/docs.md", "Refresh the README.", "Keep it short.", "... (2 more lines)"} {
+			if !strings.Contains(clean, snippet) {
+				t.Fatalf("expected %q in output:\n%s", snippet, clean)
+			}
+		}
+		tenders, err := LoadTenders(root)
+		if err != nil {
+			t.Fatalf("LoadTenders returned error: %v", err)
+		}
+		if got := tenders[0]; got.PromptFile != ".tender/prompts/docs.md" || got.Prompt != "" {
+			t.Fatalf("edit dropped the prompt file: %+v", got)
+		}
+	})
+
 	t.Run("agent picker supports 9/0 paging for long lists", func(t *testing.T) {
 		root := t.TempDir()
 		if err := EnsureWorkflowDir(root); err != nil {
@@ -312,7 +455,10 @@ EOF
 			"paged-agents", // name
 			"0",            // agent page down
 			"2",            // choose Agent10 (2nd slot on page 2)
+			"",             // branch (default main)
 			"",             // push (default no)
+			"",             // delivery (default push)
+			"",             // verify command (none)
 			"",             // timeout (default 30)
 			"2",            // recurring schedule: no
 			"q",            // exit
@@ -369,9 +515,13 @@ EOF
 			"1",               // create
 			"journey",         // name
 			"",                // agent (default TendTests)
+			"develop",         // branch
 			"1",               // push: yes
+			"2",               // delivery: pull request
+			"",                // verify command (none)
 			"45",              // timeout
 			"1",               // recurring schedule: yes
+			"",                // timezone (default: UTC)
 			"2",               // schedule mode: daily
 			"4",               // daily time: 12:00 UTC
 			"2",               // open tender
@@ -380,7 +530,10 @@ EOF
 			"2",               // edit
 			"journey-renamed", // name
 			"2",               // agent: RefineDocs
+			"",                // branch (default: develop)
 			"2",               // push: no
+			"",                // delivery (default: pull request)
+			"",                // verify command (none)
 			"60",              // timeout
 			"2",               // recurring schedule: no
 			"3",               // delete
@@ -409,7 +562,10 @@ EOF
 			"OK: Deleted journey.yml",
 			"Tender journey",
 			"Tender journey-renamed",
-			"daily at 12:00 UTC + on-push(main) + on-demand",
+			"Run on every push to develop?",
+			"daily at 12:00 UTC + on-push(develop) + on-demand",
+			"Branch:   develop",
+			"Delivery: pull request",
 			"RefineDocs",
 		}
 		for _, snippet := range requiredSnippets {
@@ -467,7 +623,7 @@ func TestDrawHome(t *testing.T) {
 		var stdout bytes.Buffer
 		tenders := []Tender{
 			{Name: "test1", Agent: "Build", Manual: true, WorkflowFile: "test1.yml"},
-			{Name: "test2", Agent: "Deploy", Cron: "0 9 * * *", WorkflowFile: "test2.yml"},
+			{Name: "test2", Agent: "Deploy", Crons: []string{"0 9 * * *"}, WorkflowFile: "test2.yml"},
 		}
 
 		drawHome(&stdout, tenders, 0, nil)
@@ -1194,7 +1194,7 @@ func TestPaintBand(t *testing.T) {
 
 func TestPaintTrigger(t *testing.T) {
 	t.Run("cron plus manual", func(t *testing.T) {
-		result := paintTrigger("daily at 09:00 UTC + on-demand", "0 9 * * *", true, false)
+		result := paintTrigger("daily at 09:00 UTC + on-demand", true, true, false)
 		if !strings.Contains(result, cCyan) {
 			t.Fatal("expected cyan color for cron+manual")
 		}
@@ -1204,7 +1204,7 @@ func TestPaintTrigger(t *testing.T) {
 	})
 
 	t.Run("cron only", func(t *testing.T) {
-		result := paintTrigger("daily at 09:00 UTC", "0 9 * * *", false, false)
+		result := paintTrigger("daily at 09:00 UTC", true, false, false)
 		if !strings.Contains(result, cMagenta) {
 			t.Fatal("expected magenta color for cron only")
 		}
@@ -1214,7 +1214,7 @@ func TestPaintTrigger(t *testing.T) {
 	})
 
 	t.Run("manual only", func(t *testing.T) {
-		result := paintTrigger("on-demand", "", true, false)
+		result := paintTrigger("on-demand", false, true, false)
 		if !strings.Contains(result, cGreen) {
 			t.Fatal("expected green color for manual only")
 		}
@@ -1224,7 +1224,7 @@ func TestPaintTrigger(t *testing.T) {
 	})
 
 	t.Run("push only", func(t *testing.T) {
-		result := paintTrigger("on-push(main)", "", false, true)
+		result := paintTrigger("on-push(main)", false, false, true)
 		if !strings.Contains(result, cBlue) {
 			t.Fatal("expected blue color for push only")
 		}
@@ -1234,7 +1234,7 @@ func TestPaintTrigger(t *testing.T) {
 	})
 
 	t.Run("no trigger", func(t *testing.T) {
-		result := paintTrigger("none", "", false, false)
+		result := paintTrigger("none", false, false, false)
 		if strings.Contains(result, cCyan) || strings.Contains(result, cMagenta) || strings.Contains(result, cGreen) || strings.Contains(result, cBlue) {
 			t.Fatal("expected no color for no trigger")
 		}
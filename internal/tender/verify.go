@@ -0,0 +1,119 @@
+package tender
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// A tender's verify command gates delivery: when it fails, the run fails and
+// the changes are discarded, or pushed to a review branch with
+// VerifyBranch. An empty VerifyFailure means VerifyDiscard.
+const (
+	VerifyDiscard = "discard"
+	VerifyBranch  = "branch"
+)
+
+func normalizeVerifyFailure(mode string) string {
+	if m := strings.ToLower(strings.TrimSpace(mode)); m != "" {
+		return m
+	}
+	return VerifyDiscard
+}
+
+func validateVerify(t Tender) error {
+	command := strings.TrimSpace(t.Verify)
+	if command == "" {
+		if strings.TrimSpace(t.VerifyFailure) != "" {
+			return fmt.Errorf("verify-failure needs a verify command")
+		}
+		return nil
+	}
+	if strings.ContainsAny(command, "\r\n") {
+		return fmt.Errorf("verify command must be a single line")
+	}
+	if m := normalizeVerifyFailure(t.VerifyFailure); m != VerifyDiscard && m != VerifyBranch {
+		return fmt.Errorf("verify-failure must be %q or %q", VerifyDiscard, VerifyBranch)
+	}
+	return nil
+}
+
+// verifyBranchName is where VerifyBranch tenders push changes that failed
+// verification; ${GITHUB_RUN_ID} is left for the shell.
+func verifyBranchName(t Tender) string {
+	return "tender/" + Slugify(t.Name) + "/unverified-${GITHUB_RUN_ID}"
+}
+
+// verifyStep runs the verify command on the agent's changes before the
+// delivery step, which GitHub skips once a step has failed. Runs that changed
+// nothing have nothing to verify. The changes are staged first and the working
+// tree is reset to them afterwards, so files the command writes or edits
+// (coverage reports, build output) are not delivered with them.
+func verifyStep(t Tender) workflowBlock {
+	branch := normalizeBranch(t.Branch)
+	return workflowBlock{Key: "Verify changes", Lines: []string{
+		"- name: Verify changes",
+		"  id: verify",
+		"  shell: bash",
+		"  run: |",
+		"    set -euo pipefail",
+		"    cd \"$GITHUB_WORKSPACE\"",
+		"    if [ -z \"$(git status --porcelain --ignore-submodules)\" ] && [ \"$(git rev-list --count origin/" + branch + "..HEAD || echo 0)\" -eq 0 ]; then",
+		"      echo \"No changes to verify\"",
+		"      exit 0",
+		"    fi",
+		"    git add -A",
+		"    STATUS=0",
+		"    bash -c \"$TENDER_VERIFY\" || STATUS=$?",
+		"    git checkout -- .",
+		"    git clean -fdq",
+		"    if [ \"$STATUS\" -ne 0 ]; then",
+		"      printf '### Verification failed\\n\\n`%s` failed, so the changes were not delivered to " + branch + ".\\n' \"$TENDER_VERIFY\" >> \"$GITHUB_STEP_SUMMARY\"",
+		"      exit 1",
+		"    fi",
+	}}
+}
+
+// verifyFailureStep pushes changes that failed verification to a review
+// branch. The run stays failed.
+func verifyFailureStep(t Tender) workflowBlock {
+	return workflowBlock{Key: "Push unverified changes", Lines: []string{
+		"- name: Push unverified changes",
+		"  if: failure() && steps.verify.outcome == 'failure'",
+		"  shell: bash",
+		"  run: |",
+		"    set -euo pipefail",
+		"    BRANCH=\"" + verifyBranchName(t) + "\"",
+		"    git checkout -B \"$BRANCH\"",
+		"    git add -A",
+		"    if ! git diff --cached --quiet --ignore-submodules --; then",
+		"      git commit -m \"" + fmt.Sprintf(commitMessageFormat, "$TENDER_NAME") + "\"",
+		"    fi",
+		"    git push --force origin \"HEAD:refs/heads/$BRANCH\"",
+		"    printf 'The unverified changes were pushed to `%s` for review.\\n' \"$BRANCH\" >> \"$GITHUB_STEP_SUMMARY\"",
+	}}
+}
+
+// runVerifyLocally runs a tender's verify command in dir the way its workflow
+// does and reports whether it passed.
+func runVerifyLocally(t Tender, dir string, env []string, stdout, stderr io.Writer) bool {
+	cmd := exec.Command("bash", "-c", strings.TrimSpace(t.Verify))
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run() == nil
+}
+
+// verifySummary describes a tender's verify gate for the TUI.
+func verifySummary(t Tender) string {
+	command := strings.TrimSpace(t.Verify)
+	if command == "" {
+		return "none"
+	}
+	if normalizeVerifyFailure(t.VerifyFailure) == VerifyBranch {
+		return command + " (failures go to a review branch)"
+	}
+	return command + " (failures are discarded)"
+}
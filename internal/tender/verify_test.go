@@ -0,0 +1,126 @@
+package tender
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// verify.go tests
+
+func TestVerifyGate(t *testing.T) {
+	base := Tender{Name: "nightly", Agent: "Build", Manual: true, Verify: "make check-fast"}
+
+	t.Run("renders the verify step between the agent and delivery", func(t *testing.T) {
+		content := RenderWorkflow(base)
+		run := strings.Index(content, "- name: Run OpenCode")
+		verify := strings.Index(content, "- name: Verify changes")
+		deliver := strings.Index(content, "- name: Commit and push main")
+		if run < 0 || verify < run || deliver < verify {
+			t.Fatalf("expected Run OpenCode, Verify changes, Commit and push main in order:\n%s", content)
+		}
+		if !containsAll(content,
+			"      TENDER_VERIFY: \"make check-fast\"\n",
+			"        id: verify\n",
+			`bash -c "$TENDER_VERIFY" || STATUS=$?`,
+			`"$GITHUB_STEP_SUMMARY"`,
+		) {
+			t.Fatalf("rendered workflow is missing verify content:\n%s", content)
+		}
+		if strings.Contains(content, "Push unverified changes") || strings.Contains(content, "TENDER_VERIFY_FAILURE") {
+			t.Fatalf("expected failures to be discarded by default:\n%s", content)
+		}
+	})
+
+	t.Run("pushes failed changes to a review branch", func(t *testing.T) {
+		branch := base
+		branch.VerifyFailure = VerifyBranch
+		content := RenderWorkflow(branch)
+		if !containsAll(content,
+			"      TENDER_VERIFY_FAILURE: \"branch\"\n",
+			"      - name: Push unverified changes\n        if: failure() && steps.verify.outcome == 'failure'\n",
+			`BRANCH="tender/nightly/unverified-${GITHUB_RUN_ID}"`,
+		) {
+			t.Fatalf("rendered workflow is missing the review branch step:\n%s", content)
+		}
+		if strings.Index(content, "Push unverified changes") < strings.Index(content, "Commit and push main") {
+			t.Fatalf("expected the review branch step after delivery:\n%s", content)
+		}
+	})
+
+	t.Run("round-trips through the workflow file", func(t *testing.T) {
+		for _, failure := range []string{"", VerifyBranch} {
+			tender := base
+			tender.VerifyFailure = failure
+			parsed, err := parseTenderWorkflow(RenderWorkflow(tender))
+			if err != nil {
+				t.Fatalf("parseTenderWorkflow returned error: %v", err)
+			}
+			if parsed.Verify != base.Verify || parsed.VerifyFailure != failure {
+				t.Fatalf("round trip lost the verify gate: %+v", parsed)
+			}
+		}
+	})
+
+	t.Run("patches the steps in and out", func(t *testing.T) {
+		plain := Tender{Name: "nightly", Agent: "Build", Manual: true}
+		branch := base
+		branch.VerifyFailure = VerifyBranch
+		got, err := PatchWorkflow(RenderWorkflow(plain), branch)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if got != RenderWorkflow(branch) {
+			t.Fatalf("patched workflow differs from a fresh render:\n%s", got)
+		}
+		back, err := PatchWorkflow(got, plain)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if back != RenderWorkflow(plain) {
+			t.Fatalf("expected the verify steps to be removed:\n%s", back)
+		}
+	})
+
+	t.Run("keeps files the verify command writes out of the delivery", func(t *testing.T) {
+		dir := newTestRepo(t)
+		commitAt(t, dir, "", "initial", map[string]string{"app.go": "package app\n"})
+		// The agent's change, then a verify command that rewrites it, leaves a
+		// report behind and fails.
+		writeTestFiles(t, dir, map[string]string{"app.go": "package app\n\nfunc Run() {}\n"})
+		var script []string
+		for _, line := range verifyStep(base).Lines[5:] {
+			script = append(script, strings.TrimPrefix(line, "    "))
+		}
+		cmd := exec.Command("bash", "-c", strings.Join(script, "\n"))
+		cmd.Env = append(os.Environ(),
+			"GITHUB_WORKSPACE="+dir,
+			"GITHUB_STEP_SUMMARY="+filepath.Join(t.TempDir(), "summary.md"),
+			"TENDER_VERIFY=echo formatted > app.go && mkdir -p coverage && echo 80% > coverage/report.txt && exit 3",
+		)
+		if out, err := cmd.CombinedOutput(); err == nil {
+			t.Fatalf("expected the failed verify to fail the step:\n%s", out)
+		}
+		if out := testGit(t, dir, "status", "--porcelain", "--untracked-files=all"); out != "M  app.go" {
+			t.Fatalf("expected only the agent's staged change, got:\n%s", out)
+		}
+		if data, _ := os.ReadFile(filepath.Join(dir, "app.go")); string(data) != "package app\n\nfunc Run() {}\n" {
+			t.Fatalf("expected the agent's app.go to be restored, got %q", data)
+		}
+	})
+
+	t.Run("validates the gate", func(t *testing.T) {
+		cases := map[string]Tender{
+			"single line":            {Name: "nightly", Agent: "Build", Manual: true, Verify: "make\nmake test"},
+			"needs a verify command": {Name: "nightly", Agent: "Build", Manual: true, VerifyFailure: VerifyBranch},
+			"verify-failure must be": {Name: "nightly", Agent: "Build", Manual: true, Verify: "make", VerifyFailure: "ignore"},
+		}
+		for want, tender := range cases {
+			if err := ValidateTender(tender); err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("ValidateTender(%+v) error = %v, want %q", tender, err, want)
+			}
+		}
+	})
+}
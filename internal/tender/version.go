@@ -0,0 +1,7 @@
+package tender
+
+// Version is the tender release this build belongs to. Workflows that run
+// tender themselves install exactly this version, so a run never picks up a
+// scanner newer than the CLI that rendered it. scripts/publish.sh keeps it in
+// step with package.json.
+const Version = "0.7.3"
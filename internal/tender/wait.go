@@ -0,0 +1,223 @@
+package tender
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"tender/internal/github"
+)
+
+const dispatchEvent = "workflow_dispatch"
+
+// waitOptions controls how `tender run --wait` polls GitHub. Polling starts at
+// minInterval and backs off towards maxInterval while nothing changes.
+type waitOptions struct {
+	minInterval time.Duration
+	maxInterval time.Duration
+	findTimeout time.Duration
+	maxErrors   int
+	sleep       func(time.Duration)
+}
+
+var runWaitOptions = waitOptions{
+	minInterval: 2 * time.Second,
+	maxInterval: 20 * time.Second,
+	findTimeout: 2 * time.Minute,
+	maxErrors:   3,
+	sleep:       time.Sleep,
+}
+
+// RunResult is the outcome of a run followed to completion.
+type RunResult struct {
+	RunID      int64
+	Conclusion string
+	URL        string
+}
+
+// DispatchTenderAndWait dispatches a tender like DispatchTenderNow, finds the
+// run the dispatch created, and streams its status and step transitions to
+// stdout until it completes.
+func DispatchTenderAndWait(root, tenderName, prompt string, inputs []string, stdout, stderr io.Writer) (RunResult, error) {
+	tenders, err := LoadTenders(root)
+	if err != nil {
+		return RunResult{}, err
+	}
+	idx := findTenderIndex(tenders, tenderName)
+	if idx < 0 {
+		return RunResult{}, fmt.Errorf("tender %q not found", tenderName)
+	}
+	t := tenders[idx]
+	if _, err := ResolveInputValues(t, inputs); err != nil {
+		return RunResult{}, err
+	}
+
+	client, err := newGitHubClient(root)
+	if err != nil {
+		return RunResult{}, err
+	}
+	existing, err := client.ListWorkflowRuns(t.WorkflowFile, 20, dispatchEvent)
+	if err != nil {
+		return RunResult{}, fmt.Errorf("%s: %w", t.WorkflowFile, err)
+	}
+	seen := make(map[int64]bool, len(existing))
+	for _, run := range existing {
+		seen[run.ID] = true
+	}
+
+	if err := DispatchTenderNow(root, tenderName, prompt, inputs, stdout, stderr); err != nil {
+		return RunResult{}, err
+	}
+	fmt.Fprintf(stdout, "triggered %s; waiting for the run to start\n", t.Name)
+
+	run, err := findDispatchedRun(client, t.WorkflowFile, seen, runWaitOptions)
+	if err != nil {
+		return RunResult{}, err
+	}
+	fmt.Fprintf(stdout, "run %d: %s\n", run.ID, run.HTMLURL)
+
+	run, err = followRun(client, run.ID, stdout, runWaitOptions)
+	if err != nil {
+		return RunResult{}, err
+	}
+	return RunResult{RunID: run.ID, Conclusion: run.Conclusion, URL: run.HTMLURL}, nil
+}
+
+// findDispatchedRun polls for the oldest workflow_dispatch run that did not
+// exist before the dispatch.
+func findDispatchedRun(client *github.Client, workflowFile string, seen map[int64]bool, opts waitOptions) (github.WorkflowRun, error) {
+	deadline := time.Now().Add(opts.findTimeout)
+	interval := opts.minInterval
+	for {
+		runs, err := client.ListWorkflowRuns(workflowFile, 20, dispatchEvent)
+		if err != nil {
+			return github.WorkflowRun{}, fmt.Errorf("%s: %w", workflowFile, err)
+		}
+		var fresh []github.WorkflowRun
+		for _, run := range runs {
+			if !seen[run.ID] {
+				fresh = append(fresh, run)
+			}
+		}
+		if len(fresh) > 0 {
+			sort.Slice(fresh, func(i, j int) bool { return fresh[i].ID < fresh[j].ID })
+			return fresh[0], nil
+		}
+		if time.Now().After(deadline) {
+			return github.WorkflowRun{}, fmt.Errorf("dispatched run of %s did not appear within %s", workflowFile, opts.findTimeout)
+		}
+		opts.sleep(interval)
+		interval = nextPollInterval(interval, opts)
+	}
+}
+
+// followRun polls a run until it completes, printing run status changes and
+// step transitions as they happen.
+func followRun(client *github.Client, runID int64, stdout io.Writer, opts waitOptions) (github.WorkflowRun, error) {
+	interval := opts.minInterval
+	lastStatus := ""
+	steps := map[string]string{}
+	failures := 0
+	for {
+		run, jobs, err := pollRun(client, runID)
+		if err != nil {
+			failures++
+			if failures >= opts.maxErrors {
+				return github.WorkflowRun{}, err
+			}
+			opts.sleep(interval)
+			interval = nextPollInterval(interval, opts)
+			continue
+		}
+		failures = 0
+
+		changed := false
+		if run.Status != lastStatus && run.Status != "completed" {
+			lastStatus = run.Status
+			changed = true
+			fmt.Fprintf(stdout, "run %d %s\n", run.ID, run.Status)
+		}
+		for _, job := range jobs {
+			for _, step := range job.Steps {
+				key := fmt.Sprintf("%s/%d", job.Name, step.Number)
+				state := step.Status
+				if state == "completed" {
+					state += ":" + step.Conclusion
+				}
+				if steps[key] == state {
+					continue
+				}
+				steps[key] = state
+				changed = true
+				switch step.Status {
+				case "in_progress":
+					fmt.Fprintf(stdout, "  > %s\n", step.Name)
+				case "completed":
+					fmt.Fprintf(stdout, "  %s %s\n", stepMark(step.Conclusion), step.Name)
+				}
+			}
+		}
+		if run.Status == "completed" {
+			fmt.Fprintf(stdout, "run %d completed: %s\n", run.ID, runResult(run))
+			return run, nil
+		}
+
+		if changed {
+			interval = opts.minInterval
+		} else {
+			interval = nextPollInterval(interval, opts)
+		}
+		opts.sleep(interval)
+	}
+}
+
+func pollRun(client *github.Client, runID int64) (github.WorkflowRun, []github.Job, error) {
+	run, err := client.GetRun(runID)
+	if err != nil {
+		return github.WorkflowRun{}, nil, err
+	}
+	jobs, err := client.ListRunJobs(runID)
+	if err != nil {
+		return github.WorkflowRun{}, nil, err
+	}
+	return run, jobs, nil
+}
+
+func nextPollInterval(current time.Duration, opts waitOptions) time.Duration {
+	next := current * 3 / 2
+	if next > opts.maxInterval {
+		return opts.maxInterval
+	}
+	return next
+}
+
+func stepMark(conclusion string) string {
+	switch conclusion {
+	case "success":
+		return "ok"
+	case "skipped":
+		return "--"
+	default:
+		return "!!"
+	}
+}
+
+// ConclusionExitCode maps a run conclusion to the exit code of
+// `tender run --wait`: 0 for success (and neutral or skipped runs), 1 for
+// failure, 3 for cancelled, 4 for timed_out and 5 for anything else. Exit code
+// 2 stays reserved for usage errors.
+func ConclusionExitCode(conclusion string) int {
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return 0
+	case "failure":
+		return 1
+	case "cancelled":
+		return 3
+	case "timed_out":
+		return 4
+	default:
+		return 5
+	}
+}
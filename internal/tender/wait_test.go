@@ -0,0 +1,171 @@
+package tender
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// wait.go tests
+
+// fakeRunProgress accepts a dispatch, serves the run it creates and then walks
+// through the given job snapshots, one per poll.
+func fakeRunProgress(t *testing.T, conclusion string, snapshots []string) {
+	t.Helper()
+	var mu sync.Mutex
+	dispatched, polled := false, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/acme/widgets/actions/workflows/nightly.yml/runs":
+			if r.URL.Query().Get("event") != "workflow_dispatch" {
+				t.Errorf("expected workflow_dispatch filter, got %q", r.URL.RawQuery)
+			}
+			if !dispatched {
+				fmt.Fprint(w, `{"workflow_runs": [{"id": 50, "status": "completed"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"workflow_runs": [{"id": 51, "status": "queued", "html_url": "https://github.com/acme/widgets/actions/runs/51"}, {"id": 50, "status": "completed"}]}`)
+		case "/repos/acme/widgets":
+			fmt.Fprint(w, `{"default_branch": "main"}`)
+		case "/repos/acme/widgets/actions/workflows/nightly.yml/dispatches":
+			dispatched = true
+			w.WriteHeader(http.StatusNoContent)
+		case "/repos/acme/widgets/actions/runs/51":
+			status, result := "in_progress", ""
+			if polled >= len(snapshots)-1 {
+				status, result = "completed", conclusion
+			}
+			fmt.Fprintf(w, `{"id": 51, "status": %q, "conclusion": %q, "html_url": "https://github.com/acme/widgets/actions/runs/51"}`, status, result)
+		case "/repos/acme/widgets/actions/runs/51/jobs":
+			idx := polled
+			if idx >= len(snapshots) {
+				idx = len(snapshots) - 1
+			}
+			polled++
+			fmt.Fprintf(w, `{"jobs": [{"name": "tender", "steps": %s}]}`, snapshots[idx])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("GITHUB_API_URL", server.URL)
+	t.Setenv("GITHUB_REPOSITORY", "acme/widgets")
+	t.Setenv("GH_TOKEN", "test-token")
+
+	previous := runWaitOptions
+	runWaitOptions = waitOptions{
+		minInterval: time.Millisecond,
+		maxInterval: 4 * time.Millisecond,
+		findTimeout: time.Second,
+		maxErrors:   3,
+		sleep:       func(time.Duration) {},
+	}
+	t.Cleanup(func() { runWaitOptions = previous })
+}
+
+func TestDispatchTenderAndWait(t *testing.T) {
+	setup := func(t *testing.T) string {
+		t.Helper()
+		root := t.TempDir()
+		if err := EnsureWorkflowDir(root); err != nil {
+			t.Fatalf("failed to create workflow dir: %v", err)
+		}
+		if _, err := SaveNewTender(root, Tender{Name: "nightly", Agent: "Build", Manual: true}); err != nil {
+			t.Fatalf("SaveNewTender: %v", err)
+		}
+		return root
+	}
+	snapshots := []string{
+		`[{"name": "Set up job", "number": 1, "status": "in_progress"}]`,
+		`[{"name": "Set up job", "number": 1, "status": "completed", "conclusion": "success"},
+		  {"name": "Run OpenCode", "number": 5, "status": "in_progress"}]`,
+		`[{"name": "Set up job", "number": 1, "status": "completed", "conclusion": "success"},
+		  {"name": "Run OpenCode", "number": 5, "status": "in_progress"}]`,
+		`[{"name": "Set up job", "number": 1, "status": "completed", "conclusion": "success"},
+		  {"name": "Run OpenCode", "number": 5, "status": "completed", "conclusion": "failure"}]`,
+	}
+
+	t.Run("follows the dispatched run to completion", func(t *testing.T) {
+		root := setup(t)
+		fakeRunProgress(t, "failure", snapshots)
+
+		var stdout, stderr bytes.Buffer
+		result, err := DispatchTenderAndWait(root, "nightly", "", nil, &stdout, &stderr)
+		if err != nil {
+			t.Fatalf("DispatchTenderAndWait returned error: %v", err)
+		}
+		if result.RunID != 51 || result.Conclusion != "failure" {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+		want := strings.Join([]string{
+			"triggered nightly; waiting for the run to start",
+			"run 51: https://github.com/acme/widgets/actions/runs/51",
+			"run 51 in_progress",
+			"  > Set up job",
+			"  ok Set up job",
+			"  > Run OpenCode",
+			"  !! Run OpenCode",
+			"run 51 completed: failure",
+			"",
+		}, "\n")
+		if stdout.String() != want {
+			t.Fatalf("unexpected output:\n%s\nwant:\n%s", stdout.String(), want)
+		}
+	})
+
+	t.Run("rejects tenders without workflow_dispatch before dispatching", func(t *testing.T) {
+		root := setup(t)
+		if _, err := SaveNewTender(root, Tender{Name: "scheduled", Agent: "Build", Crons: []string{"0 9 * * *"}}); err != nil {
+			t.Fatalf("SaveNewTender: %v", err)
+		}
+		newFakeGitHubAPI(t, map[string]string{
+			"/repos/acme/widgets/actions/workflows/scheduled.yml/runs": `{"workflow_runs": []}`,
+		})
+		_, err := DispatchTenderAndWait(root, "scheduled", "", nil, &bytes.Buffer{}, &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "does not allow on-demand runs") {
+			t.Fatalf("expected on-demand error, got %v", err)
+		}
+	})
+}
+
+func TestNextPollInterval(t *testing.T) {
+	t.Run("backs off up to the maximum", func(t *testing.T) {
+		opts := waitOptions{minInterval: 2 * time.Second, maxInterval: 5 * time.Second}
+		got := []time.Duration{}
+		interval := opts.minInterval
+		for i := 0; i < 4; i++ {
+			interval = nextPollInterval(interval, opts)
+			got = append(got, interval)
+		}
+		want := []time.Duration{3 * time.Second, 4500 * time.Millisecond, 5 * time.Second, 5 * time.Second}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("intervals = %v, want %v", got, want)
+		}
+	})
+}
+
+func TestConclusionExitCode(t *testing.T) {
+	t.Run("maps conclusions to exit codes", func(t *testing.T) {
+		cases := map[string]int{
+			"success":         0,
+			"skipped":         0,
+			"failure":         1,
+			"cancelled":       3,
+			"timed_out":       4,
+			"action_required": 5,
+		}
+		for conclusion, want := range cases {
+			if got := ConclusionExitCode(conclusion); got != want {
+				t.Fatalf("ConclusionExitCode(%q) = %d, want %d", conclusion, got, want)
+			}
+		}
+	})
+}
@@ -2,6 +2,7 @@ package tender
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -9,6 +10,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+
+	"tender/internal/cron"
 )
 
 func EnsureWorkflowDir(root string) error {
@@ -16,20 +20,28 @@ func EnsureWorkflowDir(root string) error {
 }
 
 func LoadTenders(root string) ([]Tender, error) {
+	tenders, _, err := LoadTenderWorkflows(root)
+	return tenders, err
+}
+
+// LoadTenderWorkflows loads every tender under .github/workflows and also
+// returns the files that declare themselves as tenders but could not be parsed.
+func LoadTenderWorkflows(root string) ([]Tender, []MalformedTender, error) {
 	dir := filepath.Join(root, WorkflowDir)
 	if _, err := os.Stat(dir); err != nil {
 		if os.IsNotExist(err) {
-			return []Tender{}, nil
+			return []Tender{}, nil, nil
 		}
-		return nil, err
+		return nil, nil, err
 	}
 
 	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	out := make([]Tender, 0)
+	var malformed []MalformedTender
 	for _, e := range entries {
 		if e.IsDir() {
 			continue
@@ -41,10 +53,13 @@ func LoadTenders(root string) ([]Tender, error) {
 		abs := filepath.Join(dir, name)
 		data, err := os.ReadFile(abs)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		t, ok := parseTenderWorkflow(string(data))
-		if !ok {
+		t, err := parseTenderWorkflow(string(data))
+		if err != nil {
+			if !errors.Is(err, errNotTenderWorkflow) {
+				malformed = append(malformed, MalformedTender{WorkflowFile: name, Err: err})
+			}
 			continue
 		}
 		t.WorkflowFile = name
@@ -52,13 +67,22 @@ func LoadTenders(root string) ([]Tender, error) {
 	}
 
 	SortTenders(out)
-	return out, nil
+	return out, malformed, nil
 }
 
 func SaveTender(root string, t Tender) error {
 	if err := ValidateTender(t); err != nil {
 		return err
 	}
+	if file := strings.TrimSpace(t.PromptFile); file != "" {
+		prompt, err := readPromptFile(root, t)
+		if err != nil {
+			return err
+		}
+		if err := validatePromptPlaceholders(prompt, t); err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+	}
 	if err := EnsureWorkflowDir(root); err != nil {
 		return err
 	}
@@ -72,7 +96,21 @@ func SaveTender(root string, t Tender) error {
 	}
 	file = filepath.Base(file)
 	path := filepath.Join(root, WorkflowDir, file)
-	return os.WriteFile(path, []byte(RenderWorkflow(t)), 0o644)
+	content := RenderWorkflow(t)
+	if existing, err := os.ReadFile(path); err == nil {
+		if _, err := parseTenderWorkflow(string(existing)); err == nil {
+			// Keep hand edits: only the fields tender owns are rewritten.
+			patched, err := PatchWorkflow(string(existing), t)
+			if err != nil {
+				return fmt.Errorf("update %s: %w", file, err)
+			}
+			if _, err := parseTenderWorkflow(patched); err != nil {
+				return fmt.Errorf("update %s: result is no longer a tender workflow: %w", file, err)
+			}
+			content = patched
+		}
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
 }
 
 func RemoveTender(root, name string) error {
@@ -84,172 +122,413 @@ func RemoveTender(root, name string) error {
 	if idx < 0 {
 		return fmt.Errorf("tender %q not found", name)
 	}
+	if dependents := chainDependents(tenders, tenders[idx].Name); len(dependents) > 0 {
+		return fmt.Errorf("cannot remove %q: %s run(s) after it; update them first", tenders[idx].Name, strings.Join(dependents, ", "))
+	}
 	path := filepath.Join(root, WorkflowDir, tenders[idx].WorkflowFile)
 	return os.Remove(path)
 }
 
-func RenderWorkflow(t Tender) string {
-	var b strings.Builder
-	b.WriteString("name: ")
-	b.WriteString(strconv.Quote("tender/" + strings.TrimSpace(t.Name)))
-	b.WriteString("\n\n")
-	b.WriteString("on:\n")
+// pushLoopGuard prevents circular runs when a push-triggered tender pushes
+// back to its target branch.
+const (
+	pushLoopGuardExpr = "github.event_name != 'push' || github.actor != 'github-actions[bot]'"
+	pushLoopGuard     = "${{ " + pushLoopGuardExpr + " }}"
+)
+
+// jobCondition is the job's `if`: the guards of its triggers, all of which
+// must hold. It is empty when no trigger needs one.
+func jobCondition(t Tender) string {
+	var guards []string
+	if t.Push {
+		guards = append(guards, pushLoopGuardExpr)
+	}
+	guards = append(guards, eventGuards(t)...)
+	guards = append(guards, chainGuard(t)...)
+	switch len(guards) {
+	case 0:
+		return ""
+	case 1:
+		return "${{ " + guards[0] + " }}"
+	}
+	return "${{ (" + strings.Join(guards, ") && (") + ") }}"
+}
+
+// tenderBotEmail is the commit author email of generated workflows.
+const tenderBotEmail = "tender[bot]@users.noreply.github.com"
+
+// fallbackPromptFormat and commitMessageFormat take the tender name. Workflows
+// fill in $TENDER_NAME; local runs fill in the name itself.
+const (
+	fallbackPromptFormat = "Run the tender task '%s' for this repository."
+	commitMessageFormat  = "tender(%s): autonomous update"
+)
+
+// workflowBlock is one tender-owned mapping entry, rendered as lines relative
+// to the mapping that contains it.
+type workflowBlock struct {
+	Key   string
+	Lines []string
+}
+
+// workflowScalar is one tender-owned key with a single-line value; Encoded is
+// the YAML text written for Value.
+type workflowScalar struct {
+	Key     string
+	Value   string
+	Encoded string
+}
+
+func quotedScalar(key, value string) workflowScalar {
+	return workflowScalar{Key: key, Value: value, Encoded: strconv.Quote(value)}
+}
+
+func plainScalar(key, value string) workflowScalar {
+	return workflowScalar{Key: key, Value: value, Encoded: value}
+}
+
+func (s workflowScalar) line() string {
+	return s.Key + ": " + s.Encoded
+}
+
+func workflowName(t Tender) workflowScalar {
+	return quotedScalar("name", tenderNamePrefix+strings.TrimSpace(t.Name))
+}
+
+// renderTriggers returns the `on:` entries tender owns, in render order.
+func renderTriggers(t Tender) []workflowBlock {
+	blocks := make([]workflowBlock, 0, 3)
 	if t.Manual {
-		b.WriteString("  workflow_dispatch:\n")
-		b.WriteString("    inputs:\n")
-		b.WriteString("      prompt:\n")
-		b.WriteString("        description: \"Optional prompt override\"\n")
-		b.WriteString("        required: false\n")
-		b.WriteString("        default: \"\"\n")
-		b.WriteString("        type: string\n")
+		lines := []string{
+			"workflow_dispatch:",
+			"  inputs:",
+			"    prompt:",
+			"      description: \"Optional prompt override\"",
+			"      required: false",
+			"      default: \"\"",
+			"      type: string",
+		}
+		for _, line := range renderInputLines(t.Inputs) {
+			lines = append(lines, "    "+line)
+		}
+		blocks = append(blocks, workflowBlock{Key: "workflow_dispatch", Lines: lines})
 	}
 	if t.Push {
-		b.WriteString("  push:\n")
-		b.WriteString("    branches:\n")
-		b.WriteString("      - main\n")
-	}
-	if strings.TrimSpace(t.Cron) != "" {
-		b.WriteString("  schedule:\n")
-		b.WriteString("    - cron: ")
-		b.WriteString(strconv.Quote(strings.TrimSpace(t.Cron)))
+		blocks = append(blocks, workflowBlock{Key: "push", Lines: []string{
+			"push:",
+			"  branches:",
+			"    - " + normalizeBranch(t.Branch),
+		}})
+	}
+	if crons := normalizeCrons(t.Crons); len(crons) > 0 {
+		lines := []string{"schedule:"}
+		for _, c := range crons {
+			lines = append(lines, "  - cron: "+strconv.Quote(c))
+		}
+		blocks = append(blocks, workflowBlock{Key: "schedule", Lines: lines})
+	}
+	blocks = append(blocks, renderEventTriggers(t)...)
+	blocks = append(blocks, renderChainTrigger(t)...)
+	if len(blocks) == 0 {
+		blocks = append(blocks, workflowBlock{Key: "workflow_dispatch", Lines: []string{"workflow_dispatch:"}})
+	}
+	return blocks
+}
+
+func tenderEnv(t Tender) []workflowScalar {
+	env := []workflowScalar{
+		quotedScalar("TENDER_NAME", strings.TrimSpace(t.Name)),
+		quotedScalar("TENDER_AGENT", strings.TrimSpace(t.Agent)),
+		quotedScalar("TENDER_PROMPT", strings.TrimSpace(t.Prompt)),
+	}
+	if file := strings.TrimSpace(t.PromptFile); file != "" {
+		env = append(env, quotedScalar("TENDER_PROMPT_FILE", file))
+	}
+	if normalizeDelivery(t.Delivery) == DeliveryPR {
+		env = append(env, quotedScalar("TENDER_DELIVERY", DeliveryPR))
+	}
+	if branch := normalizeBranch(t.Branch); branch != DefaultBranch {
+		env = append(env, quotedScalar("TENDER_BRANCH", branch))
+	}
+	if model := strings.TrimSpace(t.Model); model != "" {
+		env = append(env, quotedScalar("TENDER_MODEL", model), quotedScalar("TENDER_PROVIDER", tenderProvider(t)))
+	}
+	env = append(env, guardrailEnv(t)...)
+	if t.SecretScan {
+		env = append(env, quotedScalar("TENDER_SECRET_SCAN", "true"))
+	}
+	if verify := strings.TrimSpace(t.Verify); verify != "" {
+		env = append(env, quotedScalar("TENDER_VERIFY", verify))
+		if normalizeVerifyFailure(t.VerifyFailure) == VerifyBranch {
+			env = append(env, quotedScalar("TENDER_VERIFY_FAILURE", VerifyBranch))
+		}
+	}
+	if strings.TrimSpace(t.After) != "" && normalizeAfterWhen(t.AfterWhen) != AfterCompleted {
+		env = append(env, quotedScalar("TENDER_AFTER_WHEN", normalizeAfterWhen(t.AfterWhen)))
+	}
+	if timezone := strings.TrimSpace(t.Timezone); timezone != "" {
+		env = append(env,
+			quotedScalar("TENDER_TIMEZONE", timezone),
+			quotedScalar("TENDER_LOCAL_CRON", strings.Join(normalizeCrons(t.LocalCrons), localCronSeparator)),
+		)
+	}
+	return env
+}
+
+func renderPermissions(t Tender) []workflowScalar {
+	permissions := []workflowScalar{plainScalar("contents", "write")}
+	if normalizeDelivery(t.Delivery) == DeliveryPR {
+		permissions = append(permissions, plainScalar("pull-requests", "write"))
+	} else if hasPullRequestTriggers(t) {
+		permissions = append(permissions, plainScalar("pull-requests", "read"))
+	}
+	return permissions
+}
+
+// concurrencyGroup serialises every tender that lands changes on the same
+// branch.
+func concurrencyGroup(t Tender) workflowScalar {
+	return plainScalar("group", concurrencyGroupName(t))
+}
+
+func concurrencyGroupName(t Tender) string {
+	return "tender-" + normalizeBranch(t.Branch)
+}
+
+func timeoutScalar(t Tender) workflowScalar {
+	return plainScalar("timeout-minutes", strconv.Itoa(normalizeTimeoutMinutes(t.TimeoutMinutes)))
+}
+
+func writeIndented(b *strings.Builder, indent string, lines []string) {
+	for _, line := range lines {
+		b.WriteString(indent)
+		b.WriteString(line)
 		b.WriteString("\n")
 	}
-	if !t.Manual && !t.Push && strings.TrimSpace(t.Cron) == "" {
-		b.WriteString("  workflow_dispatch:\n")
+}
+
+func RenderWorkflow(t Tender) string {
+	var b strings.Builder
+	b.WriteString(workflowName(t).line())
+	b.WriteString("\n\n")
+	b.WriteString("on:\n")
+	for _, block := range renderTriggers(t) {
+		writeIndented(&b, "  ", block.Lines)
 	}
 
 	b.WriteString("\npermissions:\n")
-	b.WriteString("  contents: write\n\n")
+	for _, permission := range renderPermissions(t) {
+		b.WriteString("  " + permission.line() + "\n")
+	}
+	b.WriteString("\n")
 	b.WriteString("concurrency:\n")
-	b.WriteString("  group: tender-main\n")
+	b.WriteString("  " + concurrencyGroup(t).line() + "\n")
 	b.WriteString("  cancel-in-progress: false\n\n")
 	b.WriteString("jobs:\n")
 	b.WriteString("  tender:\n")
-	if t.Push {
-		// Prevent circular runs when this workflow pushes back to main.
-		b.WriteString("    if: ${{ github.event_name != 'push' || github.actor != 'github-actions[bot]' }}\n")
+	if condition := jobCondition(t); condition != "" {
+		b.WriteString("    if: " + condition + "\n")
 	}
 	b.WriteString("    runs-on: ubuntu-latest\n")
-	b.WriteString("    timeout-minutes: ")
-	b.WriteString(strconv.Itoa(normalizeTimeoutMinutes(t.TimeoutMinutes)))
-	b.WriteString("\n")
+	b.WriteString("    " + timeoutScalar(t).line() + "\n")
 	b.WriteString("    env:\n")
-	b.WriteString("      TENDER_NAME: ")
-	b.WriteString(strconv.Quote(strings.TrimSpace(t.Name)))
-	b.WriteString("\n")
-	b.WriteString("      TENDER_AGENT: ")
-	b.WriteString(strconv.Quote(strings.TrimSpace(t.Agent)))
-	b.WriteString("\n")
-	b.WriteString("      TENDER_PROMPT: ")
-	b.WriteString(strconv.Quote(strings.TrimSpace(t.Prompt)))
-	b.WriteString("\n")
+	for _, env := range tenderEnv(t) {
+		b.WriteString("      " + env.line() + "\n")
+	}
 	b.WriteString("    steps:\n")
-	b.WriteString("      - uses: actions/checkout@v4\n")
-	b.WriteString("        with:\n")
-	b.WriteString("          fetch-depth: 0\n\n")
-	b.WriteString("      - name: Install OpenCode\n")
-	b.WriteString("        shell: bash\n")
-	b.WriteString("        run: |\n")
-	b.WriteString("          set -euo pipefail\n")
-	b.WriteString("          curl -fsSL https://opencode.ai/install | bash\n")
-	b.WriteString("          echo \"$HOME/bin\" >> \"$GITHUB_PATH\"\n")
-	b.WriteString("          echo \"$HOME/.local/bin\" >> \"$GITHUB_PATH\"\n")
-	b.WriteString("          echo \"$HOME/.opencode/bin\" >> \"$GITHUB_PATH\"\n\n")
-	b.WriteString("      - name: Prepare main\n")
-	b.WriteString("        shell: bash\n")
-	b.WriteString("        run: |\n")
-	b.WriteString("          set -euo pipefail\n")
-	b.WriteString("          git config user.name \"tender[bot]\"\n")
-	b.WriteString("          git config user.email \"tender[bot]@users.noreply.github.com\"\n")
-	b.WriteString("          git fetch origin main\n")
-	b.WriteString("          git checkout -B main origin/main\n\n")
-	b.WriteString("      - name: Run OpenCode\n")
-	b.WriteString("        shell: bash\n")
-	b.WriteString("        env:\n")
-	b.WriteString("          OPENAI_API_KEY: ${{ secrets.OPENAI_API_KEY }}\n")
-	b.WriteString("          ANTHROPIC_API_KEY: ${{ secrets.ANTHROPIC_API_KEY }}\n")
-	b.WriteString("        run: |\n")
-	b.WriteString("          set -euo pipefail\n")
-	b.WriteString("          cd \"$GITHUB_WORKSPACE\"\n")
-	b.WriteString("          if [ -f \"$GITHUB_WORKSPACE/opencode.json\" ]; then export OPENCODE_CONFIG=\"$GITHUB_WORKSPACE/opencode.json\"; fi\n")
-	b.WriteString("          if [ -d \"$GITHUB_WORKSPACE/.opencode\" ]; then export OPENCODE_CONFIG_DIR=\"$GITHUB_WORKSPACE/.opencode\"; fi\n")
-	b.WriteString("          DISPATCH_PROMPT=\"${{ github.event_name == 'workflow_dispatch' && inputs.prompt || '' }}\"\n")
-	b.WriteString("          RUN_PROMPT=\"${DISPATCH_PROMPT:-}\"\n")
-	b.WriteString("          if [ -z \"${RUN_PROMPT}\" ]; then\n")
-	b.WriteString("            RUN_PROMPT=\"${TENDER_PROMPT:-}\"\n")
-	b.WriteString("          fi\n")
-	b.WriteString("          if [ -z \"${RUN_PROMPT}\" ]; then\n")
-	b.WriteString("            RUN_PROMPT=\"Run the tender task '$TENDER_NAME' for this repository.\"\n")
-	b.WriteString("          fi\n")
-	b.WriteString("          opencode run --agent \"$TENDER_AGENT\" \"$RUN_PROMPT\"\n\n")
-	b.WriteString("      - name: Commit and push main\n")
-	b.WriteString("        shell: bash\n")
-	b.WriteString("        run: |\n")
-	b.WriteString("          set -euo pipefail\n")
-	b.WriteString("          CURRENT_BRANCH=\"$(git rev-parse --abbrev-ref HEAD || echo detached)\"\n")
-	b.WriteString("          AHEAD_COUNT=\"$(git rev-list --count origin/main..HEAD || echo 0)\"\n")
-	b.WriteString("          if git diff --quiet --ignore-submodules -- && git diff --cached --quiet --ignore-submodules --; then\n")
-	b.WriteString("            if [ \"$CURRENT_BRANCH\" != \"main\" ] || [ \"$AHEAD_COUNT\" -gt 0 ]; then\n")
-	b.WriteString("              echo \"No working tree changes; pushing existing commits from $CURRENT_BRANCH to main\"\n")
-	b.WriteString("              git pull --rebase origin main\n")
-	b.WriteString("              git push origin HEAD:main\n")
-	b.WriteString("              exit 0\n")
-	b.WriteString("            fi\n")
-	b.WriteString("            echo \"No changes to commit\"\n")
-	b.WriteString("            exit 0\n")
-	b.WriteString("          fi\n")
-	b.WriteString("          git add -A\n")
-	b.WriteString("          git commit -m \"tender($TENDER_NAME): autonomous update\"\n")
-	b.WriteString("          git pull --rebase origin main\n")
-	b.WriteString("          git push origin HEAD:main\n")
+	for i, step := range renderSteps(t) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeIndented(&b, "      ", step.Lines)
+	}
 	return b.String()
 }
 
-func parseTenderWorkflow(content string) (Tender, bool) {
-	var t Tender
-	lines := strings.Split(content, "\n")
-	hasRun := false
-	hasAgent := false
-	hasTopName := false
-	for _, line := range lines {
-		trim := strings.TrimSpace(line)
-		switch {
-		case strings.HasPrefix(trim, "name:") && !strings.HasPrefix(trim, "- name:"):
-			raw := strings.TrimSpace(strings.TrimPrefix(trim, "name:"))
-			name := parseQuotedValue(raw)
-			if strings.HasPrefix(name, "tender/") {
-				t.Name = strings.TrimPrefix(name, "tender/")
-				hasTopName = true
-			}
-		case trim == "workflow_dispatch:":
-			t.Manual = true
-		case trim == "push:":
-			t.Push = true
-		case strings.HasPrefix(trim, "- cron:"):
-			t.Cron = parseQuotedValue(strings.TrimSpace(strings.TrimPrefix(trim, "- cron:")))
-		case strings.HasPrefix(trim, "TENDER_AGENT:"):
-			t.Agent = parseQuotedValue(strings.TrimSpace(strings.TrimPrefix(trim, "TENDER_AGENT:")))
-			hasAgent = strings.TrimSpace(t.Agent) != ""
-		case strings.HasPrefix(trim, "TENDER_PROMPT:"):
-			t.Prompt = parseQuotedValue(strings.TrimSpace(strings.TrimPrefix(trim, "TENDER_PROMPT:")))
-		case strings.HasPrefix(trim, "timeout-minutes:"):
-			timeoutRaw := strings.TrimSpace(strings.TrimPrefix(trim, "timeout-minutes:"))
-			timeout, err := strconv.Atoi(timeoutRaw)
-			if err == nil && timeout > 0 {
-				t.TimeoutMinutes = timeout
-			}
-		case strings.Contains(trim, "opencode run"):
-			hasRun = true
+// renderSteps returns the job steps tender generates, keyed by stepKey.
+func renderSteps(t Tender) []workflowBlock {
+	branch := normalizeBranch(t.Branch)
+	steps := []workflowBlock{
+		{Key: "uses:actions/checkout@v4", Lines: []string{
+			"- uses: actions/checkout@v4",
+			"  with:",
+			"    fetch-depth: 0",
+		}},
+		installStep(t),
+		{Key: "Prepare " + branch, Lines: []string{
+			"- name: Prepare " + branch,
+			"  shell: bash",
+			"  run: |",
+			"    set -euo pipefail",
+			"    git config user.name \"tender[bot]\"",
+			"    git config user.email \"" + tenderBotEmail + "\"",
+			"    git fetch origin " + branch,
+			"    git checkout -B " + branch + " origin/" + branch,
+		}},
+	}
+	steps = append(steps, chainCheckStep(t)...)
+	work := []workflowBlock{runOpenCodeStep(t)}
+	if hasGuardrails(t) {
+		work = append(work, guardrailStep(t))
+	}
+	if t.SecretScan {
+		work = append(work, secretScanStep(t))
+	}
+	if strings.TrimSpace(t.Verify) != "" {
+		work = append(work, verifyStep(t))
+	}
+	work = append(work, deliveryStep(t))
+	if strings.TrimSpace(t.Verify) != "" && normalizeVerifyFailure(t.VerifyFailure) == VerifyBranch {
+		work = append(work, verifyFailureStep(t))
+	}
+	for _, step := range work {
+		steps = append(steps, gateOnUpstreamPush(t, step))
+	}
+	return steps
+}
+
+// installStep installs OpenCode and, for tenders that scan for secrets, the
+// tender CLI at Version, so later steps need no network to run it.
+func installStep(t Tender) workflowBlock {
+	lines := []string{
+		"- name: Install OpenCode",
+		"  shell: bash",
+		"  run: |",
+		"    set -euo pipefail",
+		"    curl -fsSL https://opencode.ai/install | bash",
+		"    echo \"$HOME/bin\" >> \"$GITHUB_PATH\"",
+		"    echo \"$HOME/.local/bin\" >> \"$GITHUB_PATH\"",
+		"    echo \"$HOME/.opencode/bin\" >> \"$GITHUB_PATH\"",
+	}
+	if t.SecretScan {
+		// The npm launcher downloads its binary on first use; running it here
+		// caches the binary before the agent runs.
+		lines = append(lines,
+			"    npm install --global @susu-eng/tender@"+Version,
+			"    tender help scan-diff > /dev/null",
+		)
+	}
+	return workflowBlock{Key: "Install OpenCode", Lines: lines}
+}
+
+// runOpenCodeStep runs the agent. Tenders with a model pass it through
+// --model and also receive their provider's API key secret.
+func runOpenCodeStep(t Tender) workflowBlock {
+	secrets := []string{"OPENAI_API_KEY", "ANTHROPIC_API_KEY"}
+	modelRef := ""
+	if model := strings.TrimSpace(t.Model); model != "" {
+		if secret := providerSecretName(tenderProvider(t)); secret != secrets[0] && secret != secrets[1] {
+			secrets = append(secrets, secret)
 		}
+		modelRef = "\"$TENDER_MODEL\""
 	}
-	if !hasTopName || !hasAgent || !hasRun {
-		return Tender{}, false
-	}
-	if strings.TrimSpace(t.Name) == "" {
-		t.Name = strings.TrimSpace(t.Agent)
+	command := "    opencode " + strings.Join(openCodeRunArgs("\"$TENDER_AGENT\"", modelRef, "\"$RUN_PROMPT\""), " ")
+	// A prompt file is read from the checkout, so edits land with the commit
+	// that makes them; a missing file fails the run.
+	tenderPrompt := "\"${TENDER_PROMPT:-}\""
+	if strings.TrimSpace(t.PromptFile) != "" {
+		tenderPrompt = "\"$(cat \"$GITHUB_WORKSPACE/$TENDER_PROMPT_FILE\")\""
 	}
-	t.TimeoutMinutes = normalizeTimeoutMinutes(t.TimeoutMinutes)
-	return t, true
+
+	lines := []string{
+		"- name: Run OpenCode",
+		"  shell: bash",
+		"  env:",
+	}
+	for _, secret := range secrets {
+		lines = append(lines, "    "+secret+": ${{ secrets."+secret+" }}")
+	}
+	lines = append(lines, inputEnvLines(t.Inputs)...)
+	lines = append(lines, eventEnvLines(t)...)
+	lines = append(lines,
+		"  run: |",
+		"    set -euo pipefail",
+		"    cd \"$GITHUB_WORKSPACE\"",
+		"    if [ -f \"$GITHUB_WORKSPACE/opencode.json\" ]; then export OPENCODE_CONFIG=\"$GITHUB_WORKSPACE/opencode.json\"; fi",
+		"    if [ -d \"$GITHUB_WORKSPACE/.opencode\" ]; then export OPENCODE_CONFIG_DIR=\"$GITHUB_WORKSPACE/.opencode\"; fi",
+	)
+	lines = append(lines,
+		"    DISPATCH_PROMPT=\"${{ github.event_name == 'workflow_dispatch' && inputs.prompt || '' }}\"",
+		"    RUN_PROMPT=\"${DISPATCH_PROMPT:-}\"",
+		"    if [ -z \"${RUN_PROMPT}\" ]; then",
+		"      RUN_PROMPT="+tenderPrompt,
+		"    fi",
+		"    if [ -z \"${RUN_PROMPT}\" ]; then",
+		"      RUN_PROMPT=\""+fmt.Sprintf(fallbackPromptFormat, "$TENDER_NAME")+"\"",
+		"    fi",
+	)
+	if usesPromptTemplate(t) {
+		lines = append(lines, promptTemplateLines(t)...)
+	}
+	return workflowBlock{Key: "Run OpenCode", Lines: append(lines, command)}
+}
+
+// openCodeRunArgs is the `opencode run` argv shared by generated workflows and
+// local runs; model is omitted when empty.
+func openCodeRunArgs(agent, model, prompt string) []string {
+	args := []string{"run", "--agent", agent}
+	if model != "" {
+		args = append(args, "--model", model)
+	}
+	return append(args, prompt)
+}
+
+// deliveryStep lands the run's changes: pushed straight to the target branch,
+// or committed to a per-run branch with a pull request opened (or refreshed on
+// re-runs).
+func deliveryStep(t Tender) workflowBlock {
+	branch := normalizeBranch(t.Branch)
+	if normalizeDelivery(t.Delivery) == DeliveryPR {
+		return workflowBlock{Key: "Open pull request", Lines: []string{
+			"- name: Open pull request",
+			"  shell: bash",
+			"  env:",
+			"    GH_TOKEN: ${{ github.token }}",
+			"  run: |",
+			"    set -euo pipefail",
+			"    BRANCH=\"tender/" + Slugify(t.Name) + "/${GITHUB_RUN_ID}\"",
+			"    if [ -z \"$(git status --porcelain --ignore-submodules)\" ] && [ \"$(git rev-list --count origin/" + branch + "..HEAD || echo 0)\" -eq 0 ]; then",
+			"      echo \"No changes to deliver\"",
+			"      exit 0",
+			"    fi",
+			"    git checkout -B \"$BRANCH\"",
+			"    git add -A",
+			"    if ! git diff --cached --quiet --ignore-submodules --; then",
+			"      git commit -m \"" + fmt.Sprintf(commitMessageFormat, "$TENDER_NAME") + "\"",
+			"    fi",
+			"    git push --force origin \"HEAD:refs/heads/$BRANCH\"",
+			"    TITLE=\"" + fmt.Sprintf(commitMessageFormat, "$TENDER_NAME") + "\"",
+			"    BODY=\"$(printf 'Automated update from tender %s using agent %s.\\n\\nRun: %s/%s/actions/runs/%s\\n' \"$TENDER_NAME\" \"$TENDER_AGENT\" \"$GITHUB_SERVER_URL\" \"$GITHUB_REPOSITORY\" \"$GITHUB_RUN_ID\")\"",
+			"    PR_NUMBER=\"$(gh pr list --head \"$BRANCH\" --base " + branch + " --state open --json number --jq '.[0].number // empty')\"",
+			"    if [ -n \"$PR_NUMBER\" ]; then",
+			"      gh pr edit \"$PR_NUMBER\" --title \"$TITLE\" --body \"$BODY\"",
+			"    else",
+			"      gh pr create --base " + branch + " --head \"$BRANCH\" --title \"$TITLE\" --body \"$BODY\"",
+			"    fi",
+		}}
+	}
+	return workflowBlock{Key: "Commit and push " + branch, Lines: []string{
+		"- name: Commit and push " + branch,
+		"  shell: bash",
+		"  run: |",
+		"    set -euo pipefail",
+		"    CURRENT_BRANCH=\"$(git rev-parse --abbrev-ref HEAD || echo detached)\"",
+		"    AHEAD_COUNT=\"$(git rev-list --count origin/" + branch + "..HEAD || echo 0)\"",
+		"    if [ -z \"$(git status --porcelain --ignore-submodules)\" ]; then",
+		"      if [ \"$CURRENT_BRANCH\" != \"" + branch + "\" ] || [ \"$AHEAD_COUNT\" -gt 0 ]; then",
+		"        echo \"No working tree changes; pushing existing commits from $CURRENT_BRANCH to " + branch + "\"",
+		"        git pull --rebase origin " + branch,
+		"        git push origin HEAD:" + branch,
+		"        exit 0",
+		"      fi",
+		"      echo \"No changes to commit\"",
+		"      exit 0",
+		"    fi",
+		"    git add -A",
+		"    git commit -m \"" + fmt.Sprintf(commitMessageFormat, "$TENDER_NAME") + "\"",
+		"    git pull --rebase origin " + branch,
+		"    git push origin HEAD:" + branch,
+	}}
 }
 
 func parseQuotedValue(raw string) string {
@@ -264,7 +543,10 @@ func parseQuotedValue(raw string) string {
 	return strings.Trim(raw, "\"'")
 }
 
-func ValidateTender(t Tender) error {
+// ValidateTender checks a tender's settings. When the loaded tenders are
+// passed too, its upstream tender must be one of them and must not lead back
+// to it.
+func ValidateTender(t Tender, tenders ...Tender) error {
 	name := strings.TrimSpace(t.Name)
 	if name == "" {
 		return fmt.Errorf("name is required")
@@ -278,20 +560,97 @@ func ValidateTender(t Tender) error {
 	if strings.Contains(name, "/") {
 		return fmt.Errorf("name cannot contain '/'")
 	}
-	if strings.TrimSpace(t.Cron) != "" {
-		if len(strings.Fields(t.Cron)) != 5 {
-			return fmt.Errorf("cron must have 5 fields")
+	if err := validatePromptFile(strings.TrimSpace(t.PromptFile)); err != nil {
+		return err
+	}
+	if strings.TrimSpace(t.Prompt) != "" && strings.TrimSpace(t.PromptFile) != "" {
+		return fmt.Errorf("set either a prompt or a prompt file, not both")
+	}
+	if err := validateInputs(t.Inputs); err != nil {
+		return err
+	}
+	if len(t.Inputs) > 0 && !t.Manual {
+		return fmt.Errorf("inputs need manual runs; enable workflow_dispatch")
+	}
+	if err := validateEventTriggers(t); err != nil {
+		return err
+	}
+	if err := validateAfter(t); err != nil {
+		return err
+	}
+	if tenders != nil {
+		if err := validateChain(t, tenders); err != nil {
+			return err
 		}
 	}
+	if err := validatePromptPlaceholders(t.Prompt, t); err != nil {
+		return err
+	}
+	if err := validateCrons(t.Crons); err != nil {
+		return err
+	}
+	if err := validateTimezone(t); err != nil {
+		return err
+	}
 	if t.TimeoutMinutes < 0 {
 		return fmt.Errorf("timeout-minutes must be greater than 0")
 	}
-	if !t.Manual && !t.Push && strings.TrimSpace(t.Cron) == "" {
+	if d := normalizeDelivery(t.Delivery); d != DeliveryPush && d != DeliveryPR {
+		return fmt.Errorf("delivery must be %q or %q", DeliveryPush, DeliveryPR)
+	}
+	if err := validateBranch(t.Branch); err != nil {
+		return err
+	}
+	if err := validateGuardrails(t); err != nil {
+		return err
+	}
+	if err := validateVerify(t); err != nil {
+		return err
+	}
+	if err := validateModel(t.Model, t.Provider); err != nil {
+		return err
+	}
+	if !t.Manual && !t.Push && len(normalizeCrons(t.Crons)) == 0 && !hasEventTriggers(t) && strings.TrimSpace(t.After) == "" {
 		return fmt.Errorf("enable manual or set a schedule")
 	}
 	return nil
 }
 
+// validateCrons checks every schedule of a tender; listing one twice is
+// rejected since GitHub would start a single run for both.
+func validateCrons(crons []string) error {
+	seen := map[string]bool{}
+	for _, c := range normalizeCrons(crons) {
+		if err := cron.Validate(c); err != nil {
+			return err
+		}
+		if seen[c] {
+			return fmt.Errorf("cron %q is listed twice", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// validateBranch accepts branch names that are safe to splice into the
+// generated YAML and shell steps; an empty branch means DefaultBranch.
+func validateBranch(branch string) error {
+	b := strings.TrimSpace(branch)
+	if b == "" {
+		return nil
+	}
+	for _, r := range b {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && !strings.ContainsRune("._/-", r) {
+			return fmt.Errorf("branch %q may only contain letters, digits, '.', '_', '-' and '/'", b)
+		}
+	}
+	if strings.HasPrefix(b, "-") || strings.HasPrefix(b, "/") || strings.HasSuffix(b, "/") ||
+		strings.HasSuffix(b, ".") || strings.HasSuffix(b, ".lock") || strings.Contains(b, "..") || strings.Contains(b, "//") {
+		return fmt.Errorf("branch %q is not a valid git branch name", b)
+	}
+	return nil
+}
+
 func Slugify(s string) string {
 	s = strings.ToLower(strings.TrimSpace(s))
 	var out []rune
@@ -325,58 +684,124 @@ func findTenderIndex(tenders []Tender, name string) int {
 }
 
 func PrintList(root string, stdout io.Writer) error {
-	tenders, err := LoadTenders(root)
+	tenders, malformed, err := LoadTenderWorkflows(root)
 	if err != nil {
 		return err
 	}
 	if len(tenders) == 0 {
 		_, _ = fmt.Fprintln(stdout, "No managed tender workflows found.")
-		return nil
+	} else {
+		now := time.Now()
+		_, _ = fmt.Fprintln(stdout, "NAME\tAGENT\tTRIGGER\tNEXT RUN\tWORKFLOW")
+		for _, t := range tenders {
+			_, _ = fmt.Fprintf(stdout, "%s\t%s\t%s\t%s\t%s\n", t.Name, t.Agent, tenderTriggerSummary(t), nextRunSummary(t, now), t.WorkflowFile)
+		}
 	}
-	_, _ = fmt.Fprintln(stdout, "NAME\tAGENT\tTRIGGER\tWORKFLOW")
-	for _, t := range tenders {
-		_, _ = fmt.Fprintf(stdout, "%s\t%s\t%s\t%s\n", t.Name, t.Agent, TriggerSummary(t.Cron, t.Manual, t.Push), t.WorkflowFile)
+	if len(malformed) > 0 {
+		_, _ = fmt.Fprintln(stdout)
+		_, _ = fmt.Fprintln(stdout, "Skipped malformed tender workflows:")
+		for _, m := range malformed {
+			_, _ = fmt.Fprintf(stdout, "  %s: %v\n", m.WorkflowFile, m.Err)
+		}
 	}
 	return nil
 }
 
-func TriggerSummary(cron string, manual bool, push bool) string {
-	schedule := ""
-	if strings.TrimSpace(cron) != "" {
-		if d, ok := scheduleDefaultsFromCron(cron); ok {
-			switch d.Mode {
-			case "hourly":
-				schedule = fmt.Sprintf("every hour at :%02d UTC", d.Minute)
-			case "daily":
-				schedule = fmt.Sprintf("daily at %02d:%02d UTC", d.Hour, d.Minute)
-			case "weekly":
-				dayNames := make([]string, 0, len(d.Days))
-				for _, day := range d.Days {
-					dayNames = append(dayNames, weekdayName(day))
-				}
-				schedule = fmt.Sprintf("weekly %s at %02d:%02d UTC", strings.Join(dayNames, ","), d.Hour, d.Minute)
-			}
-		} else {
-			schedule = "scheduled"
+// nextRunSummary is when any of a tender's schedules next fires after now:
+// "-" for unscheduled tenders and "invalid cron" when a schedule does not
+// parse.
+func nextRunSummary(t Tender, now time.Time) string {
+	crons := normalizeCrons(t.Crons)
+	if len(crons) == 0 {
+		return "-"
+	}
+	next, err := nextCronRun(crons, now)
+	if err != nil {
+		return "invalid cron"
+	}
+	if next.IsZero() {
+		return "never"
+	}
+	if loc, err := LoadTimezone(t.Timezone); err == nil {
+		return next.In(loc).Format("Mon 2006-01-02 15:04 MST")
+	}
+	return next.Format("Mon 2006-01-02 15:04 UTC")
+}
+
+// nextCronRun is the earliest fire time of any of crons after now, or the
+// zero time when none fires.
+func nextCronRun(crons []string, now time.Time) (time.Time, error) {
+	var next time.Time
+	for _, expr := range crons {
+		schedule, err := cron.Parse(expr)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if n := schedule.Next(now); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
 		}
 	}
+	return next, nil
+}
+
+func TriggerSummary(crons []string, manual bool, push bool) string {
+	return triggerSummary(crons, "UTC", manual, push, DefaultBranch, nil)
+}
+
+// tenderTriggerSummary describes a timezone tender's schedules as written, in
+// its zone, rather than the UTC crons they were converted to.
+func tenderTriggerSummary(t Tender) string {
+	if timezone := strings.TrimSpace(t.Timezone); timezone != "" {
+		return triggerSummary(t.LocalCrons, timezone, t.Manual, t.Push, normalizeBranch(t.Branch), triggerEventSummaries(t))
+	}
+	return triggerSummary(t.Crons, "UTC", t.Manual, t.Push, normalizeBranch(t.Branch), triggerEventSummaries(t))
+}
+
+func triggerEventSummaries(t Tender) []string {
+	return append(eventTriggerSummaries(t), chainTriggerSummaries(t)...)
+}
 
-	parts := make([]string, 0, 3)
-	if schedule != "" {
-		parts = append(parts, schedule)
+func triggerSummary(crons []string, zone string, manual bool, push bool, branch string, events []string) string {
+	parts := make([]string, 0, 3+len(events))
+	for _, c := range normalizeCrons(crons) {
+		parts = append(parts, scheduleSummary(c, zone))
 	}
 	if push {
-		parts = append(parts, "on-push(main)")
+		parts = append(parts, "on-push("+branch+")")
 	}
 	if manual {
 		parts = append(parts, "on-demand")
 	}
+	parts = append(parts, events...)
 	if len(parts) == 0 {
 		return "none"
 	}
 	return strings.Join(parts, " + ")
 }
 
+// scheduleSummary describes one cron written in zone, preferring the short
+// forms of the TUI presets.
+func scheduleSummary(cronExpr string, zone string) string {
+	if d, ok := scheduleDefaultsFromCron(cronExpr); ok {
+		switch d.Mode {
+		case "hourly":
+			return fmt.Sprintf("every hour at :%02d %s", d.Minute, zone)
+		case "daily":
+			return fmt.Sprintf("daily at %02d:%02d %s", d.Hour, d.Minute, zone)
+		case "weekly":
+			dayNames := make([]string, 0, len(d.Days))
+			for _, day := range d.Days {
+				dayNames = append(dayNames, weekdayName(day))
+			}
+			return fmt.Sprintf("weekly %s at %02d:%02d %s", strings.Join(dayNames, ","), d.Hour, d.Minute, zone)
+		}
+	}
+	if parsed, err := cron.Parse(cronExpr); err == nil {
+		return parsed.DescribeIn(zone)
+	}
+	return "scheduled"
+}
+
 func weekdayName(day int) string {
 	switch day {
 	case 0:
@@ -430,6 +855,9 @@ func SaveNewTender(root string, t Tender) (Tender, error) {
 		return Tender{}, err
 	}
 	t.WorkflowFile = wf
+	if err := ValidateTender(t, current...); err != nil {
+		return Tender{}, err
+	}
 	if err := SaveTender(root, t); err != nil {
 		return Tender{}, err
 	}
@@ -453,6 +881,14 @@ func UpdateTender(root string, oldName string, updated Tender) error {
 			return fmt.Errorf("tender %q already exists", updated.Name)
 		}
 	}
+	if !strings.EqualFold(strings.TrimSpace(updated.Name), strings.TrimSpace(tenders[idx].Name)) {
+		if dependents := chainDependents(tenders, tenders[idx].Name); len(dependents) > 0 {
+			return fmt.Errorf("cannot rename %q: %s run(s) after it; update them first", tenders[idx].Name, strings.Join(dependents, ", "))
+		}
+	}
+	if err := ValidateTender(updated, tenders...); err != nil {
+		return err
+	}
 	updated.WorkflowFile = tenders[idx].WorkflowFile
 	return SaveTender(root, updated)
 }
@@ -473,12 +909,13 @@ func SortedCrons(tenders []Tender) []string {
 	seen := map[string]bool{}
 	out := make([]string, 0)
 	for _, t := range tenders {
-		c := strings.TrimSpace(t.Cron)
-		if c == "" || seen[c] {
-			continue
+		for _, c := range normalizeCrons(t.Crons) {
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+			out = append(out, c)
 		}
-		seen[c] = true
-		out = append(out, c)
 	}
 	sort.Strings(out)
 	return out
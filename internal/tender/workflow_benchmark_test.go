@@ -53,7 +53,7 @@ func BenchmarkSaveTender(b *testing.B) {
 		Name:   "benchmark-test",
 		Agent:  "Build",
 		Manual: true,
-		Cron:   "0 9 * * *",
+		Crons:  []string{"0 9 * * *"},
 	}
 
 	b.ResetTimer()
@@ -74,7 +74,7 @@ func BenchmarkRenderWorkflow(b *testing.B) {
 		Agent:  "Build",
 		Prompt: "This is a test prompt for benchmarking workflow rendering performance",
 		Manual: true,
-		Cron:   "30 14 * * 1,3,5",
+		Crons:  []string{"30 14 * * 1,3,5"},
 	}
 
 	b.ResetTimer()
@@ -168,9 +168,9 @@ jobs:
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		_, ok := parseTenderWorkflow(workflow)
-		if !ok {
-			b.Fatal("failed to parse workflow")
+		_, err := parseTenderWorkflow(workflow)
+		if err != nil {
+			b.Fatalf("failed to parse workflow: %v", err)
 		}
 	}
 }
@@ -234,7 +234,7 @@ func BenchmarkTriggerSummary(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
 		tc := testCases[i%len(testCases)]
-		_ = TriggerSummary(tc.cron, tc.manual, tc.push)
+		_ = TriggerSummary([]string{tc.cron}, tc.manual, tc.push)
 	}
 }
 
@@ -244,7 +244,7 @@ func BenchmarkValidateTender(b *testing.B) {
 		Agent:  "Build",
 		Prompt: "Test prompt for validation benchmark",
 		Manual: true,
-		Cron:   "0 9 * * *",
+		Crons:  []string{"0 9 * * *"},
 	}
 
 	b.ResetTimer()
@@ -316,7 +316,7 @@ func setupBenchmarkRepo(b *testing.B, numTenders int) string {
 			Name:   fmt.Sprintf("tender-%03d", i),
 			Agent:  []string{"Build", "Test", "Deploy"}[i%3],
 			Manual: i%2 == 0,
-			Cron:   fmt.Sprintf("%d %d * * *", i%60, i%24),
+			Crons:  []string{fmt.Sprintf("%d %d * * *", i%60, i%24)},
 		}
 		if _, err := SaveNewTender(root, tender); err != nil {
 			b.Fatalf("failed to save benchmark tender %d: %v", i, err)
@@ -333,7 +333,7 @@ func BenchmarkMemoryAllocation_RenderWorkflow(b *testing.B) {
 		Agent:  "Build",
 		Prompt: "This is a test prompt to check memory allocation during workflow rendering",
 		Manual: true,
-		Cron:   "30 14 * * 1,3,5",
+		Crons:  []string{"30 14 * * 1,3,5"},
 	}
 
 	b.ReportAllocs()
@@ -0,0 +1,293 @@
+package tender
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"tender/internal/cron"
+)
+
+const (
+	tenderNamePrefix = "tender/"
+	tenderJobName    = "tender"
+)
+
+// errNotTenderWorkflow marks workflow files that never claimed to be a tender
+// (for example a repository's regular CI). LoadTenders skips these quietly;
+// every other parse error is reported as a malformed tender.
+var errNotTenderWorkflow = errors.New("not a tender workflow")
+
+// workflowDocument is the subset of a GitHub Actions workflow tender reads.
+type workflowDocument struct {
+	Name string                 `yaml:"name"`
+	On   yaml.Node              `yaml:"on"`
+	Jobs map[string]workflowJob `yaml:"jobs"`
+}
+
+type workflowJob struct {
+	If             string            `yaml:"if"`
+	TimeoutMinutes string            `yaml:"timeout-minutes"`
+	Env            map[string]string `yaml:"env"`
+	Steps          []workflowStep    `yaml:"steps"`
+}
+
+type workflowStep struct {
+	Name string `yaml:"name"`
+	Uses string `yaml:"uses"`
+	Run  string `yaml:"run"`
+}
+
+type workflowScheduleEntry struct {
+	Cron string `yaml:"cron"`
+}
+
+type workflowRunTrigger struct {
+	Workflows []string `yaml:"workflows"`
+}
+
+// parseTenderWorkflow decodes a workflow file into a Tender. The returned error
+// explains why the file was not recognised; it wraps errNotTenderWorkflow when
+// the file does not declare itself as a tender at all.
+func parseTenderWorkflow(content string) (Tender, error) {
+	var doc workflowDocument
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		if !looksLikeTender(content) {
+			return Tender{}, fmt.Errorf("%w: invalid YAML: %v", errNotTenderWorkflow, err)
+		}
+		return Tender{}, fmt.Errorf("invalid YAML: %v", err)
+	}
+
+	name := strings.TrimSpace(doc.Name)
+	if name == "" {
+		return Tender{}, fmt.Errorf("%w: missing top-level name", errNotTenderWorkflow)
+	}
+	if !strings.HasPrefix(name, tenderNamePrefix) {
+		return Tender{}, fmt.Errorf("%w: name %q does not start with %q", errNotTenderWorkflow, name, tenderNamePrefix)
+	}
+
+	var t Tender
+	t.Name = strings.TrimSpace(strings.TrimPrefix(name, tenderNamePrefix))
+
+	if err := applyWorkflowTriggers(&t, &doc.On); err != nil {
+		return Tender{}, err
+	}
+
+	jobKey, job, err := findTenderJob(doc.Jobs)
+	if err != nil {
+		return Tender{}, err
+	}
+	t.Agent = strings.TrimSpace(job.Env["TENDER_AGENT"])
+	if t.Agent == "" {
+		return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_AGENT is not set", jobKey)
+	}
+	t.Prompt = job.Env["TENDER_PROMPT"]
+	t.PromptFile = strings.TrimSpace(job.Env["TENDER_PROMPT_FILE"])
+	if err := validatePromptFile(t.PromptFile); err != nil {
+		return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_PROMPT_FILE: %v", jobKey, err)
+	}
+	if !stepsRunOpenCode(job.Steps) {
+		return Tender{}, fmt.Errorf("no step in jobs.%s runs `opencode run`", jobKey)
+	}
+
+	t.Delivery = normalizeDelivery(job.Env["TENDER_DELIVERY"])
+	if t.Delivery != DeliveryPush && t.Delivery != DeliveryPR {
+		return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_DELIVERY must be %q or %q, got %q", jobKey, DeliveryPush, DeliveryPR, job.Env["TENDER_DELIVERY"])
+	}
+
+	t.Model = strings.TrimSpace(job.Env["TENDER_MODEL"])
+	t.Provider = strings.TrimSpace(job.Env["TENDER_PROVIDER"])
+	if err := validateModel(t.Model, t.Provider); err != nil {
+		return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_MODEL: %v", jobKey, err)
+	}
+	// Workflows written before TENDER_PROVIDER only name the model.
+	t.Provider = tenderProvider(t)
+
+	t.Branch = normalizeBranch(job.Env["TENDER_BRANCH"])
+	if err := validateBranch(t.Branch); err != nil {
+		return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_BRANCH: %v", jobKey, err)
+	}
+
+	if timezone := strings.TrimSpace(job.Env["TENDER_TIMEZONE"]); timezone != "" {
+		if _, err := LoadTimezone(timezone); err != nil {
+			return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_TIMEZONE: %v", jobKey, err)
+		}
+		local := parseLocalCrons(job.Env["TENDER_LOCAL_CRON"])
+		if len(local) == 0 {
+			return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_LOCAL_CRON is not set", jobKey)
+		}
+		for _, c := range local {
+			if _, err := cron.Parse(c); err != nil {
+				return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_LOCAL_CRON: %v", jobKey, err)
+			}
+		}
+		if len(t.Crons) == 0 {
+			return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_TIMEZONE is set but on.schedule has no cron", jobKey)
+		}
+		t.Timezone, t.LocalCrons = timezone, local
+	}
+
+	if err := parseGuardrailEnv(&t, job.Env); err != nil {
+		return Tender{}, fmt.Errorf("jobs.%s.env: %v", jobKey, err)
+	}
+	if scan := strings.TrimSpace(job.Env["TENDER_SECRET_SCAN"]); scan != "" {
+		if scan != "true" {
+			return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_SECRET_SCAN must be \"true\", got %q", jobKey, scan)
+		}
+		t.SecretScan = true
+	}
+	t.Verify = strings.TrimSpace(job.Env["TENDER_VERIFY"])
+	if failure := strings.TrimSpace(job.Env["TENDER_VERIFY_FAILURE"]); failure != "" {
+		if t.Verify == "" {
+			return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_VERIFY_FAILURE is set but TENDER_VERIFY is not", jobKey)
+		}
+		t.VerifyFailure = normalizeVerifyFailure(failure)
+		if t.VerifyFailure != VerifyDiscard && t.VerifyFailure != VerifyBranch {
+			return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_VERIFY_FAILURE must be %q or %q, got %q", jobKey, VerifyDiscard, VerifyBranch, failure)
+		}
+	}
+
+	if when := strings.TrimSpace(job.Env["TENDER_AFTER_WHEN"]); when != "" {
+		if t.After == "" {
+			return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_AFTER_WHEN is set but on.workflow_run names no tender", jobKey)
+		}
+		t.AfterWhen = normalizeAfterWhen(when)
+		if t.AfterWhen != AfterSuccess && t.AfterWhen != AfterPushed {
+			return Tender{}, fmt.Errorf("jobs.%s.env.TENDER_AFTER_WHEN must be %q or %q, got %q", jobKey, AfterSuccess, AfterPushed, when)
+		}
+	}
+
+	if raw := strings.TrimSpace(job.TimeoutMinutes); raw != "" {
+		timeout, err := strconv.Atoi(raw)
+		if err != nil || timeout <= 0 {
+			return Tender{}, fmt.Errorf("jobs.%s.timeout-minutes must be a positive integer, got %q", jobKey, raw)
+		}
+		t.TimeoutMinutes = timeout
+	}
+
+	if t.Name == "" {
+		t.Name = t.Agent
+	}
+	t.TimeoutMinutes = normalizeTimeoutMinutes(t.TimeoutMinutes)
+	return t, nil
+}
+
+// looksLikeTender decides whether an unparseable file should be reported as a
+// broken tender rather than ignored as somebody else's workflow.
+func looksLikeTender(content string) bool {
+	return strings.Contains(content, "TENDER_AGENT:") || strings.Contains(content, tenderNamePrefix)
+}
+
+func applyWorkflowTriggers(t *Tender, on *yaml.Node) error {
+	switch on.Kind {
+	case 0:
+		return fmt.Errorf("missing top-level `on` triggers")
+	case yaml.ScalarNode:
+		return applyWorkflowTrigger(t, on.Value, nil)
+	case yaml.SequenceNode:
+		for _, item := range on.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("on: list entries must be event names")
+			}
+			if err := applyWorkflowTrigger(t, item.Value, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			if err := applyWorkflowTrigger(t, on.Content[i].Value, on.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("on: unsupported trigger syntax")
+	}
+}
+
+func applyWorkflowTrigger(t *Tender, event string, value *yaml.Node) error {
+	switch event {
+	case "workflow_dispatch":
+		t.Manual = true
+		inputs, err := parseDispatchInputs(value)
+		if err != nil {
+			return err
+		}
+		t.Inputs = inputs
+	case "push":
+		t.Push = true
+	case "issues":
+		t.IssueLabel = true
+	case "issue_comment":
+		t.PRComment = true
+	case "pull_request":
+		t.PullRequest = true
+	case "workflow_run":
+		after, err := parseChainTrigger(value)
+		if err != nil {
+			return err
+		}
+		t.After = after
+	case "schedule":
+		if value == nil {
+			return fmt.Errorf("on.schedule requires at least one cron entry")
+		}
+		var entries []workflowScheduleEntry
+		if err := value.Decode(&entries); err != nil {
+			return fmt.Errorf("on.schedule must be a list of cron entries")
+		}
+		for _, entry := range entries {
+			if c := strings.TrimSpace(entry.Cron); c != "" {
+				t.Crons = append(t.Crons, c)
+			}
+		}
+	}
+	return nil
+}
+
+// findTenderJob returns the job that runs the tender. Generated workflows name
+// it "tender"; a renamed job is still accepted when it is the only one that
+// declares TENDER_AGENT.
+func findTenderJob(jobs map[string]workflowJob) (string, workflowJob, error) {
+	if len(jobs) == 0 {
+		return "", workflowJob{}, fmt.Errorf("missing jobs")
+	}
+	if job, ok := jobs[tenderJobName]; ok {
+		return tenderJobName, job, nil
+	}
+	candidates := make([]string, 0, 1)
+	for key, job := range jobs {
+		if _, ok := job.Env["TENDER_AGENT"]; ok {
+			candidates = append(candidates, key)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return "", workflowJob{}, fmt.Errorf("missing jobs.%s", tenderJobName)
+	case 1:
+		return candidates[0], jobs[candidates[0]], nil
+	default:
+		sort.Strings(candidates)
+		return "", workflowJob{}, fmt.Errorf("missing jobs.%s and several jobs declare TENDER_AGENT (%s)", tenderJobName, strings.Join(candidates, ", "))
+	}
+}
+
+func stepsRunOpenCode(steps []workflowStep) bool {
+	for _, step := range steps {
+		for _, line := range strings.Split(step.Run, "\n") {
+			line = strings.TrimSpace(line)
+			if strings.HasPrefix(line, "#") {
+				continue
+			}
+			if strings.Contains(line, "opencode run") {
+				return true
+			}
+		}
+	}
+	return false
+}
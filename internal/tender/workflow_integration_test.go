@@ -20,7 +20,7 @@ func TestWorkflowIntegration_CompleteLifecycle(t *testing.T) {
 			Agent:  "Build",
 			Prompt: "Initial test prompt",
 			Manual: true,
-			Cron:   "0 9 * * *", // Daily at 9:00 UTC
+			Crons:  []string{"0 9 * * *"}, // Daily at 9:00 UTC
 		}
 
 		saved, err := SaveNewTender(root, original)
@@ -51,8 +51,8 @@ func TestWorkflowIntegration_CompleteLifecycle(t *testing.T) {
 			Name:   "renamed-integration-test",
 			Agent:  "Test",
 			Prompt: "Updated test prompt",
-			Manual: false,             // Change to schedule-only
-			Cron:   "30 14 * * 1,3,5", // Mon,Wed,Fri at 14:30 UTC
+			Manual: false,                       // Change to schedule-only
+			Crons:  []string{"30 14 * * 1,3,5"}, // Mon,Wed,Fri at 14:30 UTC
 		}
 
 		err = UpdateTender(root, "integration-test", updated)
@@ -124,19 +124,19 @@ func TestWorkflowIntegration_MultipleTenders(t *testing.T) {
 				Name:   "daily-build",
 				Agent:  "Build",
 				Manual: true,
-				Cron:   "0 8 * * *", // Daily at 8:00 UTC + manual
+				Crons:  []string{"0 8 * * *"}, // Daily at 8:00 UTC + manual
 			},
 			{
 				Name:   "weekly-test",
 				Agent:  "Test",
 				Manual: false,
-				Cron:   "0 12 * * 6", // Saturday at noon UTC only
+				Crons:  []string{"0 12 * * 6"}, // Saturday at noon UTC only
 			},
 			{
 				Name:   "manual-deploy",
 				Agent:  "Deploy",
 				Manual: true,
-				Cron:   "", // Manual only
+				Crons:  nil, // Manual only
 			},
 		}
 
@@ -251,7 +251,7 @@ func TestWorkflowIntegration_WorkflowContentValidation(t *testing.T) {
 			Agent:  "Build",
 			Prompt: "Test with special chars: \"quotes\" and 'apostrophes'",
 			Manual: true,
-			Cron:   "30 14 * * 1,3,5", // Mon,Wed,Fri at 14:30 UTC
+			Crons:  []string{"30 14 * * 1,3,5"}, // Mon,Wed,Fri at 14:30 UTC
 		}
 
 		saved, err := SaveNewTender(root, tender)
@@ -293,9 +293,9 @@ func TestWorkflowIntegration_WorkflowContentValidation(t *testing.T) {
 		}
 
 		// Verify it can be parsed back
-		parsed, ok := parseTenderWorkflow(workflowText)
-		if !ok {
-			t.Fatal("generated workflow cannot be parsed back")
+		parsed, err := parseTenderWorkflow(workflowText)
+		if err != nil {
+			t.Fatalf("generated workflow cannot be parsed back: %v", err)
 		}
 		if parsed.Name != tender.Name {
 			t.Fatalf("parsed name mismatch: expected %q, got %q", tender.Name, parsed.Name)
@@ -306,8 +306,8 @@ func TestWorkflowIntegration_WorkflowContentValidation(t *testing.T) {
 		if !parsed.Manual {
 			t.Fatal("parsed manual should be true")
 		}
-		if parsed.Cron != tender.Cron {
-			t.Fatalf("parsed cron mismatch: expected %q, got %q", tender.Cron, parsed.Cron)
+		if strings.Join(parsed.Crons, "; ") != strings.Join(tender.Crons, "; ") {
+			t.Fatalf("parsed cron mismatch: expected %q, got %q", tender.Crons, parsed.Crons)
 		}
 		if parsed.TimeoutMinutes != DefaultTimeoutMinutes {
 			t.Fatalf("parsed timeout mismatch: expected %d, got %d", DefaultTimeoutMinutes, parsed.TimeoutMinutes)
@@ -326,7 +326,7 @@ func TestWorkflowIntegration_PerformanceAndScalability(t *testing.T) {
 				Name:   fmt.Sprintf("tender-%03d", i),
 				Agent:  "Build",
 				Manual: i%2 == 0, // Alternate between manual and schedule-only
-				Cron:   fmt.Sprintf("%d %d * * *", i%60, i%24),
+				Crons:  []string{fmt.Sprintf("%d %d * * *", i%60, i%24)},
 			}
 			_, err := SaveNewTender(root, tender)
 			if err != nil {
@@ -371,7 +371,7 @@ func TestWorkflowIntegration_PerformanceAndScalability(t *testing.T) {
 			Name:   "updated-" + originalName,
 			Agent:  "Test",
 			Manual: true,
-			Cron:   "0 0 * * *",
+			Crons:  []string{"0 0 * * *"},
 		}
 
 		err = UpdateTender(root, originalName, updated)
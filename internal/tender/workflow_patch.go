@@ -0,0 +1,621 @@
+package tender
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PatchWorkflow rewrites only the fields tender owns (name, triggers, TENDER_*
+// env, timeout, permissions, concurrency group and the steps it generates) in an existing tender
+// workflow. Everything else, including user-added steps, env vars, comments and
+// blank lines, is left byte-for-byte untouched. A generated step is only
+// rewritten when tender's rendering of it changes, so hand edits to it survive
+// unrelated updates. Each edit splices the original lines using yaml.v3 node
+// positions, then re-parses so later edits see fresh line numbers.
+func PatchWorkflow(content string, t Tender) (string, error) {
+	current, err := parseTenderWorkflow(content)
+	if err != nil {
+		return "", err
+	}
+	var doc workflowDocument
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return "", err
+	}
+	jobKey, _, err := findTenderJob(doc.Jobs)
+	if err != nil {
+		return "", err
+	}
+
+	e := newWorkflowEditor(content)
+	if err := e.setScalar(nil, workflowName(t), "", true); err != nil {
+		return "", err
+	}
+	if err := e.patchTriggers(t, current); err != nil {
+		return "", err
+	}
+
+	job := []string{"jobs", jobKey}
+	if condition := jobCondition(t); condition != "" {
+		if err := e.setScalarIfValue(job, plainScalar("if", condition), jobCondition(current)); err != nil {
+			return "", err
+		}
+	} else if err := e.deleteKeyIfValue(job, "if", jobCondition(current)); err != nil {
+		return "", err
+	}
+	if err := e.setScalar(job, timeoutScalar(t), "runs-on", false); err != nil {
+		return "", err
+	}
+	if err := e.patchEnv(job, tenderEnv(current), tenderEnv(t)); err != nil {
+		return "", err
+	}
+	// Workflows without a permissions mapping run with the repository's default
+	// token permissions; that choice is left alone.
+	if e.hasMapping([]string{"permissions"}) {
+		if err := e.patchScalars([]string{"permissions"}, renderPermissions(current), renderPermissions(t)); err != nil {
+			return "", err
+		}
+	}
+	if group := concurrencyGroup(t); group != concurrencyGroup(current) && e.hasMapping([]string{"concurrency"}) {
+		if err := e.setScalar([]string{"concurrency"}, group, "", false); err != nil {
+			return "", err
+		}
+	}
+	if err := e.patchSteps([]string{"jobs", jobKey, "steps"}, renderSteps(current), renderSteps(t)); err != nil {
+		return "", err
+	}
+	return e.String(), nil
+}
+
+func (e *workflowEditor) patchTriggers(t Tender, current Tender) error {
+	path := []string{"on"}
+	root, err := e.root()
+	if err != nil {
+		return err
+	}
+	on, _, ok := e.lookupMapping(root, path)
+	if !ok || on.Style&yaml.FlowStyle != 0 {
+		// `on: push`, `on: [push]` and flow mappings have nowhere to splice
+		// into, so tender takes over the whole entry.
+		return e.replaceEntry(nil, "on", append([]string{"on:"}, indentLines("  ", flattenBlocks(renderTriggers(t)))...), "")
+	}
+
+	// Upsert before deleting so the mapping is never left empty mid-edit.
+	// A trigger is only rewritten when tender's rendering of it changes, so
+	// hand-added filters survive unrelated updates.
+	previous := map[string][]string{}
+	for _, block := range renderTriggers(current) {
+		previous[block.Key] = block.Lines
+	}
+	blocks := renderTriggers(t)
+	wanted := map[string]bool{}
+	after := ""
+	for _, block := range blocks {
+		wanted[block.Key] = true
+		if old, ok := previous[block.Key]; ok && !sameLines(old, block.Lines) {
+			err = e.replaceEntry(path, block.Key, block.Lines, after)
+		} else {
+			err = e.insertEntryIfMissing(path, block.Key, block.Lines, after)
+		}
+		if err != nil {
+			return err
+		}
+		after = block.Key
+	}
+	for _, key := range []string{"workflow_dispatch", "push", "schedule", "issues", "issue_comment", "pull_request", "workflow_run"} {
+		if wanted[key] {
+			continue
+		}
+		if err := e.deleteKey(path, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flattenBlocks(blocks []workflowBlock) []string {
+	out := make([]string, 0)
+	for _, block := range blocks {
+		out = append(out, block.Lines...)
+	}
+	return out
+}
+
+func indentLines(indent string, lines []string) []string {
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, indent+line)
+	}
+	return out
+}
+
+// workflowEditor applies line-level edits to a YAML document.
+type workflowEditor struct {
+	lines []string
+}
+
+func newWorkflowEditor(content string) *workflowEditor {
+	return &workflowEditor{lines: strings.Split(content, "\n")}
+}
+
+func (e *workflowEditor) String() string {
+	return strings.Join(e.lines, "\n")
+}
+
+func (e *workflowEditor) root() (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(e.String()), &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("workflow is not a YAML mapping")
+	}
+	return doc.Content[0], nil
+}
+
+// lookupMapping walks path from the document root and returns the mapping found
+// there together with the exclusive line index its entries must stay above.
+func (e *workflowEditor) lookupMapping(root *yaml.Node, path []string) (*yaml.Node, int, bool) {
+	node := root
+	limit := len(e.lines)
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode || node.Style&yaml.FlowStyle != 0 {
+			return nil, 0, false
+		}
+		i := mappingIndex(node, key)
+		if i < 0 {
+			return nil, 0, false
+		}
+		_, end := e.entryRange(node, i, limit)
+		node = node.Content[i+1]
+		limit = end + 1
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, 0, false
+	}
+	return node, limit, true
+}
+
+func (e *workflowEditor) mapping(path []string) (*yaml.Node, int, error) {
+	root, err := e.root()
+	if err != nil {
+		return nil, 0, err
+	}
+	m, limit, ok := e.lookupMapping(root, path)
+	if !ok {
+		return nil, 0, fmt.Errorf("cannot update %s: expected a block mapping", strings.Join(path, "."))
+	}
+	if m.Style&yaml.FlowStyle != 0 {
+		return nil, 0, fmt.Errorf("cannot update %s: flow-style mappings are not supported", strings.Join(path, "."))
+	}
+	return m, limit, nil
+}
+
+func (e *workflowEditor) hasMapping(path []string) bool {
+	root, err := e.root()
+	if err != nil {
+		return false
+	}
+	_, _, ok := e.lookupMapping(root, path)
+	return ok
+}
+
+func mappingIndex(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// entryRange returns the inclusive 0-based line span of the entry whose key is
+// m.Content[i]. Trailing blank lines and comments indented no deeper than the
+// key are left to whatever follows.
+func (e *workflowEditor) entryRange(m *yaml.Node, i int, limit int) (int, int) {
+	key := m.Content[i]
+	start := key.Line - 1
+	next := limit
+	if i+2 < len(m.Content) {
+		next = m.Content[i+2].Line - 1
+	}
+	end := next - 1
+	indent := key.Column - 1
+	for end > start {
+		line := e.lines[end]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || (strings.HasPrefix(trimmed, "#") && leadingSpaces(line) <= indent) {
+			end--
+			continue
+		}
+		break
+	}
+	return start, end
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// childIndent is the indentation used for new keys in m.
+func childIndent(m *yaml.Node, parentIndent int) string {
+	if len(m.Content) > 0 {
+		return strings.Repeat(" ", m.Content[0].Column-1)
+	}
+	return strings.Repeat(" ", parentIndent+2)
+}
+
+// insertionLine picks where a new entry goes: after the entry for `after` when
+// present, before the first entry when `after` is empty, and at the end of the
+// mapping otherwise.
+func (e *workflowEditor) insertionLine(m *yaml.Node, limit int, after string) int {
+	if len(m.Content) == 0 {
+		return limit
+	}
+	if after == "" {
+		return m.Content[0].Line - 1
+	}
+	if i := mappingIndex(m, after); i >= 0 {
+		_, end := e.entryRange(m, i, limit)
+		return end + 1
+	}
+	_, end := e.entryRange(m, len(m.Content)-2, limit)
+	return end + 1
+}
+
+func (e *workflowEditor) splice(start, end int, replacement []string) {
+	out := make([]string, 0, len(e.lines)-(end-start)+len(replacement))
+	out = append(out, e.lines[:start]...)
+	out = append(out, replacement...)
+	out = append(out, e.lines[end:]...)
+	e.lines = out
+}
+
+// setScalar writes a single-line value for s.Key in the mapping at path. An
+// unchanged value is left as written. For missing keys, `first` inserts at the
+// top of the mapping and `after` names the sibling to follow.
+func (e *workflowEditor) setScalar(path []string, s workflowScalar, after string, first bool) error {
+	m, limit, err := e.mapping(path)
+	if err != nil {
+		return err
+	}
+	i := mappingIndex(m, s.Key)
+	if i < 0 {
+		at := e.insertionLine(m, limit, after)
+		if first {
+			at = e.insertionLine(m, limit, "")
+		}
+		e.splice(at, at, []string{childIndent(m, 0) + s.line()})
+		return nil
+	}
+
+	key, value := m.Content[i], m.Content[i+1]
+	if value.Kind == yaml.ScalarNode && value.Value == s.Value {
+		return nil
+	}
+	start, end := e.entryRange(m, i, limit)
+	comment := value.LineComment
+	if comment == "" {
+		comment = key.LineComment
+	}
+	line := strings.Repeat(" ", key.Column-1) + s.line()
+	sameLine := value.Kind == yaml.ScalarNode && value.Line == key.Line &&
+		value.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 && start == end
+	if sameLine {
+		// Keep the key exactly as written and replace only the value text.
+		runes := []rune(e.lines[start])
+		if col := value.Column - 1; col <= len(runes) {
+			line = string(runes[:col]) + s.Encoded
+		}
+	}
+	if comment != "" {
+		line += " " + comment
+	}
+	e.splice(start, end+1, []string{line})
+	return nil
+}
+
+// setScalarIfValue writes s when its key is missing or still holds old, the
+// value tender wrote before, so a hand-written value survives.
+func (e *workflowEditor) setScalarIfValue(path []string, s workflowScalar, old string) error {
+	m, _, err := e.mapping(path)
+	if err != nil {
+		return err
+	}
+	i := mappingIndex(m, s.Key)
+	if i < 0 {
+		return e.setScalar(path, s, "", true)
+	}
+	if m.Content[i+1].Kind != yaml.ScalarNode || m.Content[i+1].Value != old {
+		return nil
+	}
+	return e.setScalar(path, s, "", false)
+}
+
+func (e *workflowEditor) deleteKey(path []string, key string) error {
+	m, limit, err := e.mapping(path)
+	if err != nil {
+		return err
+	}
+	i := mappingIndex(m, key)
+	if i < 0 {
+		return nil
+	}
+	start, end := e.entryRange(m, i, limit)
+	e.splice(start, end+1, nil)
+	return nil
+}
+
+// deleteKeyIfValue removes key only while it still holds the value tender wrote,
+// so a hand-written condition survives.
+func (e *workflowEditor) deleteKeyIfValue(path []string, key string, value string) error {
+	m, _, err := e.mapping(path)
+	if err != nil {
+		return err
+	}
+	i := mappingIndex(m, key)
+	if i < 0 || m.Content[i+1].Kind != yaml.ScalarNode || m.Content[i+1].Value != value {
+		return nil
+	}
+	return e.deleteKey(path, key)
+}
+
+// replaceEntry swaps the entry for key with lines (relative to the mapping's
+// indentation), inserting it when missing.
+func (e *workflowEditor) replaceEntry(path []string, key string, lines []string, after string) error {
+	m, limit, err := e.mapping(path)
+	if err != nil {
+		return err
+	}
+	indent := childIndent(m, 0)
+	i := mappingIndex(m, key)
+	if i < 0 {
+		at := e.insertionLine(m, limit, after)
+		e.splice(at, at, indentLines(indent, lines))
+		return nil
+	}
+	start, end := e.entryRange(m, i, limit)
+	e.splice(start, end+1, indentLines(strings.Repeat(" ", m.Content[i].Column-1), lines))
+	return nil
+}
+
+func (e *workflowEditor) insertEntryIfMissing(path []string, key string, lines []string, after string) error {
+	m, _, err := e.mapping(path)
+	if err != nil {
+		return err
+	}
+	if mappingIndex(m, key) >= 0 {
+		return nil
+	}
+	return e.replaceEntry(path, key, lines, after)
+}
+
+// patchEnv sets the tender-owned env vars on the job, creating the env mapping
+// after timeout-minutes when the job has none.
+func (e *workflowEditor) patchEnv(job []string, from, to []workflowScalar) error {
+	root, err := e.root()
+	if err != nil {
+		return err
+	}
+	env := append(append([]string{}, job...), "env")
+	if _, _, ok := e.lookupMapping(root, env); ok {
+		return e.patchScalars(env, from, to)
+	}
+
+	m, _, err := e.mapping(job)
+	if err != nil {
+		return err
+	}
+	if i := mappingIndex(m, "env"); i >= 0 {
+		value := m.Content[i+1]
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!null" {
+			return fmt.Errorf("cannot update %s.env: expected a block mapping", strings.Join(job, "."))
+		}
+	}
+	lines := []string{"env:"}
+	for _, v := range to {
+		lines = append(lines, "  "+v.line())
+	}
+	return e.replaceEntry(job, "env", lines, "timeout-minutes")
+}
+
+// patchScalars sets every scalar in `to` and removes the ones tender rendered
+// for the old settings but no longer wants, unless they were hand-edited.
+func (e *workflowEditor) patchScalars(path []string, from, to []workflowScalar) error {
+	wanted := map[string]bool{}
+	after := ""
+	for _, s := range to {
+		wanted[s.Key] = true
+		if err := e.setScalar(path, s, after, false); err != nil {
+			return err
+		}
+		after = s.Key
+	}
+	for _, s := range from {
+		if wanted[s.Key] {
+			continue
+		}
+		if err := e.deleteKeyIfValue(path, s.Key, s.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchSteps brings the generated steps in line with `to`. Steps are matched by
+// stepKey; a step whose rendering is the same under both settings is left as
+// written, a step only `to` renders is inserted, and steps tender never
+// generated are never touched.
+func (e *workflowEditor) patchSteps(path []string, from, to []workflowBlock) error {
+	previous := map[string][]string{}
+	for _, block := range from {
+		previous[block.Key] = block.Lines
+	}
+	wanted := map[string]bool{}
+	after := ""
+	for _, block := range to {
+		wanted[block.Key] = true
+		old, rendered := previous[block.Key]
+		var err error
+		switch {
+		case !rendered:
+			err = e.upsertStep(path, block, after)
+		case !sameLines(old, block.Lines):
+			// A generated step the user deleted stays deleted.
+			err = e.replaceStepIfPresent(path, block)
+		}
+		if err != nil {
+			return err
+		}
+		after = block.Key
+	}
+	for _, block := range from {
+		if wanted[block.Key] {
+			continue
+		}
+		if err := e.deleteStep(path, block.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sameLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// stepKey identifies a step by its name, falling back to the action it uses.
+func stepKey(item *yaml.Node) string {
+	if item.Kind != yaml.MappingNode {
+		return ""
+	}
+	if i := mappingIndex(item, "name"); i >= 0 {
+		return item.Content[i+1].Value
+	}
+	if i := mappingIndex(item, "uses"); i >= 0 {
+		return "uses:" + item.Content[i+1].Value
+	}
+	return ""
+}
+
+func (e *workflowEditor) sequence(path []string) (*yaml.Node, int, error) {
+	root, err := e.root()
+	if err != nil {
+		return nil, 0, err
+	}
+	parent, limit, ok := e.lookupMapping(root, path[:len(path)-1])
+	if !ok {
+		return nil, 0, fmt.Errorf("cannot update %s: expected a block mapping", strings.Join(path[:len(path)-1], "."))
+	}
+	i := mappingIndex(parent, path[len(path)-1])
+	if i < 0 {
+		return nil, 0, fmt.Errorf("cannot update %s: missing", strings.Join(path, "."))
+	}
+	seq := parent.Content[i+1]
+	if seq.Kind != yaml.SequenceNode || seq.Style&yaml.FlowStyle != 0 || len(seq.Content) == 0 {
+		return nil, 0, fmt.Errorf("cannot update %s: expected a block list", strings.Join(path, "."))
+	}
+	_, end := e.entryRange(parent, i, limit)
+	return seq, end + 1, nil
+}
+
+func sequenceIndex(seq *yaml.Node, key string) int {
+	for i, item := range seq.Content {
+		if stepKey(item) == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// itemRange returns the inclusive 0-based line span of seq.Content[i],
+// starting at its "- " line.
+func (e *workflowEditor) itemRange(seq *yaml.Node, i int, limit int) (int, int) {
+	start := seq.Content[i].Line - 1
+	next := limit
+	if i+1 < len(seq.Content) {
+		next = seq.Content[i+1].Line - 1
+	}
+	end := next - 1
+	indent := leadingSpaces(e.lines[start])
+	for end > start {
+		line := e.lines[end]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || (strings.HasPrefix(trimmed, "#") && leadingSpaces(line) <= indent) {
+			end--
+			continue
+		}
+		break
+	}
+	return start, end
+}
+
+func (e *workflowEditor) upsertStep(path []string, block workflowBlock, after string) error {
+	seq, limit, err := e.sequence(path)
+	if err != nil {
+		return err
+	}
+	i := sequenceIndex(seq, block.Key)
+	if i < 0 {
+		return e.insertStep(path, block, after)
+	}
+	start, end := e.itemRange(seq, i, limit)
+	e.splice(start, end+1, indentLines(strings.Repeat(" ", leadingSpaces(e.lines[start])), block.Lines))
+	return nil
+}
+
+func (e *workflowEditor) replaceStepIfPresent(path []string, block workflowBlock) error {
+	seq, _, err := e.sequence(path)
+	if err != nil {
+		return err
+	}
+	if sequenceIndex(seq, block.Key) < 0 {
+		return nil
+	}
+	return e.upsertStep(path, block, "")
+}
+
+// insertStep adds block after the step keyed `after` (or first when that step
+// is missing), separated from its neighbours by a blank line.
+func (e *workflowEditor) insertStep(path []string, block workflowBlock, after string) error {
+	seq, limit, err := e.sequence(path)
+	if err != nil {
+		return err
+	}
+	indent := strings.Repeat(" ", leadingSpaces(e.lines[seq.Content[0].Line-1]))
+	lines := indentLines(indent, block.Lines)
+	if i := sequenceIndex(seq, after); after != "" && i >= 0 {
+		_, end := e.itemRange(seq, i, limit)
+		e.splice(end+1, end+1, append([]string{""}, lines...))
+		return nil
+	}
+	at := seq.Content[0].Line - 1
+	e.splice(at, at, append(lines, ""))
+	return nil
+}
+
+func (e *workflowEditor) deleteStep(path []string, key string) error {
+	seq, limit, err := e.sequence(path)
+	if err != nil {
+		return err
+	}
+	i := sequenceIndex(seq, key)
+	if i < 0 {
+		return nil
+	}
+	start, end := e.itemRange(seq, i, limit)
+	if start > 0 && strings.TrimSpace(e.lines[start-1]) == "" {
+		start--
+	}
+	e.splice(start, end+1, nil)
+	return nil
+}
@@ -0,0 +1,231 @@
+package tender
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// workflow_patch.go tests
+
+const handEditedWorkflow = `# Maintained by the platform team.
+name: "tender/nightly"
+
+on:
+  workflow_dispatch:
+    inputs:
+      prompt:
+        description: "Optional prompt override"
+        required: false
+        default: ""
+        type: string
+  schedule:
+    - cron: "0 9 * * *" # morning run
+
+permissions:
+  contents: write
+
+jobs:
+  tender:
+    runs-on: ubuntu-latest
+    timeout-minutes: 30
+    env:
+      TENDER_NAME: "nightly"
+      TENDER_AGENT: "Build"
+      TENDER_PROMPT: "old prompt"
+      # Extra tooling config.
+      GOFLAGS: "-mod=mod"
+    steps:
+      - uses: actions/checkout@v4
+
+      # Warm the module cache before the agent runs.
+      - name: Warm cache
+        run: go mod download
+
+      - name: Run OpenCode
+        run: |
+          opencode run --agent "$TENDER_AGENT" "$TENDER_PROMPT"
+`
+
+func TestPatchWorkflow(t *testing.T) {
+	t.Run("rewrites owned fields and keeps hand edits", func(t *testing.T) {
+		updated := Tender{
+			Name:           "nightly",
+			Agent:          "TendTests",
+			Prompt:         "new prompt",
+			Crons:          []string{"0 9 * * *"},
+			Manual:         true,
+			TimeoutMinutes: 45,
+		}
+
+		got, err := PatchWorkflow(handEditedWorkflow, updated)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+
+		want := strings.NewReplacer(
+			`TENDER_AGENT: "Build"`, `TENDER_AGENT: "TendTests"`,
+			`TENDER_PROMPT: "old prompt"`, `TENDER_PROMPT: "new prompt"`,
+			`timeout-minutes: 30`, `timeout-minutes: 45`,
+		).Replace(handEditedWorkflow)
+		if got != want {
+			t.Fatalf("unexpected patch result:\n--- got ---\n%s\n--- want ---\n%s", got, want)
+		}
+	})
+
+	t.Run("leaves unchanged workflows byte-for-byte identical", func(t *testing.T) {
+		current, err := parseTenderWorkflow(handEditedWorkflow)
+		if err != nil {
+			t.Fatalf("failed to parse workflow: %v", err)
+		}
+
+		got, err := PatchWorkflow(handEditedWorkflow, current)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if got != handEditedWorkflow {
+			t.Fatalf("expected no changes, got:\n%s", got)
+		}
+	})
+
+	t.Run("switches triggers without touching steps", func(t *testing.T) {
+		updated := Tender{Name: "renamed", Agent: "Build", Push: true, TimeoutMinutes: 30}
+
+		got, err := PatchWorkflow(handEditedWorkflow, updated)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+
+		parsed, err := parseTenderWorkflow(got)
+		if err != nil {
+			t.Fatalf("patched workflow does not parse: %v\n%s", err, got)
+		}
+		if parsed.Name != "renamed" || parsed.Manual || !parsed.Push || len(parsed.Crons) != 0 {
+			t.Fatalf("unexpected parsed tender: %+v", parsed)
+		}
+		if !containsAll(got,
+			`name: "tender/renamed"`,
+			`TENDER_NAME: "renamed"`,
+			"    if: "+pushLoopGuard+"\n    runs-on: ubuntu-latest",
+			"# Maintained by the platform team.",
+			"# Warm the module cache before the agent runs.",
+			"      - name: Warm cache\n        run: go mod download\n\n",
+			`GOFLAGS: "-mod=mod"`,
+		) {
+			t.Fatalf("patched workflow lost content:\n%s", got)
+		}
+		if strings.Contains(got, "schedule:") || strings.Contains(got, "workflow_dispatch:") {
+			t.Fatalf("expected disabled triggers to be removed:\n%s", got)
+		}
+	})
+
+	t.Run("keeps a hand-written job condition when push is disabled", func(t *testing.T) {
+		content := strings.Replace(handEditedWorkflow, "    runs-on: ubuntu-latest\n", "    if: github.repository_owner == 'acme'\n    runs-on: ubuntu-latest\n", 1)
+		current, err := parseTenderWorkflow(content)
+		if err != nil {
+			t.Fatalf("failed to parse workflow: %v", err)
+		}
+
+		got, err := PatchWorkflow(content, current)
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		if !strings.Contains(got, "if: github.repository_owner == 'acme'") {
+			t.Fatalf("expected custom condition to survive:\n%s", got)
+		}
+	})
+
+	t.Run("replaces shorthand on syntax", func(t *testing.T) {
+		content := `name: "tender/short"
+on: workflow_dispatch
+jobs:
+  tender:
+    runs-on: ubuntu-latest
+    env:
+      TENDER_AGENT: "Build"
+    steps:
+      - run: opencode run --agent "$TENDER_AGENT"
+`
+		got, err := PatchWorkflow(content, Tender{Name: "short", Agent: "Build", Manual: true, Crons: []string{"0 6 * * 1"}})
+		if err != nil {
+			t.Fatalf("PatchWorkflow returned error: %v", err)
+		}
+		parsed, err := parseTenderWorkflow(got)
+		if err != nil {
+			t.Fatalf("patched workflow does not parse: %v\n%s", err, got)
+		}
+		if !parsed.Manual || strings.Join(parsed.Crons, "; ") != "0 6 * * 1" {
+			t.Fatalf("unexpected parsed tender: %+v", parsed)
+		}
+		if !strings.Contains(got, "    timeout-minutes: 30\n    env:") {
+			t.Fatalf("expected timeout inserted after runs-on:\n%s", got)
+		}
+	})
+
+	t.Run("matches a fresh render when patching generated workflows", func(t *testing.T) {
+		variants := []Tender{
+			{Name: "a", Agent: "Build", Manual: true},
+			{Name: "a", Agent: "Build", Push: true, TimeoutMinutes: 10},
+			{Name: "b", Agent: "Test", Crons: []string{"15 * * * *"}, Prompt: "p"},
+			{Name: "b", Agent: "Test", Manual: true, Push: true, Crons: []string{"0 9 * * 1-5"}},
+			{Name: "b", Agent: "Test", Manual: true, Delivery: DeliveryPR},
+			{Name: "c", Agent: "Build", Push: true, Delivery: DeliveryPR, TimeoutMinutes: 10},
+			{Name: "c", Agent: "Build", Push: true, Branch: "develop"},
+			{Name: "c", Agent: "Build", Push: true, Branch: "develop", Model: "groq/llama-3.1-8b-instant"},
+			{Name: "a", Agent: "Build", Manual: true, Model: "anthropic/claude-sonnet-4"},
+			{Name: "c", Agent: "Build", Manual: true, Delivery: DeliveryPR, Branch: "release/2.0"},
+		}
+		for _, from := range variants {
+			for _, to := range variants {
+				got, err := PatchWorkflow(RenderWorkflow(from), to)
+				if err != nil {
+					t.Fatalf("PatchWorkflow(%+v -> %+v) returned error: %v", from, to, err)
+				}
+				if want := RenderWorkflow(to); got != want {
+					t.Fatalf("PatchWorkflow(%+v -> %+v) differs from render:\n--- got ---\n%s\n--- want ---\n%s", from, to, got, want)
+				}
+			}
+		}
+	})
+
+	t.Run("refuses flow-style env it cannot splice", func(t *testing.T) {
+		content := `name: "tender/flow"
+on:
+  workflow_dispatch:
+jobs:
+  tender:
+    runs-on: ubuntu-latest
+    env: {TENDER_AGENT: "Build"}
+    steps:
+      - run: opencode run --agent "$TENDER_AGENT"
+`
+		if _, err := PatchWorkflow(content, Tender{Name: "flow", Agent: "Other", Manual: true}); err == nil {
+			t.Fatal("expected flow-style env to be rejected")
+		}
+	})
+}
+
+func TestUpdateTenderPreservesHandEdits(t *testing.T) {
+	root := t.TempDir()
+	if err := EnsureWorkflowDir(root); err != nil {
+		t.Fatalf("failed to create workflow dir: %v", err)
+	}
+	path := filepath.Join(root, WorkflowDir, "nightly.yml")
+	if err := os.WriteFile(path, []byte(handEditedWorkflow), 0o644); err != nil {
+		t.Fatalf("failed to write workflow: %v", err)
+	}
+
+	if err := UpdateTender(root, "nightly", Tender{Name: "nightly", Agent: "Build", Prompt: "old prompt", Manual: true, Crons: []string{"30 6 * * *"}}); err != nil {
+		t.Fatalf("UpdateTender returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read workflow: %v", err)
+	}
+	want := strings.Replace(handEditedWorkflow, `    - cron: "0 9 * * *" # morning run`, `    - cron: "30 6 * * *"`, 1)
+	if string(content) != want {
+		t.Fatalf("unexpected workflow after update:\n%s", content)
+	}
+}
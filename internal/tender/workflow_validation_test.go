@@ -57,7 +57,7 @@ func TestWorkflowContentValidation(t *testing.T) {
 		tender := Tender{
 			Name:   "scheduled-workflow",
 			Agent:  "Deploy",
-			Cron:   "0 9 * * *",
+			Crons:  []string{"0 9 * * *"},
 			Manual: false,
 		}
 
@@ -81,7 +81,7 @@ func TestWorkflowContentValidation(t *testing.T) {
 		tender := Tender{
 			Name:   "hybrid-workflow",
 			Agent:  "Test",
-			Cron:   "30 14 * * 1,3,5",
+			Crons:  []string{"30 14 * * 1,3,5"},
 			Manual: true,
 		}
 
@@ -141,7 +141,7 @@ func TestWorkflowContentValidation(t *testing.T) {
 			Name:   "round-trip-test",
 			Agent:  "Test Agent",
 			Prompt: "Round trip test prompt",
-			Cron:   "15 */2 * * *",
+			Crons:  []string{"15 */2 * * *"},
 			Manual: true,
 		}
 
@@ -171,8 +171,8 @@ func TestWorkflowContentValidation(t *testing.T) {
 		if reloaded.Prompt != original.Prompt {
 			t.Fatalf("prompt not preserved: expected %q, got %q", original.Prompt, reloaded.Prompt)
 		}
-		if reloaded.Cron != original.Cron {
-			t.Fatalf("cron not preserved: expected %q, got %q", original.Cron, reloaded.Cron)
+		if strings.Join(reloaded.Crons, "; ") != strings.Join(original.Crons, "; ") {
+			t.Fatalf("cron not preserved: expected %q, got %q", original.Crons, reloaded.Crons)
 		}
 		if reloaded.Manual != original.Manual {
 			t.Fatalf("manual flag not preserved: expected %v, got %v", original.Manual, reloaded.Manual)
@@ -367,7 +367,7 @@ func TestWorkflowYAMLStructure(t *testing.T) {
 				tender: Tender{
 					Name:   "complex-cron",
 					Agent:  "Deploy",
-					Cron:   "*/15 2,14 * * 1-5",
+					Crons:  []string{"*/15 2,14 * * 1-5"},
 					Manual: false,
 				},
 			},
@@ -59,13 +59,23 @@ function printHelp() {
   process.stdout.write("  add             Add a tender non-interactively (agent-friendly)\n");
   process.stdout.write("  update          Update a tender non-interactively (agent-friendly)\n");
   process.stdout.write("  ls              List managed tender workflows\n");
-  process.stdout.write("  run             Trigger an on-demand tender now via GitHub CLI\n");
+  process.stdout.write("  status          Show recent GitHub Actions runs per tender\n");
+  process.stdout.write("  logs            Show the OpenCode output of a tender run\n");
+  process.stdout.write("  run             Trigger an on-demand tender now via the GitHub API\n");
   process.stdout.write("  rm              Remove a tender workflow\n");
+  process.stdout.write("  doctor          Check tender workflows for problems\n");
+  process.stdout.write("  schedule        Show the week's scheduled runs and overlaps\n");
+  process.stdout.write("  history         Show what each tender's commits changed\n");
+  process.stdout.write("  revert          Revert a tender's commits locally\n");
+  process.stdout.write("  scan-diff       Scan staged changes for secrets\n");
   process.stdout.write("  help [command]  Show command help\n\n");
   process.stdout.write("Examples:\n");
   process.stdout.write("  npx @susu-eng/tender@latest ls\n");
   process.stdout.write("  npx @susu-eng/tender@latest add --name nightly --agent Build --cron \"0 9 * * 1\"\n");
   process.stdout.write("  npx @susu-eng/tender@latest run nightly --prompt \"review and commit\"\n");
+  process.stdout.write("  npx @susu-eng/tender@latest run nightly --wait\n");
+  process.stdout.write("  npx @susu-eng/tender@latest run nightly --local\n");
+  process.stdout.write("  npx @susu-eng/tender@latest run nightly --input focus=tests\n");
   process.stdout.write("  npx @susu-eng/tender@latest help add\n");
 }
 
@@ -85,9 +85,11 @@ make npx-pack-smoke
 
 echo "==> Setting package version to $VERSION"
 npm version --no-git-tag-version "$VERSION" >/dev/null
+sed -i.bak -E "s/^const Version = \".*\"$/const Version = \"$VERSION\"/" internal/tender/version.go
+rm -f internal/tender/version.go.bak
 
 echo "==> Committing version bump"
-git add package.json
+git add package.json internal/tender/version.go
 git commit -m "release: $TAG"
 
 echo "==> Creating tag $TAG"